@@ -1,4 +1,129 @@
+// Package api provides a gRPC server for controlling a running V2Ray instance.
 package api
 
+//go:generate go run $GOPATH/src/v2ray.com/core/tools/generrorgen/main.go -pkg api -path App,Api
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/log"
+	"v2ray.com/core/common"
+	v2net "v2ray.com/core/common/net"
+)
+
+// Service is a gRPC service that can be served by ApiServer.
+type Service interface {
+	// Register registers the service onto the given gRPC server.
+	Register(server *grpc.Server)
+}
+
+// ServiceCreator creates a Service based on the apps in the given space.
+// It may return a nil Service if the apps it depends on are not available.
+type ServiceCreator func(space app.Space) (Service, error)
+
+var (
+	serviceCreators []ServiceCreator
+)
+
+// RegisterService registers a creator of a service that will be served by every ApiServer.
+func RegisterService(creator ServiceCreator) error {
+	serviceCreators = append(serviceCreators, creator)
+	return nil
+}
+
+// ApiServer is an application that serves gRPC services for controlling V2Ray at runtime.
 type ApiServer struct {
+	sync.Mutex
+	config   *Config
+	server   *grpc.Server
+	listener net.Listener
+}
+
+// New creates a new ApiServer with the given config.
+func New(ctx context.Context, config *Config) (*ApiServer, error) {
+	space := app.SpaceFromContext(ctx)
+	if space == nil {
+		return nil, newError("no space in context")
+	}
+
+	s := &ApiServer{
+		config: config,
+		server: grpc.NewServer(),
+	}
+
+	space.OnInitialize(func() error {
+		for _, creator := range serviceCreators {
+			service, err := creator(space)
+			if err != nil {
+				return newError("failed to create service").Base(err)
+			}
+			if service != nil {
+				service.Register(s.server)
+			}
+		}
+		return nil
+	})
+
+	return s, nil
+}
+
+// Interface implements app.Application.
+func (*ApiServer) Interface() interface{} {
+	return (*ApiServer)(nil)
+}
+
+// Start implements app.Application.
+func (s *ApiServer) Start() error {
+	address := v2net.LocalHostIP
+	if s.config.Listen != nil {
+		address = s.config.Listen.AsAddress()
+	}
+	listener, err := net.Listen("tcp", v2net.TCPDestination(address, v2net.Port(s.config.DirectPort)).NetAddr())
+	if err != nil {
+		return newError("failed to listen on ", address, ":", s.config.DirectPort).Base(err)
+	}
+
+	s.Lock()
+	s.listener = listener
+	s.Unlock()
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil {
+			log.Trace(newError("API server stopped").Base(err).AtInfo())
+		}
+	}()
+
+	log.Trace(newError("API server listening on ", listener.Addr()).AtWarning())
+	return nil
+}
+
+// Close implements app.Application.
+func (s *ApiServer) Close() {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.listener != nil {
+		s.server.Stop()
+		s.listener = nil
+	}
+}
+
+// FromSpace returns the ApiServer in the given space, or nil if it doesn't exist.
+func FromSpace(space app.Space) *ApiServer {
+	app := space.GetApplication((*ApiServer)(nil))
+	if app == nil {
+		return nil
+	}
+	return app.(*ApiServer)
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return New(ctx, config.(*Config))
+	}))
 }
@@ -0,0 +1,621 @@
+package api_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"v2ray.com/core"
+	. "v2ray.com/core/app/api"
+	"v2ray.com/core/app/conntrack"
+	"v2ray.com/core/app/proxyman"
+	"v2ray.com/core/app/router"
+	"v2ray.com/core/app/stats"
+	"v2ray.com/core/common"
+	v2net "v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
+	"v2ray.com/core/common/serial"
+	"v2ray.com/core/common/uuid"
+	_ "v2ray.com/core/main/distro/all"
+	"v2ray.com/core/proxy/blackhole"
+	"v2ray.com/core/proxy/dokodemo"
+	"v2ray.com/core/proxy/freedom"
+	"v2ray.com/core/proxy/vmess"
+	vmessinbound "v2ray.com/core/proxy/vmess/inbound"
+	"v2ray.com/core/testing/assert"
+	"v2ray.com/core/testing/servers/tcp"
+)
+
+func pickPort() v2net.Port {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	return v2net.Port(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func xor(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i, v := range b {
+		r[i] = v ^ 'c'
+	}
+	return r
+}
+
+func TestAddHandlers(t *testing.T) {
+	assert := assert.On(t)
+
+	tcpServer := tcp.Server{
+		MsgProcessor: xor,
+	}
+	dest, err := tcpServer.Start()
+	assert.Error(err).IsNil()
+	defer tcpServer.Close()
+
+	apiPort := pickPort()
+	server, err := core.New(&core.Config{
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				DirectPort: uint32(apiPort),
+			}),
+		},
+	})
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	conn, err := grpc.Dial(v2net.TCPDestination(v2net.LocalHostIP, apiPort).NetAddr(), grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(time.Second*5))
+	assert.Error(err).IsNil()
+	defer conn.Close()
+
+	client := NewHandlerServiceClient(conn)
+
+	_, err = client.AddOutbound(context.Background(), &AddOutboundRequest{
+		Outbound: &proxyman.OutboundHandlerConfig{
+			Tag:           "blocked",
+			ProxySettings: serial.ToTypedMessage(&blackhole.Config{}),
+		},
+	})
+	assert.Error(err).IsNil()
+
+	inboundPort := pickPort()
+	_, err = client.AddInbound(context.Background(), &AddInboundRequest{
+		Inbound: &proxyman.InboundHandlerConfig{
+			Tag: "d",
+			ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+				PortRange: v2net.SinglePortRange(inboundPort),
+				Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+			}),
+			ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+				Address: v2net.NewIPOrDomain(dest.Address),
+				Port:    uint32(dest.Port),
+				NetworkList: &v2net.NetworkList{
+					Network: []v2net.Network{v2net.Network_TCP},
+				},
+			}),
+		},
+	})
+	assert.Error(err).IsNil()
+
+	clientConn, err := net.DialTCP("tcp", nil, &net.TCPAddr{
+		IP:   []byte{127, 0, 0, 1},
+		Port: int(inboundPort),
+	})
+	assert.Error(err).IsNil()
+
+	payload := "dokodemo request."
+	nBytes, err := clientConn.Write([]byte(payload))
+	assert.Error(err).IsNil()
+	assert.Int(nBytes).Equals(len(payload))
+
+	response := make([]byte, 1024)
+	clientConn.SetReadDeadline(time.Now().Add(time.Second * 5))
+	nBytes, err = clientConn.Read(response)
+	assert.Error(err).IsNil()
+	assert.Bytes(response[:nBytes]).Equals(xor([]byte(payload)))
+	assert.Error(clientConn.Close()).IsNil()
+
+	_, err = client.AddInbound(context.Background(), &AddInboundRequest{})
+	assert.Error(err).IsNotNil()
+}
+
+func TestRemoveHandlers(t *testing.T) {
+	assert := assert.On(t)
+
+	apiPort := pickPort()
+	inboundPort := pickPort()
+	server, err := core.New(&core.Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			{
+				Tag: "d",
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: v2net.SinglePortRange(inboundPort),
+					Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+				}),
+				ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+					Address: v2net.NewIPOrDomain(v2net.LocalHostIP),
+					Port:    uint32(apiPort),
+					NetworkList: &v2net.NetworkList{
+						Network: []v2net.Network{v2net.Network_TCP},
+					},
+				}),
+			},
+		},
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+			{
+				Tag:           "blocked",
+				ProxySettings: serial.ToTypedMessage(&blackhole.Config{}),
+			},
+		},
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				DirectPort: uint32(apiPort),
+			}),
+		},
+	})
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	conn, err := grpc.Dial(v2net.TCPDestination(v2net.LocalHostIP, apiPort).NetAddr(), grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(time.Second*5))
+	assert.Error(err).IsNil()
+	defer conn.Close()
+
+	client := NewHandlerServiceClient(conn)
+
+	// A handler with an existing tag is rejected, instead of leaving the old one unreachable.
+	_, err = client.AddInbound(context.Background(), &AddInboundRequest{
+		Inbound: &proxyman.InboundHandlerConfig{
+			Tag: "d",
+			ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+				PortRange: v2net.SinglePortRange(pickPort()),
+				Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+			}),
+			ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+				Address: v2net.NewIPOrDomain(v2net.LocalHostIP),
+				Port:    uint32(apiPort),
+				NetworkList: &v2net.NetworkList{
+					Network: []v2net.Network{v2net.Network_TCP},
+				},
+			}),
+		},
+	})
+	assert.Error(err).IsNotNil()
+
+	_, err = client.RemoveInbound(context.Background(), &RemoveInboundRequest{Tag: "d"})
+	assert.Error(err).IsNil()
+
+	_, err = net.DialTCP("tcp", nil, &net.TCPAddr{
+		IP:   []byte{127, 0, 0, 1},
+		Port: int(inboundPort),
+	})
+	assert.Error(err).IsNotNil()
+
+	_, err = client.RemoveInbound(context.Background(), &RemoveInboundRequest{Tag: "d"})
+	assert.Error(err).IsNotNil()
+
+	_, err = client.RemoveOutbound(context.Background(), &RemoveOutboundRequest{Tag: "blocked"})
+	assert.Error(err).IsNil()
+
+	_, err = client.RemoveOutbound(context.Background(), &RemoveOutboundRequest{Tag: "direct"})
+	assert.Error(err).IsNotNil()
+}
+
+func TestGetOutboundHealth(t *testing.T) {
+	assert := assert.On(t)
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer httpServer.Close()
+
+	apiPort := pickPort()
+	server, err := core.New(&core.Config{
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+			{
+				Tag:           "blocked",
+				ProxySettings: serial.ToTypedMessage(&blackhole.Config{}),
+			},
+		},
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				DirectPort: uint32(apiPort),
+			}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{
+				HealthCheck: &proxyman.HealthCheckConfig{
+					ProbeUrl:         httpServer.URL,
+					Timeout:          1,
+					FailureThreshold: 1,
+				},
+			}),
+		},
+	})
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	conn, err := grpc.Dial(v2net.TCPDestination(v2net.LocalHostIP, apiPort).NetAddr(), grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(time.Second*5))
+	assert.Error(err).IsNil()
+	defer conn.Close()
+
+	client := NewHandlerServiceClient(conn)
+
+	var response *GetOutboundHealthResponse
+	for i := 0; i < 30; i++ {
+		response, err = client.GetOutboundHealth(context.Background(), &GetOutboundHealthRequest{})
+		assert.Error(err).IsNil()
+		if response.Health[0].LastProbeTime > 0 && response.Health[1].LastProbeTime > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	assert.Int(len(response.Health)).Equals(2)
+
+	blocked := response.Health[0]
+	assert.String(blocked.Tag).Equals("blocked")
+	assert.Bool(blocked.Healthy).IsFalse()
+	assert.Bool(len(blocked.LastError) > 0).IsTrue()
+
+	direct := response.Health[1]
+	assert.String(direct.Tag).Equals("direct")
+	assert.Bool(direct.Healthy).IsTrue()
+	assert.Bool(direct.SuccessRate == 1).IsTrue()
+	assert.Bool(direct.LastProbeTime > 0).IsTrue()
+
+	_, err = client.GetOutboundHealth(context.Background(), &GetOutboundHealthRequest{Tag: []string{"unknown"}})
+	assert.Error(err).IsNotNil()
+}
+
+func TestQueryStats(t *testing.T) {
+	assert := assert.On(t)
+
+	tcpServer := tcp.Server{
+		MsgProcessor: xor,
+	}
+	dest, err := tcpServer.Start()
+	assert.Error(err).IsNil()
+	defer tcpServer.Close()
+
+	apiPort := pickPort()
+	inboundPort := pickPort()
+	server, err := core.New(&core.Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			{
+				Tag: "d",
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: v2net.SinglePortRange(inboundPort),
+					Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+				}),
+				ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+					Address: v2net.NewIPOrDomain(dest.Address),
+					Port:    uint32(dest.Port),
+					NetworkList: &v2net.NetworkList{
+						Network: []v2net.Network{v2net.Network_TCP},
+					},
+				}),
+			},
+		},
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				DirectPort: uint32(apiPort),
+			}),
+			serial.ToTypedMessage(&stats.Config{}),
+		},
+	})
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	clientConn, err := net.DialTCP("tcp", nil, &net.TCPAddr{
+		IP:   []byte{127, 0, 0, 1},
+		Port: int(inboundPort),
+	})
+	assert.Error(err).IsNil()
+
+	payload := "dokodemo request."
+	nBytes, err := clientConn.Write([]byte(payload))
+	assert.Error(err).IsNil()
+	assert.Int(nBytes).Equals(len(payload))
+
+	response := make([]byte, 1024)
+	clientConn.SetReadDeadline(time.Now().Add(time.Second * 5))
+	nBytes, err = clientConn.Read(response)
+	assert.Error(err).IsNil()
+	assert.Bytes(response[:nBytes]).Equals(xor([]byte(payload)))
+	assert.Error(clientConn.Close()).IsNil()
+
+	conn, err := grpc.Dial(v2net.TCPDestination(v2net.LocalHostIP, apiPort).NetAddr(), grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(time.Second*5))
+	assert.Error(err).IsNil()
+	defer conn.Close()
+
+	client := NewStatsServiceClient(conn)
+
+	resp, err := client.QueryStats(context.Background(), &QueryStatsRequest{Pattern: ">>>traffic>>>"})
+	assert.Error(err).IsNil()
+	values := make(map[string]int64)
+	for _, stat := range resp.Stat {
+		values[stat.Name] = stat.Value
+	}
+	assert.Int64(values[stats.InboundTrafficCounterName("d", stats.Uplink)]).Equals(int64(len(payload)))
+	assert.Int64(values[stats.InboundTrafficCounterName("d", stats.Downlink)]).Equals(int64(len(payload)))
+	assert.Int64(values[stats.OutboundTrafficCounterName("direct", stats.Uplink)]).Equals(int64(len(payload)))
+	assert.Int64(values[stats.OutboundTrafficCounterName("direct", stats.Downlink)]).Equals(int64(len(payload)))
+
+	name := stats.InboundTrafficCounterName("d", stats.Uplink)
+	getResp, err := client.GetStats(context.Background(), &GetStatsRequest{Name: name, Reset_: true})
+	assert.Error(err).IsNil()
+	assert.Int64(getResp.Stat.Value).Equals(int64(len(payload)))
+
+	getResp, err = client.GetStats(context.Background(), &GetStatsRequest{Name: name})
+	assert.Error(err).IsNil()
+	assert.Int64(getResp.Stat.Value).Equals(0)
+
+	_, err = client.GetStats(context.Background(), &GetStatsRequest{Name: "nonexist"})
+	assert.Error(err).IsNotNil()
+}
+
+func TestAlterInbound(t *testing.T) {
+	assert := assert.On(t)
+
+	apiPort := pickPort()
+	inboundPort := pickPort()
+	server, err := core.New(&core.Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			{
+				Tag: "vmess",
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: v2net.SinglePortRange(inboundPort),
+					Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+				}),
+				ProxySettings: serial.ToTypedMessage(&vmessinbound.Config{}),
+			},
+		},
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				DirectPort: uint32(apiPort),
+			}),
+		},
+	})
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	conn, err := grpc.Dial(v2net.TCPDestination(v2net.LocalHostIP, apiPort).NetAddr(), grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(time.Second*5))
+	assert.Error(err).IsNil()
+	defer conn.Close()
+
+	client := NewHandlerServiceClient(conn)
+
+	addUser := &AlterInboundRequest{
+		Tag: "vmess",
+		Operation: serial.ToTypedMessage(&AddUserOperation{
+			User: &protocol.User{
+				Email: "test@v2ray.com",
+				Account: serial.ToTypedMessage(&vmess.Account{
+					Id: uuid.New().String(),
+				}),
+			},
+		}),
+	}
+	_, err = client.AlterInbound(context.Background(), addUser)
+	assert.Error(err).IsNil()
+
+	_, err = client.AlterInbound(context.Background(), addUser)
+	assert.Error(err).IsNotNil()
+
+	removeUser := &AlterInboundRequest{
+		Tag: "vmess",
+		Operation: serial.ToTypedMessage(&RemoveUserOperation{
+			Email: "test@v2ray.com",
+		}),
+	}
+	_, err = client.AlterInbound(context.Background(), removeUser)
+	assert.Error(err).IsNil()
+
+	_, err = client.AlterInbound(context.Background(), removeUser)
+	assert.Error(err).IsNotNil()
+
+	removeUser.Tag = "nonexist"
+	_, err = client.AlterInbound(context.Background(), removeUser)
+	assert.Error(err).IsNotNil()
+}
+
+func TestConnections(t *testing.T) {
+	assert := assert.On(t)
+
+	tcpServer := tcp.Server{
+		MsgProcessor: xor,
+	}
+	dest, err := tcpServer.Start()
+	assert.Error(err).IsNil()
+	defer tcpServer.Close()
+
+	apiPort := pickPort()
+	inboundPort := pickPort()
+	server, err := core.New(&core.Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			{
+				Tag: "d",
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: v2net.SinglePortRange(inboundPort),
+					Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+				}),
+				ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+					Address: v2net.NewIPOrDomain(dest.Address),
+					Port:    uint32(dest.Port),
+					NetworkList: &v2net.NetworkList{
+						Network: []v2net.Network{v2net.Network_TCP},
+					},
+				}),
+			},
+		},
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				DirectPort: uint32(apiPort),
+			}),
+			serial.ToTypedMessage(&conntrack.Config{}),
+		},
+	})
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	clientConn, err := net.DialTCP("tcp", nil, &net.TCPAddr{
+		IP:   []byte{127, 0, 0, 1},
+		Port: int(inboundPort),
+	})
+	assert.Error(err).IsNil()
+	defer clientConn.Close()
+
+	payload := "dokodemo request."
+	nBytes, err := clientConn.Write([]byte(payload))
+	assert.Error(err).IsNil()
+	assert.Int(nBytes).Equals(len(payload))
+
+	response := make([]byte, 1024)
+	clientConn.SetReadDeadline(time.Now().Add(time.Second * 5))
+	nBytes, err = clientConn.Read(response)
+	assert.Error(err).IsNil()
+	assert.Bytes(response[:nBytes]).Equals(xor([]byte(payload)))
+
+	conn, err := grpc.Dial(v2net.TCPDestination(v2net.LocalHostIP, apiPort).NetAddr(), grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(time.Second*5))
+	assert.Error(err).IsNil()
+	defer conn.Close()
+
+	client := NewConnectionServiceClient(conn)
+
+	resp, err := client.ListConnections(context.Background(), &ListConnectionsRequest{InboundTag: "d"})
+	assert.Error(err).IsNil()
+	assert.Int(len(resp.Connection)).Equals(1)
+	c := resp.Connection[0]
+	assert.String(c.OutboundTag).Equals("direct")
+	assert.String(c.Destination).Equals(dest.String())
+	assert.Int64(c.Uplink).Equals(int64(len(payload)))
+	assert.Int64(c.Downlink).Equals(int64(len(payload)))
+
+	resp, err = client.ListConnections(context.Background(), &ListConnectionsRequest{InboundTag: "nonexist"})
+	assert.Error(err).IsNil()
+	assert.Int(len(resp.Connection)).Equals(0)
+
+	closeResp, err := client.CloseConnections(context.Background(), &CloseConnectionsRequest{Id: []uint64{c.Id}})
+	assert.Error(err).IsNil()
+	assert.Uint32(closeResp.Closed).Equals(1)
+
+	clientConn.SetReadDeadline(time.Now().Add(time.Second * 5))
+	_, err = clientConn.Read(response)
+	assert.Error(err).IsNotNil()
+
+	_, err = client.CloseConnections(context.Background(), &CloseConnectionsRequest{})
+	assert.Error(err).IsNotNil()
+}
+
+func TestTestRoute(t *testing.T) {
+	assert := assert.On(t)
+
+	apiPort := pickPort()
+	server, err := core.New(&core.Config{
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+			{
+				Tag:           "blocked",
+				ProxySettings: serial.ToTypedMessage(&blackhole.Config{}),
+			},
+		},
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&Config{
+				DirectPort: uint32(apiPort),
+			}),
+			serial.ToTypedMessage(&router.Config{
+				Rule: []*router.RoutingRule{
+					{
+						Tag:        "blocked",
+						InboundTag: []string{"socks"},
+					},
+					{
+						Tag: "direct",
+						Domain: []*router.Domain{
+							{Type: router.Domain_Domain, Value: "v2ray.com"},
+						},
+						UserEmail: []string{"love@v2ray.com"},
+					},
+				},
+			}),
+		},
+	})
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	conn, err := grpc.Dial(v2net.TCPDestination(v2net.LocalHostIP, apiPort).NetAddr(), grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(time.Second*5))
+	assert.Error(err).IsNil()
+	defer conn.Close()
+
+	client := NewRoutingServiceClient(conn)
+
+	resp, err := client.TestRoute(context.Background(), &TestRouteRequest{
+		Destination:   "tcp:1.2.3.4:443",
+		InboundTag:    "http",
+		User:          "love@v2ray.com",
+		SniffedDomain: "www.v2ray.com",
+	})
+	assert.Error(err).IsNil()
+	assert.String(resp.DomainStrategy).Equals("AsIs")
+	assert.Int(int(resp.MatchedRule)).Equals(1)
+	assert.String(resp.OutboundTag).Equals("direct")
+	assert.Int(len(resp.Rule)).Equals(2)
+	assert.String(resp.Rule[0].Reason).Equals("inbound tag does not match")
+	assert.String(resp.Rule[1].Reason).Equals("")
+
+	resp, err = client.TestRoute(context.Background(), &TestRouteRequest{
+		Destination: "tcp:www.v2ray.com:443",
+		User:        "admin@v2ray.com",
+	})
+	assert.Error(err).IsNil()
+	assert.Int(int(resp.MatchedRule)).Equals(-1)
+	assert.String(resp.OutboundTag).Equals("")
+	assert.Int(len(resp.Rule)).Equals(2)
+	assert.String(resp.Rule[1].Reason).Equals("user does not match")
+
+	_, err = client.TestRoute(context.Background(), &TestRouteRequest{
+		Destination: "v2ray.com",
+	})
+	assert.Error(err).IsNotNil()
+}
@@ -0,0 +1,1394 @@
+package api
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import v2ray_core_app_proxyman "v2ray.com/core/app/proxyman"
+import v2ray_core_common_protocol "v2ray.com/core/common/protocol"
+import v2ray_core_common_serial "v2ray.com/core/common/serial"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type AddInboundRequest struct {
+	Inbound *v2ray_core_app_proxyman.InboundHandlerConfig `protobuf:"bytes,1,opt,name=inbound" json:"inbound,omitempty"`
+}
+
+func (m *AddInboundRequest) Reset()                    { *m = AddInboundRequest{} }
+func (m *AddInboundRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddInboundRequest) ProtoMessage()               {}
+func (*AddInboundRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *AddInboundRequest) GetInbound() *v2ray_core_app_proxyman.InboundHandlerConfig {
+	if m != nil {
+		return m.Inbound
+	}
+	return nil
+}
+
+type AddInboundResponse struct {
+}
+
+func (m *AddInboundResponse) Reset()                    { *m = AddInboundResponse{} }
+func (m *AddInboundResponse) String() string            { return proto.CompactTextString(m) }
+func (*AddInboundResponse) ProtoMessage()               {}
+func (*AddInboundResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type RemoveInboundRequest struct {
+	Tag string `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
+}
+
+func (m *RemoveInboundRequest) Reset()                    { *m = RemoveInboundRequest{} }
+func (m *RemoveInboundRequest) String() string            { return proto.CompactTextString(m) }
+func (*RemoveInboundRequest) ProtoMessage()               {}
+func (*RemoveInboundRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *RemoveInboundRequest) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+type RemoveInboundResponse struct {
+}
+
+func (m *RemoveInboundResponse) Reset()                    { *m = RemoveInboundResponse{} }
+func (m *RemoveInboundResponse) String() string            { return proto.CompactTextString(m) }
+func (*RemoveInboundResponse) ProtoMessage()               {}
+func (*RemoveInboundResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+// AddUserOperation adds a user to an inbound handler.
+type AddUserOperation struct {
+	User *v2ray_core_common_protocol.User `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
+}
+
+func (m *AddUserOperation) Reset()                    { *m = AddUserOperation{} }
+func (m *AddUserOperation) String() string            { return proto.CompactTextString(m) }
+func (*AddUserOperation) ProtoMessage()               {}
+func (*AddUserOperation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *AddUserOperation) GetUser() *v2ray_core_common_protocol.User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+// RemoveUserOperation removes the user with the given email from an inbound handler.
+type RemoveUserOperation struct {
+	Email string `protobuf:"bytes,1,opt,name=email" json:"email,omitempty"`
+}
+
+func (m *RemoveUserOperation) Reset()                    { *m = RemoveUserOperation{} }
+func (m *RemoveUserOperation) String() string            { return proto.CompactTextString(m) }
+func (*RemoveUserOperation) ProtoMessage()               {}
+func (*RemoveUserOperation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *RemoveUserOperation) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+type AlterInboundRequest struct {
+	Tag string `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
+	// One of the operations above.
+	Operation *v2ray_core_common_serial.TypedMessage `protobuf:"bytes,2,opt,name=operation" json:"operation,omitempty"`
+}
+
+func (m *AlterInboundRequest) Reset()                    { *m = AlterInboundRequest{} }
+func (m *AlterInboundRequest) String() string            { return proto.CompactTextString(m) }
+func (*AlterInboundRequest) ProtoMessage()               {}
+func (*AlterInboundRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *AlterInboundRequest) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *AlterInboundRequest) GetOperation() *v2ray_core_common_serial.TypedMessage {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+type AlterInboundResponse struct {
+}
+
+func (m *AlterInboundResponse) Reset()                    { *m = AlterInboundResponse{} }
+func (m *AlterInboundResponse) String() string            { return proto.CompactTextString(m) }
+func (*AlterInboundResponse) ProtoMessage()               {}
+func (*AlterInboundResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type AddOutboundRequest struct {
+	Outbound *v2ray_core_app_proxyman.OutboundHandlerConfig `protobuf:"bytes,1,opt,name=outbound" json:"outbound,omitempty"`
+}
+
+func (m *AddOutboundRequest) Reset()                    { *m = AddOutboundRequest{} }
+func (m *AddOutboundRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddOutboundRequest) ProtoMessage()               {}
+func (*AddOutboundRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *AddOutboundRequest) GetOutbound() *v2ray_core_app_proxyman.OutboundHandlerConfig {
+	if m != nil {
+		return m.Outbound
+	}
+	return nil
+}
+
+type AddOutboundResponse struct {
+}
+
+func (m *AddOutboundResponse) Reset()                    { *m = AddOutboundResponse{} }
+func (m *AddOutboundResponse) String() string            { return proto.CompactTextString(m) }
+func (*AddOutboundResponse) ProtoMessage()               {}
+func (*AddOutboundResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+type RemoveOutboundRequest struct {
+	Tag string `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
+}
+
+func (m *RemoveOutboundRequest) Reset()                    { *m = RemoveOutboundRequest{} }
+func (m *RemoveOutboundRequest) String() string            { return proto.CompactTextString(m) }
+func (*RemoveOutboundRequest) ProtoMessage()               {}
+func (*RemoveOutboundRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *RemoveOutboundRequest) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+type RemoveOutboundResponse struct {
+}
+
+func (m *RemoveOutboundResponse) Reset()                    { *m = RemoveOutboundResponse{} }
+func (m *RemoveOutboundResponse) String() string            { return proto.CompactTextString(m) }
+func (*RemoveOutboundResponse) ProtoMessage()               {}
+func (*RemoveOutboundResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+type GetOutboundHealthRequest struct {
+	// Tags of the outbound handlers. All tagged outbound handlers are returned if empty.
+	Tag []string `protobuf:"bytes,1,rep,name=tag" json:"tag,omitempty"`
+}
+
+func (m *GetOutboundHealthRequest) Reset()                    { *m = GetOutboundHealthRequest{} }
+func (m *GetOutboundHealthRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetOutboundHealthRequest) ProtoMessage()               {}
+func (*GetOutboundHealthRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *GetOutboundHealthRequest) GetTag() []string {
+	if m != nil {
+		return m.Tag
+	}
+	return nil
+}
+
+type OutboundHealth struct {
+	Tag string `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
+	// Whether the outbound is used for routing. Outbounds that are not probed are always healthy.
+	Healthy bool `protobuf:"varint,2,opt,name=healthy" json:"healthy,omitempty"`
+	// Ratio of successful ones in recent probes, from 0 to 1.
+	SuccessRate float32 `protobuf:"fixed32,3,opt,name=success_rate,json=successRate" json:"success_rate,omitempty"`
+	// Round trip time of the last successful probe, in milliseconds.
+	Rtt uint32 `protobuf:"varint,4,opt,name=rtt" json:"rtt,omitempty"`
+	// Unix time of the last probe. 0 if the outbound is never probed.
+	LastProbeTime int64 `protobuf:"varint,5,opt,name=last_probe_time,json=lastProbeTime" json:"last_probe_time,omitempty"`
+	// Error of the last probe. Empty if it succeeded.
+	LastError string `protobuf:"bytes,6,opt,name=last_error,json=lastError" json:"last_error,omitempty"`
+}
+
+func (m *OutboundHealth) Reset()                    { *m = OutboundHealth{} }
+func (m *OutboundHealth) String() string            { return proto.CompactTextString(m) }
+func (*OutboundHealth) ProtoMessage()               {}
+func (*OutboundHealth) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *OutboundHealth) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *OutboundHealth) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *OutboundHealth) GetSuccessRate() float32 {
+	if m != nil {
+		return m.SuccessRate
+	}
+	return 0
+}
+
+func (m *OutboundHealth) GetRtt() uint32 {
+	if m != nil {
+		return m.Rtt
+	}
+	return 0
+}
+
+func (m *OutboundHealth) GetLastProbeTime() int64 {
+	if m != nil {
+		return m.LastProbeTime
+	}
+	return 0
+}
+
+func (m *OutboundHealth) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+type GetOutboundHealthResponse struct {
+	Health []*OutboundHealth `protobuf:"bytes,1,rep,name=health" json:"health,omitempty"`
+}
+
+func (m *GetOutboundHealthResponse) Reset()                    { *m = GetOutboundHealthResponse{} }
+func (m *GetOutboundHealthResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetOutboundHealthResponse) ProtoMessage()               {}
+func (*GetOutboundHealthResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *GetOutboundHealthResponse) GetHealth() []*OutboundHealth {
+	if m != nil {
+		return m.Health
+	}
+	return nil
+}
+
+type GetStatsRequest struct {
+	// Name of the stat counter.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Whether or not to reset the counter after fetching its value.
+	Reset_ bool `protobuf:"varint,2,opt,name=reset" json:"reset,omitempty"`
+}
+
+func (m *GetStatsRequest) Reset()                    { *m = GetStatsRequest{} }
+func (m *GetStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetStatsRequest) ProtoMessage()               {}
+func (*GetStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *GetStatsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetStatsRequest) GetReset_() bool {
+	if m != nil {
+		return m.Reset_
+	}
+	return false
+}
+
+type Stat struct {
+	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Value int64  `protobuf:"varint,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *Stat) Reset()                    { *m = Stat{} }
+func (m *Stat) String() string            { return proto.CompactTextString(m) }
+func (*Stat) ProtoMessage()               {}
+func (*Stat) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *Stat) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Stat) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type GetStatsResponse struct {
+	Stat *Stat `protobuf:"bytes,1,opt,name=stat" json:"stat,omitempty"`
+}
+
+func (m *GetStatsResponse) Reset()                    { *m = GetStatsResponse{} }
+func (m *GetStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetStatsResponse) ProtoMessage()               {}
+func (*GetStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *GetStatsResponse) GetStat() *Stat {
+	if m != nil {
+		return m.Stat
+	}
+	return nil
+}
+
+type QueryStatsRequest struct {
+	// Only counters whose names contain the pattern are returned. All counters are returned if it is empty.
+	Pattern string `protobuf:"bytes,1,opt,name=pattern" json:"pattern,omitempty"`
+	// Whether or not to reset the matched counters after fetching their values.
+	Reset_ bool `protobuf:"varint,2,opt,name=reset" json:"reset,omitempty"`
+}
+
+func (m *QueryStatsRequest) Reset()                    { *m = QueryStatsRequest{} }
+func (m *QueryStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*QueryStatsRequest) ProtoMessage()               {}
+func (*QueryStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *QueryStatsRequest) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *QueryStatsRequest) GetReset_() bool {
+	if m != nil {
+		return m.Reset_
+	}
+	return false
+}
+
+type QueryStatsResponse struct {
+	Stat []*Stat `protobuf:"bytes,1,rep,name=stat" json:"stat,omitempty"`
+}
+
+func (m *QueryStatsResponse) Reset()                    { *m = QueryStatsResponse{} }
+func (m *QueryStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*QueryStatsResponse) ProtoMessage()               {}
+func (*QueryStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *QueryStatsResponse) GetStat() []*Stat {
+	if m != nil {
+		return m.Stat
+	}
+	return nil
+}
+
+type Connection struct {
+	Id         uint64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	InboundTag string `protobuf:"bytes,2,opt,name=inbound_tag,json=inboundTag" json:"inbound_tag,omitempty"`
+	// Source address of the connection, in the form of "tcp:1.2.3.4:5678".
+	Source string `protobuf:"bytes,3,opt,name=source" json:"source,omitempty"`
+	// Email of the user, if the inbound authenticates users.
+	User string `protobuf:"bytes,4,opt,name=user" json:"user,omitempty"`
+	// Destination requested by the client.
+	Destination string `protobuf:"bytes,5,opt,name=destination" json:"destination,omitempty"`
+	// Domain sniffed from the traffic, if any.
+	Domain      string `protobuf:"bytes,6,opt,name=domain" json:"domain,omitempty"`
+	OutboundTag string `protobuf:"bytes,7,opt,name=outbound_tag,json=outboundTag" json:"outbound_tag,omitempty"`
+	// Start time of the connection, in seconds since Unix epoch.
+	StartTime int64 `protobuf:"varint,8,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	Uplink    int64 `protobuf:"varint,9,opt,name=uplink" json:"uplink,omitempty"`
+	Downlink  int64 `protobuf:"varint,10,opt,name=downlink" json:"downlink,omitempty"`
+}
+
+func (m *Connection) Reset()                    { *m = Connection{} }
+func (m *Connection) String() string            { return proto.CompactTextString(m) }
+func (*Connection) ProtoMessage()               {}
+func (*Connection) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *Connection) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Connection) GetInboundTag() string {
+	if m != nil {
+		return m.InboundTag
+	}
+	return ""
+}
+
+func (m *Connection) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Connection) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *Connection) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *Connection) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *Connection) GetOutboundTag() string {
+	if m != nil {
+		return m.OutboundTag
+	}
+	return ""
+}
+
+func (m *Connection) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *Connection) GetUplink() int64 {
+	if m != nil {
+		return m.Uplink
+	}
+	return 0
+}
+
+func (m *Connection) GetDownlink() int64 {
+	if m != nil {
+		return m.Downlink
+	}
+	return 0
+}
+
+type ListConnectionsRequest struct {
+	// Only connections of this user are returned, if set.
+	User string `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
+	// Only connections of this inbound are returned, if set.
+	InboundTag string `protobuf:"bytes,2,opt,name=inbound_tag,json=inboundTag" json:"inbound_tag,omitempty"`
+}
+
+func (m *ListConnectionsRequest) Reset()                    { *m = ListConnectionsRequest{} }
+func (m *ListConnectionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListConnectionsRequest) ProtoMessage()               {}
+func (*ListConnectionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ListConnectionsRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ListConnectionsRequest) GetInboundTag() string {
+	if m != nil {
+		return m.InboundTag
+	}
+	return ""
+}
+
+type ListConnectionsResponse struct {
+	Connection []*Connection `protobuf:"bytes,1,rep,name=connection" json:"connection,omitempty"`
+}
+
+func (m *ListConnectionsResponse) Reset()                    { *m = ListConnectionsResponse{} }
+func (m *ListConnectionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListConnectionsResponse) ProtoMessage()               {}
+func (*ListConnectionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ListConnectionsResponse) GetConnection() []*Connection {
+	if m != nil {
+		return m.Connection
+	}
+	return nil
+}
+
+type CloseConnectionsRequest struct {
+	// IDs of the connections to close.
+	Id []uint64 `protobuf:"varint,1,rep,packed,name=id" json:"id,omitempty"`
+	// All connections of this user are closed, if set.
+	User string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+}
+
+func (m *CloseConnectionsRequest) Reset()                    { *m = CloseConnectionsRequest{} }
+func (m *CloseConnectionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloseConnectionsRequest) ProtoMessage()               {}
+func (*CloseConnectionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *CloseConnectionsRequest) GetId() []uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *CloseConnectionsRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+type CloseConnectionsResponse struct {
+	// Number of connections closed.
+	Closed uint32 `protobuf:"varint,1,opt,name=closed" json:"closed,omitempty"`
+}
+
+func (m *CloseConnectionsResponse) Reset()                    { *m = CloseConnectionsResponse{} }
+func (m *CloseConnectionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*CloseConnectionsResponse) ProtoMessage()               {}
+func (*CloseConnectionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *CloseConnectionsResponse) GetClosed() uint32 {
+	if m != nil {
+		return m.Closed
+	}
+	return 0
+}
+
+type TestRouteRequest struct {
+	// Destination of the connection, in the form of "tcp:1.2.3.4:443", "udp:example.com:53" or "example.com:443".
+	Destination string `protobuf:"bytes,1,opt,name=destination" json:"destination,omitempty"`
+	// Source address of the connection, in the same form as destination. Optional.
+	Source     string `protobuf:"bytes,2,opt,name=source" json:"source,omitempty"`
+	InboundTag string `protobuf:"bytes,3,opt,name=inbound_tag,json=inboundTag" json:"inbound_tag,omitempty"`
+	// Email of the user. Optional.
+	User string `protobuf:"bytes,4,opt,name=user" json:"user,omitempty"`
+	// Domain sniffed from the traffic. Optional. It overrides the destination if the destination is an IP.
+	SniffedDomain string `protobuf:"bytes,5,opt,name=sniffed_domain,json=sniffedDomain" json:"sniffed_domain,omitempty"`
+	// Protocol sniffed from the traffic, such as "http" or "tls". Optional.
+	SniffedProtocol string `protobuf:"bytes,6,opt,name=sniffed_protocol,json=sniffedProtocol" json:"sniffed_protocol,omitempty"`
+	// Port that the inbound receives the connection on. Optional.
+	LocalPort uint32 `protobuf:"varint,7,opt,name=local_port,json=localPort" json:"local_port,omitempty"`
+	// Attributes attached to the connection by the inbound. Optional.
+	Attributes map[string]string `protobuf:"bytes,8,rep,name=attributes" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *TestRouteRequest) Reset()                    { *m = TestRouteRequest{} }
+func (m *TestRouteRequest) String() string            { return proto.CompactTextString(m) }
+func (*TestRouteRequest) ProtoMessage()               {}
+func (*TestRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *TestRouteRequest) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *TestRouteRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *TestRouteRequest) GetInboundTag() string {
+	if m != nil {
+		return m.InboundTag
+	}
+	return ""
+}
+
+func (m *TestRouteRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *TestRouteRequest) GetSniffedDomain() string {
+	if m != nil {
+		return m.SniffedDomain
+	}
+	return ""
+}
+
+func (m *TestRouteRequest) GetSniffedProtocol() string {
+	if m != nil {
+		return m.SniffedProtocol
+	}
+	return ""
+}
+
+func (m *TestRouteRequest) GetLocalPort() uint32 {
+	if m != nil {
+		return m.LocalPort
+	}
+	return 0
+}
+
+func (m *TestRouteRequest) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+type RuleEvaluation struct {
+	// Index of the rule in the routing config.
+	Index int32  `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	Tag   string `protobuf:"bytes,2,opt,name=tag" json:"tag,omitempty"`
+	// Why the rule is not taken. Empty if the rule is taken.
+	Reason string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
+	// Whether the rule is evaluated with the IPs resolved from the destination domain.
+	AfterResolution bool `protobuf:"varint,4,opt,name=after_resolution,json=afterResolution" json:"after_resolution,omitempty"`
+}
+
+func (m *RuleEvaluation) Reset()                    { *m = RuleEvaluation{} }
+func (m *RuleEvaluation) String() string            { return proto.CompactTextString(m) }
+func (*RuleEvaluation) ProtoMessage()               {}
+func (*RuleEvaluation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *RuleEvaluation) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *RuleEvaluation) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *RuleEvaluation) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *RuleEvaluation) GetAfterResolution() bool {
+	if m != nil {
+		return m.AfterResolution
+	}
+	return false
+}
+
+type TestRouteResponse struct {
+	// Domain strategy of the router, such as "AsIs" or "IpIfNonMatch".
+	DomainStrategy string `protobuf:"bytes,1,opt,name=domain_strategy,json=domainStrategy" json:"domain_strategy,omitempty"`
+	// Evaluated rules, in order.
+	Rule []*RuleEvaluation `protobuf:"bytes,2,rep,name=rule" json:"rule,omitempty"`
+	// Whether the destination domain is resolved for routing, with resolved_ip as the result.
+	Resolved   bool     `protobuf:"varint,3,opt,name=resolved" json:"resolved,omitempty"`
+	ResolvedIp []string `protobuf:"bytes,4,rep,name=resolved_ip,json=resolvedIp" json:"resolved_ip,omitempty"`
+	// Index of the rule taken, or -1 if the connection goes to the default outbound.
+	MatchedRule int32 `protobuf:"varint,5,opt,name=matched_rule,json=matchedRule" json:"matched_rule,omitempty"`
+	// Tag of the outbound the connection goes to. Empty for the default outbound.
+	OutboundTag string `protobuf:"bytes,6,opt,name=outbound_tag,json=outboundTag" json:"outbound_tag,omitempty"`
+}
+
+func (m *TestRouteResponse) Reset()                    { *m = TestRouteResponse{} }
+func (m *TestRouteResponse) String() string            { return proto.CompactTextString(m) }
+func (*TestRouteResponse) ProtoMessage()               {}
+func (*TestRouteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *TestRouteResponse) GetDomainStrategy() string {
+	if m != nil {
+		return m.DomainStrategy
+	}
+	return ""
+}
+
+func (m *TestRouteResponse) GetRule() []*RuleEvaluation {
+	if m != nil {
+		return m.Rule
+	}
+	return nil
+}
+
+func (m *TestRouteResponse) GetResolved() bool {
+	if m != nil {
+		return m.Resolved
+	}
+	return false
+}
+
+func (m *TestRouteResponse) GetResolvedIp() []string {
+	if m != nil {
+		return m.ResolvedIp
+	}
+	return nil
+}
+
+func (m *TestRouteResponse) GetMatchedRule() int32 {
+	if m != nil {
+		return m.MatchedRule
+	}
+	return 0
+}
+
+func (m *TestRouteResponse) GetOutboundTag() string {
+	if m != nil {
+		return m.OutboundTag
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*AddInboundRequest)(nil), "v2ray.core.app.api.AddInboundRequest")
+	proto.RegisterType((*AddInboundResponse)(nil), "v2ray.core.app.api.AddInboundResponse")
+	proto.RegisterType((*RemoveInboundRequest)(nil), "v2ray.core.app.api.RemoveInboundRequest")
+	proto.RegisterType((*RemoveInboundResponse)(nil), "v2ray.core.app.api.RemoveInboundResponse")
+	proto.RegisterType((*AddUserOperation)(nil), "v2ray.core.app.api.AddUserOperation")
+	proto.RegisterType((*RemoveUserOperation)(nil), "v2ray.core.app.api.RemoveUserOperation")
+	proto.RegisterType((*AlterInboundRequest)(nil), "v2ray.core.app.api.AlterInboundRequest")
+	proto.RegisterType((*AlterInboundResponse)(nil), "v2ray.core.app.api.AlterInboundResponse")
+	proto.RegisterType((*AddOutboundRequest)(nil), "v2ray.core.app.api.AddOutboundRequest")
+	proto.RegisterType((*AddOutboundResponse)(nil), "v2ray.core.app.api.AddOutboundResponse")
+	proto.RegisterType((*RemoveOutboundRequest)(nil), "v2ray.core.app.api.RemoveOutboundRequest")
+	proto.RegisterType((*RemoveOutboundResponse)(nil), "v2ray.core.app.api.RemoveOutboundResponse")
+	proto.RegisterType((*GetOutboundHealthRequest)(nil), "v2ray.core.app.api.GetOutboundHealthRequest")
+	proto.RegisterType((*OutboundHealth)(nil), "v2ray.core.app.api.OutboundHealth")
+	proto.RegisterType((*GetOutboundHealthResponse)(nil), "v2ray.core.app.api.GetOutboundHealthResponse")
+	proto.RegisterType((*GetStatsRequest)(nil), "v2ray.core.app.api.GetStatsRequest")
+	proto.RegisterType((*Stat)(nil), "v2ray.core.app.api.Stat")
+	proto.RegisterType((*GetStatsResponse)(nil), "v2ray.core.app.api.GetStatsResponse")
+	proto.RegisterType((*QueryStatsRequest)(nil), "v2ray.core.app.api.QueryStatsRequest")
+	proto.RegisterType((*QueryStatsResponse)(nil), "v2ray.core.app.api.QueryStatsResponse")
+	proto.RegisterType((*Connection)(nil), "v2ray.core.app.api.Connection")
+	proto.RegisterType((*ListConnectionsRequest)(nil), "v2ray.core.app.api.ListConnectionsRequest")
+	proto.RegisterType((*ListConnectionsResponse)(nil), "v2ray.core.app.api.ListConnectionsResponse")
+	proto.RegisterType((*CloseConnectionsRequest)(nil), "v2ray.core.app.api.CloseConnectionsRequest")
+	proto.RegisterType((*CloseConnectionsResponse)(nil), "v2ray.core.app.api.CloseConnectionsResponse")
+	proto.RegisterType((*TestRouteRequest)(nil), "v2ray.core.app.api.TestRouteRequest")
+	proto.RegisterType((*RuleEvaluation)(nil), "v2ray.core.app.api.RuleEvaluation")
+	proto.RegisterType((*TestRouteResponse)(nil), "v2ray.core.app.api.TestRouteResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for HandlerService service
+
+type HandlerServiceClient interface {
+	// AddInbound creates a new inbound handler and starts it immediately.
+	AddInbound(ctx context.Context, in *AddInboundRequest, opts ...grpc.CallOption) (*AddInboundResponse, error)
+	// RemoveInbound closes the inbound handler with the given tag, as well as all its connections.
+	RemoveInbound(ctx context.Context, in *RemoveInboundRequest, opts ...grpc.CallOption) (*RemoveInboundResponse, error)
+	// AlterInbound applies an operation, such as adding or removing a user, to the inbound handler with the given tag.
+	AlterInbound(ctx context.Context, in *AlterInboundRequest, opts ...grpc.CallOption) (*AlterInboundResponse, error)
+	// AddOutbound creates a new outbound handler.
+	AddOutbound(ctx context.Context, in *AddOutboundRequest, opts ...grpc.CallOption) (*AddOutboundResponse, error)
+	// RemoveOutbound removes the outbound handler with the given tag.
+	// Traffic routed to the removed tag goes to the default outbound handler afterwards.
+	RemoveOutbound(ctx context.Context, in *RemoveOutboundRequest, opts ...grpc.CallOption) (*RemoveOutboundResponse, error)
+	// GetOutboundHealth returns results of health probing of outbound handlers.
+	GetOutboundHealth(ctx context.Context, in *GetOutboundHealthRequest, opts ...grpc.CallOption) (*GetOutboundHealthResponse, error)
+}
+
+type handlerServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewHandlerServiceClient(cc *grpc.ClientConn) HandlerServiceClient {
+	return &handlerServiceClient{cc}
+}
+
+func (c *handlerServiceClient) AddInbound(ctx context.Context, in *AddInboundRequest, opts ...grpc.CallOption) (*AddInboundResponse, error) {
+	out := new(AddInboundResponse)
+	err := grpc.Invoke(ctx, "/v2ray.core.app.api.HandlerService/AddInbound", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) RemoveInbound(ctx context.Context, in *RemoveInboundRequest, opts ...grpc.CallOption) (*RemoveInboundResponse, error) {
+	out := new(RemoveInboundResponse)
+	err := grpc.Invoke(ctx, "/v2ray.core.app.api.HandlerService/RemoveInbound", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) AlterInbound(ctx context.Context, in *AlterInboundRequest, opts ...grpc.CallOption) (*AlterInboundResponse, error) {
+	out := new(AlterInboundResponse)
+	err := grpc.Invoke(ctx, "/v2ray.core.app.api.HandlerService/AlterInbound", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) AddOutbound(ctx context.Context, in *AddOutboundRequest, opts ...grpc.CallOption) (*AddOutboundResponse, error) {
+	out := new(AddOutboundResponse)
+	err := grpc.Invoke(ctx, "/v2ray.core.app.api.HandlerService/AddOutbound", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) RemoveOutbound(ctx context.Context, in *RemoveOutboundRequest, opts ...grpc.CallOption) (*RemoveOutboundResponse, error) {
+	out := new(RemoveOutboundResponse)
+	err := grpc.Invoke(ctx, "/v2ray.core.app.api.HandlerService/RemoveOutbound", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *handlerServiceClient) GetOutboundHealth(ctx context.Context, in *GetOutboundHealthRequest, opts ...grpc.CallOption) (*GetOutboundHealthResponse, error) {
+	out := new(GetOutboundHealthResponse)
+	err := grpc.Invoke(ctx, "/v2ray.core.app.api.HandlerService/GetOutboundHealth", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for HandlerService service
+
+type HandlerServiceServer interface {
+	// AddInbound creates a new inbound handler and starts it immediately.
+	AddInbound(context.Context, *AddInboundRequest) (*AddInboundResponse, error)
+	// RemoveInbound closes the inbound handler with the given tag, as well as all its connections.
+	RemoveInbound(context.Context, *RemoveInboundRequest) (*RemoveInboundResponse, error)
+	// AlterInbound applies an operation, such as adding or removing a user, to the inbound handler with the given tag.
+	AlterInbound(context.Context, *AlterInboundRequest) (*AlterInboundResponse, error)
+	// AddOutbound creates a new outbound handler.
+	AddOutbound(context.Context, *AddOutboundRequest) (*AddOutboundResponse, error)
+	// RemoveOutbound removes the outbound handler with the given tag.
+	// Traffic routed to the removed tag goes to the default outbound handler afterwards.
+	RemoveOutbound(context.Context, *RemoveOutboundRequest) (*RemoveOutboundResponse, error)
+	// GetOutboundHealth returns results of health probing of outbound handlers.
+	GetOutboundHealth(context.Context, *GetOutboundHealthRequest) (*GetOutboundHealthResponse, error)
+}
+
+func RegisterHandlerServiceServer(s *grpc.Server, srv HandlerServiceServer) {
+	s.RegisterService(&_HandlerService_serviceDesc, srv)
+}
+
+func _HandlerService_AddInbound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddInboundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).AddInbound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.api.HandlerService/AddInbound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).AddInbound(ctx, req.(*AddInboundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_RemoveInbound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveInboundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).RemoveInbound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.api.HandlerService/RemoveInbound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).RemoveInbound(ctx, req.(*RemoveInboundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_AlterInbound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlterInboundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).AlterInbound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.api.HandlerService/AlterInbound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).AlterInbound(ctx, req.(*AlterInboundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_AddOutbound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddOutboundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).AddOutbound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.api.HandlerService/AddOutbound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).AddOutbound(ctx, req.(*AddOutboundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_RemoveOutbound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveOutboundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).RemoveOutbound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.api.HandlerService/RemoveOutbound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).RemoveOutbound(ctx, req.(*RemoveOutboundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HandlerService_GetOutboundHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutboundHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HandlerServiceServer).GetOutboundHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.api.HandlerService/GetOutboundHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HandlerServiceServer).GetOutboundHealth(ctx, req.(*GetOutboundHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _HandlerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v2ray.core.app.api.HandlerService",
+	HandlerType: (*HandlerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddInbound",
+			Handler:    _HandlerService_AddInbound_Handler,
+		},
+		{
+			MethodName: "RemoveInbound",
+			Handler:    _HandlerService_RemoveInbound_Handler,
+		},
+		{
+			MethodName: "AlterInbound",
+			Handler:    _HandlerService_AlterInbound_Handler,
+		},
+		{
+			MethodName: "AddOutbound",
+			Handler:    _HandlerService_AddOutbound_Handler,
+		},
+		{
+			MethodName: "RemoveOutbound",
+			Handler:    _HandlerService_RemoveOutbound_Handler,
+		},
+		{
+			MethodName: "GetOutboundHealth",
+			Handler:    _HandlerService_GetOutboundHealth_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v2ray.com/core/app/api/command.proto",
+}
+
+// Client API for StatsService service
+
+type StatsServiceClient interface {
+	// GetStats returns the value of a single counter.
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	// QueryStats returns the values of all counters that match the given pattern.
+	QueryStats(ctx context.Context, in *QueryStatsRequest, opts ...grpc.CallOption) (*QueryStatsResponse, error)
+}
+
+type statsServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStatsServiceClient(cc *grpc.ClientConn) StatsServiceClient {
+	return &statsServiceClient{cc}
+}
+
+func (c *statsServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	out := new(GetStatsResponse)
+	err := grpc.Invoke(ctx, "/v2ray.core.app.api.StatsService/GetStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statsServiceClient) QueryStats(ctx context.Context, in *QueryStatsRequest, opts ...grpc.CallOption) (*QueryStatsResponse, error) {
+	out := new(QueryStatsResponse)
+	err := grpc.Invoke(ctx, "/v2ray.core.app.api.StatsService/QueryStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for StatsService service
+
+type StatsServiceServer interface {
+	// GetStats returns the value of a single counter.
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	// QueryStats returns the values of all counters that match the given pattern.
+	QueryStats(context.Context, *QueryStatsRequest) (*QueryStatsResponse, error)
+}
+
+func RegisterStatsServiceServer(s *grpc.Server, srv StatsServiceServer) {
+	s.RegisterService(&_StatsService_serviceDesc, srv)
+}
+
+func _StatsService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.api.StatsService/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StatsService_QueryStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServiceServer).QueryStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.api.StatsService/QueryStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServiceServer).QueryStats(ctx, req.(*QueryStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _StatsService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v2ray.core.app.api.StatsService",
+	HandlerType: (*StatsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStats",
+			Handler:    _StatsService_GetStats_Handler,
+		},
+		{
+			MethodName: "QueryStats",
+			Handler:    _StatsService_QueryStats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v2ray.com/core/app/api/command.proto",
+}
+
+// Client API for ConnectionService service
+
+type ConnectionServiceClient interface {
+	// ListConnections returns all live connections that match the request.
+	ListConnections(ctx context.Context, in *ListConnectionsRequest, opts ...grpc.CallOption) (*ListConnectionsResponse, error)
+	// CloseConnections forcibly closes the given connections.
+	CloseConnections(ctx context.Context, in *CloseConnectionsRequest, opts ...grpc.CallOption) (*CloseConnectionsResponse, error)
+}
+
+type connectionServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewConnectionServiceClient(cc *grpc.ClientConn) ConnectionServiceClient {
+	return &connectionServiceClient{cc}
+}
+
+func (c *connectionServiceClient) ListConnections(ctx context.Context, in *ListConnectionsRequest, opts ...grpc.CallOption) (*ListConnectionsResponse, error) {
+	out := new(ListConnectionsResponse)
+	err := grpc.Invoke(ctx, "/v2ray.core.app.api.ConnectionService/ListConnections", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *connectionServiceClient) CloseConnections(ctx context.Context, in *CloseConnectionsRequest, opts ...grpc.CallOption) (*CloseConnectionsResponse, error) {
+	out := new(CloseConnectionsResponse)
+	err := grpc.Invoke(ctx, "/v2ray.core.app.api.ConnectionService/CloseConnections", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for ConnectionService service
+
+type ConnectionServiceServer interface {
+	// ListConnections returns all live connections that match the request.
+	ListConnections(context.Context, *ListConnectionsRequest) (*ListConnectionsResponse, error)
+	// CloseConnections forcibly closes the given connections.
+	CloseConnections(context.Context, *CloseConnectionsRequest) (*CloseConnectionsResponse, error)
+}
+
+func RegisterConnectionServiceServer(s *grpc.Server, srv ConnectionServiceServer) {
+	s.RegisterService(&_ConnectionService_serviceDesc, srv)
+}
+
+func _ConnectionService_ListConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConnectionServiceServer).ListConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.api.ConnectionService/ListConnections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConnectionServiceServer).ListConnections(ctx, req.(*ListConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConnectionService_CloseConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConnectionServiceServer).CloseConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.api.ConnectionService/CloseConnections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConnectionServiceServer).CloseConnections(ctx, req.(*CloseConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ConnectionService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v2ray.core.app.api.ConnectionService",
+	HandlerType: (*ConnectionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListConnections",
+			Handler:    _ConnectionService_ListConnections_Handler,
+		},
+		{
+			MethodName: "CloseConnections",
+			Handler:    _ConnectionService_CloseConnections_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v2ray.com/core/app/api/command.proto",
+}
+
+// Client API for RoutingService service
+
+type RoutingServiceClient interface {
+	// TestRoute returns which outbound a connection would be routed to, and why, without sending any traffic.
+	TestRoute(ctx context.Context, in *TestRouteRequest, opts ...grpc.CallOption) (*TestRouteResponse, error)
+}
+
+type routingServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRoutingServiceClient(cc *grpc.ClientConn) RoutingServiceClient {
+	return &routingServiceClient{cc}
+}
+
+func (c *routingServiceClient) TestRoute(ctx context.Context, in *TestRouteRequest, opts ...grpc.CallOption) (*TestRouteResponse, error) {
+	out := new(TestRouteResponse)
+	err := grpc.Invoke(ctx, "/v2ray.core.app.api.RoutingService/TestRoute", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for RoutingService service
+
+type RoutingServiceServer interface {
+	// TestRoute returns which outbound a connection would be routed to, and why, without sending any traffic.
+	TestRoute(context.Context, *TestRouteRequest) (*TestRouteResponse, error)
+}
+
+func RegisterRoutingServiceServer(s *grpc.Server, srv RoutingServiceServer) {
+	s.RegisterService(&_RoutingService_serviceDesc, srv)
+}
+
+func _RoutingService_TestRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).TestRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.api.RoutingService/TestRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).TestRoute(ctx, req.(*TestRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RoutingService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "v2ray.core.app.api.RoutingService",
+	HandlerType: (*RoutingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TestRoute",
+			Handler:    _RoutingService_TestRoute_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v2ray.com/core/app/api/command.proto",
+}
+
+func init() { proto.RegisterFile("v2ray.com/core/app/api/command.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 1396 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xef, 0x6e, 0xdb, 0x54,
+	0x14, 0x27, 0x7f, 0x9a, 0x26, 0x27, 0x6b, 0xd2, 0xde, 0x75, 0x99, 0xb1, 0x34, 0x28, 0x66, 0xeb,
+	0x52, 0xd6, 0x39, 0x53, 0x98, 0x10, 0x1a, 0x1a, 0xa2, 0xeb, 0xa6, 0x6d, 0x88, 0x69, 0xc5, 0x2b,
+	0x4c, 0x4c, 0x4c, 0xd9, 0xad, 0x7d, 0xdb, 0x59, 0xb5, 0x7d, 0xcd, 0xf5, 0x75, 0x59, 0xc4, 0x03,
+	0xf0, 0x0c, 0xbc, 0x02, 0x4f, 0xc0, 0x13, 0xf0, 0x40, 0x7c, 0xe1, 0x13, 0x12, 0xba, 0xd7, 0xf7,
+	0x3a, 0x89, 0xe3, 0x2c, 0xf9, 0xe6, 0x73, 0xee, 0xf9, 0xf3, 0x3b, 0x7f, 0x7c, 0xce, 0x81, 0xeb,
+	0x17, 0x43, 0x86, 0xc7, 0xb6, 0x4b, 0xc3, 0x81, 0x4b, 0x19, 0x19, 0xe0, 0x38, 0x1e, 0xe0, 0xd8,
+	0x1f, 0xb8, 0x34, 0x0c, 0x71, 0xe4, 0xd9, 0x31, 0xa3, 0x9c, 0x22, 0xa4, 0xa5, 0x18, 0xb1, 0x71,
+	0x1c, 0xdb, 0x38, 0xf6, 0xcd, 0x7e, 0x89, 0x66, 0xcc, 0xe8, 0xbb, 0x71, 0x88, 0xa3, 0x81, 0x4b,
+	0xa3, 0x53, 0xff, 0x2c, 0xd3, 0x36, 0xf7, 0x0a, 0x92, 0xc2, 0x36, 0x8d, 0x06, 0xf2, 0xd1, 0xa5,
+	0xc1, 0x20, 0x4d, 0x08, 0x53, 0xa2, 0x77, 0xca, 0x45, 0x13, 0xc2, 0x7c, 0x1c, 0x0c, 0xf8, 0x38,
+	0x26, 0xde, 0x28, 0x24, 0x49, 0x82, 0xcf, 0x48, 0xa6, 0x61, 0xfd, 0x0c, 0x5b, 0x07, 0x9e, 0xf7,
+	0x34, 0x3a, 0xa1, 0x69, 0xe4, 0x39, 0xe4, 0x97, 0x94, 0x24, 0x1c, 0x3d, 0x86, 0x75, 0x3f, 0xe3,
+	0x18, 0x95, 0x9d, 0x4a, 0xbf, 0x3d, 0xbc, 0x6d, 0x17, 0x22, 0xd0, 0x48, 0x6d, 0xa5, 0xf9, 0x04,
+	0x47, 0x5e, 0x40, 0xd8, 0xa1, 0xc4, 0xed, 0x68, 0x6d, 0x6b, 0x1b, 0xd0, 0xb4, 0xf5, 0x24, 0xa6,
+	0x51, 0x42, 0xac, 0x3e, 0x6c, 0x3b, 0x24, 0xa4, 0x17, 0xa4, 0xe0, 0x76, 0x13, 0x6a, 0x1c, 0x9f,
+	0x49, 0x97, 0x2d, 0x47, 0x7c, 0x5a, 0x57, 0xe1, 0x4a, 0x41, 0x52, 0x99, 0x78, 0x02, 0x9b, 0x07,
+	0x9e, 0xf7, 0x43, 0x42, 0xd8, 0xf3, 0x98, 0x30, 0xcc, 0x7d, 0x1a, 0xa1, 0xbb, 0x50, 0x17, 0xa9,
+	0x50, 0x90, 0x77, 0xa6, 0x21, 0x67, 0x79, 0xb0, 0x75, 0xca, 0x6c, 0xa1, 0xe8, 0x48, 0x69, 0xeb,
+	0x16, 0x5c, 0xce, 0x5c, 0xcc, 0x1a, 0xdb, 0x86, 0x35, 0x12, 0x62, 0x3f, 0x50, 0x68, 0x32, 0xc2,
+	0x0a, 0xe1, 0xf2, 0x41, 0xc0, 0x09, 0x5b, 0x06, 0x1c, 0x3d, 0x84, 0x16, 0xd5, 0xb6, 0x8c, 0xaa,
+	0x04, 0xb4, 0x5b, 0x02, 0x28, 0x2b, 0x8c, 0x7d, 0x2c, 0x0a, 0xf3, 0x2c, 0xab, 0x8b, 0x33, 0x51,
+	0xb4, 0x7a, 0xb0, 0x3d, 0xeb, 0x4e, 0x45, 0xff, 0x46, 0xa6, 0xf5, 0x79, 0xca, 0x67, 0x50, 0x7c,
+	0x0b, 0x4d, 0xaa, 0x58, 0x2a, 0x07, 0xf6, 0xc2, 0xb2, 0x69, 0xdd, 0xd9, 0xba, 0xe5, 0xfa, 0xd6,
+	0x15, 0xb8, 0x3c, 0xe3, 0x41, 0x39, 0xde, 0xd3, 0xf5, 0x28, 0xfa, 0x9e, 0x2f, 0x9d, 0x01, 0xbd,
+	0xa2, 0xa8, 0x32, 0xb2, 0x0f, 0xc6, 0x63, 0xc2, 0x73, 0x04, 0x04, 0x07, 0xfc, 0xed, 0x9c, 0x9d,
+	0x9a, 0xb6, 0xf3, 0x57, 0x05, 0x3a, 0xb3, 0xb2, 0x25, 0xe9, 0x36, 0x60, 0xfd, 0xad, 0x7c, 0x1b,
+	0xcb, 0x64, 0x37, 0x1d, 0x4d, 0xa2, 0x4f, 0xe0, 0x52, 0x92, 0xba, 0x2e, 0x49, 0x92, 0x11, 0xc3,
+	0x9c, 0x18, 0xb5, 0x9d, 0x4a, 0xbf, 0xea, 0xb4, 0x15, 0xcf, 0xc1, 0x9c, 0x08, 0x73, 0x8c, 0x73,
+	0xa3, 0xbe, 0x53, 0xe9, 0x6f, 0x38, 0xe2, 0x13, 0xed, 0x42, 0x37, 0xc0, 0x09, 0x1f, 0xc5, 0x8c,
+	0x9e, 0x90, 0x11, 0xf7, 0x43, 0x62, 0xac, 0xed, 0x54, 0xfa, 0x35, 0x67, 0x43, 0xb0, 0x8f, 0x04,
+	0xf7, 0xd8, 0x0f, 0x09, 0xba, 0x06, 0x20, 0xe5, 0x08, 0x63, 0x94, 0x19, 0x0d, 0x89, 0xa7, 0x25,
+	0x38, 0x8f, 0x04, 0xc3, 0x7a, 0x09, 0x1f, 0x96, 0x04, 0x9a, 0x65, 0x01, 0xdd, 0x83, 0x46, 0x86,
+	0x51, 0x06, 0xdb, 0x1e, 0x5a, 0xf6, 0xfc, 0x90, 0xb0, 0x0b, 0xba, 0x4a, 0xc3, 0xfa, 0x0a, 0xba,
+	0x8f, 0x09, 0x7f, 0xc1, 0x31, 0x4f, 0x74, 0xe2, 0x10, 0xd4, 0x23, 0x1c, 0x12, 0x95, 0x14, 0xf9,
+	0x2d, 0x7a, 0x98, 0x91, 0x84, 0x70, 0x95, 0x93, 0x8c, 0xb0, 0xee, 0x40, 0x5d, 0x68, 0x2e, 0xd2,
+	0xb8, 0xc0, 0x41, 0x4a, 0xa4, 0x46, 0xcd, 0xc9, 0x08, 0xeb, 0x1b, 0xd8, 0x9c, 0xb8, 0x53, 0xf0,
+	0xf7, 0xa1, 0x9e, 0x70, 0xcc, 0x55, 0xa3, 0x19, 0x65, 0xe0, 0x85, 0x82, 0x23, 0xa5, 0xac, 0x43,
+	0xd8, 0xfa, 0x3e, 0x25, 0x6c, 0x3c, 0x03, 0xd9, 0x80, 0xf5, 0x18, 0x73, 0x4e, 0x58, 0xa4, 0x30,
+	0x68, 0x72, 0x01, 0xf0, 0x07, 0x80, 0xa6, 0x8d, 0xcc, 0x01, 0xa9, 0xad, 0x00, 0xe4, 0x8f, 0x2a,
+	0xc0, 0x21, 0x8d, 0x22, 0xe2, 0xca, 0xbf, 0xbc, 0x03, 0x55, 0x3f, 0xfb, 0x59, 0xea, 0x4e, 0xd5,
+	0xf7, 0xd0, 0xc7, 0xd0, 0x56, 0xa3, 0x6b, 0x24, 0x3a, 0xac, 0x2a, 0x61, 0x81, 0x62, 0x1d, 0xe3,
+	0x33, 0xd4, 0x83, 0x46, 0x42, 0x53, 0xe6, 0x66, 0x8d, 0xd4, 0x72, 0x14, 0x25, 0x92, 0x29, 0x67,
+	0x4f, 0x3d, 0x4b, 0xa6, 0xf8, 0x46, 0x3b, 0xd0, 0xf6, 0x48, 0xc2, 0xfd, 0x28, 0x9b, 0x02, 0x6b,
+	0xf2, 0x69, 0x9a, 0x25, 0xac, 0x79, 0x34, 0xc4, 0x7e, 0xa4, 0x7a, 0x47, 0x51, 0xa2, 0x69, 0xf5,
+	0x9f, 0x28, 0x71, 0xac, 0x67, 0xaa, 0x9a, 0x27, 0x80, 0x5c, 0x03, 0x48, 0x38, 0x66, 0x3c, 0xeb,
+	0xce, 0xa6, 0x2c, 0x57, 0x4b, 0x72, 0x64, 0x67, 0xf6, 0xa0, 0x91, 0xc6, 0x81, 0x1f, 0x9d, 0x1b,
+	0x2d, 0xf9, 0xa4, 0x28, 0x64, 0x42, 0xd3, 0xa3, 0xbf, 0x46, 0xf2, 0x05, 0xe4, 0x4b, 0x4e, 0x5b,
+	0xcf, 0xa0, 0xf7, 0x9d, 0x9f, 0xf0, 0x49, 0x7a, 0xa6, 0x9b, 0x2b, 0x9f, 0xac, 0x3a, 0xba, 0x65,
+	0xa9, 0xb2, 0x7e, 0x82, 0xab, 0x73, 0xe6, 0x54, 0xcd, 0xbe, 0x06, 0x70, 0x73, 0xb6, 0xaa, 0xdc,
+	0x47, 0x65, 0x95, 0x9b, 0x28, 0x3b, 0x53, 0x1a, 0xd6, 0x7d, 0xb8, 0x7a, 0x18, 0xd0, 0x84, 0x94,
+	0x40, 0xd5, 0x15, 0xad, 0xa9, 0x8a, 0x6a, 0xe8, 0xd5, 0x09, 0x74, 0x6b, 0x08, 0xc6, 0xbc, 0xba,
+	0x82, 0xd6, 0x83, 0x86, 0x2b, 0xde, 0xb2, 0xae, 0xd8, 0x70, 0x14, 0x65, 0xfd, 0x5e, 0x83, 0xcd,
+	0x63, 0x92, 0x70, 0x87, 0xa6, 0x9c, 0x68, 0x67, 0x85, 0x0a, 0x57, 0x4a, 0x2b, 0xac, 0xfa, 0xa5,
+	0x3a, 0xd3, 0x2f, 0x85, 0xec, 0xd5, 0xe6, 0x1a, 0xad, 0xac, 0xa1, 0x6e, 0x40, 0x27, 0x89, 0xfc,
+	0xd3, 0x53, 0xe2, 0x8d, 0x54, 0xdb, 0x64, 0x3d, 0xb5, 0xa1, 0xb8, 0x0f, 0x25, 0x13, 0xed, 0xc1,
+	0xa6, 0x16, 0xd3, 0x0b, 0x4f, 0xf5, 0x57, 0x57, 0xf1, 0x8f, 0x14, 0x5b, 0x0e, 0x30, 0xea, 0xe2,
+	0x60, 0x14, 0x53, 0xc6, 0x65, 0x9b, 0x6d, 0x38, 0x2d, 0xc9, 0x39, 0xa2, 0x8c, 0xa3, 0x63, 0x00,
+	0xcc, 0x39, 0xf3, 0x4f, 0x52, 0x4e, 0x12, 0xa3, 0x29, 0xeb, 0x74, 0xb7, 0xac, 0x4e, 0xc5, 0xcc,
+	0xd8, 0x07, 0xb9, 0xda, 0xa3, 0x88, 0xb3, 0xb1, 0x33, 0x65, 0xc7, 0xbc, 0x0f, 0xdd, 0xc2, 0xb3,
+	0x18, 0xc1, 0xe7, 0x64, 0xac, 0x27, 0xfa, 0x39, 0x19, 0xcf, 0x4e, 0xa2, 0x96, 0x9a, 0x44, 0xf7,
+	0xaa, 0x5f, 0x56, 0xac, 0xdf, 0xa0, 0xe3, 0xa4, 0x01, 0x79, 0x24, 0x38, 0xf9, 0xae, 0xf6, 0x23,
+	0x8f, 0xbc, 0x93, 0xfa, 0x6b, 0x4e, 0x46, 0xe8, 0x2d, 0x51, 0x9d, 0x6c, 0x89, 0x1e, 0x34, 0x18,
+	0xc1, 0x09, 0x8d, 0xf4, 0xcf, 0x9b, 0x51, 0x22, 0x61, 0xf8, 0x94, 0x13, 0x36, 0x62, 0x24, 0xa1,
+	0x41, 0x2a, 0x6b, 0x59, 0x97, 0x93, 0xa7, 0x2b, 0xf9, 0x4e, 0xce, 0xb6, 0xfe, 0xad, 0xc0, 0xd6,
+	0x54, 0xb0, 0xaa, 0x69, 0x6e, 0x42, 0x37, 0x2b, 0xc8, 0x28, 0xe1, 0x62, 0xcb, 0x9c, 0xe9, 0x50,
+	0x3a, 0x19, 0xfb, 0x85, 0xe2, 0xa2, 0x2f, 0xa0, 0xce, 0xd2, 0x40, 0x04, 0xb5, 0x70, 0xe4, 0xcf,
+	0xc6, 0xe6, 0x48, 0x79, 0xf1, 0xdb, 0x4a, 0x6c, 0x17, 0xc4, 0x93, 0xd8, 0x9b, 0x4e, 0x4e, 0x8b,
+	0x56, 0xd2, 0xdf, 0x23, 0x3f, 0x36, 0xea, 0x72, 0x75, 0x82, 0x66, 0x3d, 0x8d, 0xc5, 0x34, 0x09,
+	0x31, 0x77, 0xdf, 0x12, 0x6f, 0x24, 0x9d, 0xaf, 0xc9, 0x2c, 0xb5, 0x15, 0x4f, 0xf8, 0x9b, 0x1b,
+	0x38, 0x8d, 0xb9, 0x81, 0x33, 0xfc, 0xaf, 0x0e, 0x1d, 0x75, 0x2d, 0xbc, 0x20, 0xec, 0xc2, 0x77,
+	0x09, 0x7a, 0x0d, 0x30, 0xb9, 0xee, 0xd0, 0x8d, 0xb2, 0x68, 0xe6, 0x6e, 0x4b, 0x73, 0x77, 0x99,
+	0x98, 0xba, 0x12, 0x3e, 0x40, 0xa7, 0xb0, 0x31, 0x73, 0xfc, 0xa1, 0x7e, 0x69, 0xbe, 0x4a, 0x2e,
+	0x49, 0x73, 0x6f, 0x05, 0xc9, 0xdc, 0x8f, 0x0b, 0x97, 0xa6, 0xaf, 0x2c, 0x74, 0xb3, 0x14, 0xe1,
+	0xfc, 0xd9, 0x67, 0xf6, 0x97, 0x0b, 0xe6, 0x4e, 0xde, 0x40, 0x7b, 0xea, 0xa0, 0x42, 0x8b, 0xb2,
+	0x50, 0xb8, 0xab, 0xcc, 0x9b, 0x4b, 0xe5, 0x72, 0x0f, 0x3e, 0x74, 0x66, 0x0f, 0x2e, 0xf4, 0x9e,
+	0x2c, 0x14, 0xfd, 0x7c, 0xb6, 0x8a, 0x68, 0xee, 0x8a, 0xc1, 0xd6, 0xdc, 0x61, 0x83, 0xf6, 0xcb,
+	0x4c, 0x2c, 0x3a, 0xf4, 0xcc, 0xdb, 0x2b, 0x4a, 0x6b, 0x9f, 0xc3, 0xbf, 0x2b, 0x70, 0x49, 0x6e,
+	0x7e, 0xdd, 0x7d, 0x2f, 0xa1, 0xa9, 0xaf, 0x12, 0xf4, 0xe9, 0x02, 0x6b, 0xd3, 0xf7, 0x86, 0x79,
+	0xfd, 0xfd, 0x42, 0x79, 0x74, 0xaf, 0x01, 0x26, 0x77, 0x46, 0x79, 0x5b, 0xcf, 0x1d, 0x33, 0xe6,
+	0xee, 0x32, 0xb1, 0x3c, 0x90, 0x7f, 0x2a, 0xb0, 0x35, 0xd9, 0x3c, 0x3a, 0x9a, 0x00, 0xba, 0x85,
+	0x6d, 0x89, 0x4a, 0x6b, 0x52, 0xbe, 0xa1, 0xcd, 0x5b, 0x2b, 0xc9, 0xe6, 0x21, 0x52, 0xd8, 0x2c,
+	0x6e, 0x40, 0x54, 0x6a, 0x62, 0xc1, 0x9a, 0x35, 0xf7, 0x57, 0x13, 0xce, 0x83, 0x0e, 0xa0, 0x23,
+	0x46, 0xa6, 0x1f, 0x9d, 0xe9, 0x80, 0x5f, 0x41, 0x2b, 0x1f, 0xa4, 0xe8, 0xfa, 0x2a, 0x4b, 0xc5,
+	0xbc, 0xb1, 0x44, 0x4a, 0x7b, 0x7b, 0x70, 0x17, 0x7a, 0x2e, 0x0d, 0x4b, 0xa4, 0x8f, 0x2a, 0xaf,
+	0x6a, 0x38, 0xf6, 0xff, 0xac, 0xa2, 0x1f, 0x87, 0x0e, 0x1e, 0xdb, 0x87, 0xe2, 0xed, 0x20, 0x8e,
+	0xed, 0x83, 0xd8, 0x3f, 0x69, 0xc8, 0x6d, 0xf9, 0xf9, 0xff, 0x03, 0x00, 0x4d, 0x8c, 0x3a, 0xe6,
+	0xd4, 0x0f, 0x00, 0x00,
+}
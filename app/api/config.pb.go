@@ -0,0 +1,60 @@
+package api
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import v2ray_core_common_net "v2ray.com/core/common/net"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type Config struct {
+	// Port that the API server listens on.
+	DirectPort uint32 `protobuf:"varint,1,opt,name=direct_port,json=directPort" json:"direct_port,omitempty"`
+	// Address that the API server listens on. Default to 127.0.0.1 if unset.
+	Listen *v2ray_core_common_net.IPOrDomain `protobuf:"bytes,2,opt,name=listen" json:"listen,omitempty"`
+}
+
+func (m *Config) Reset()                    { *m = Config{} }
+func (m *Config) String() string            { return proto.CompactTextString(m) }
+func (*Config) ProtoMessage()               {}
+func (*Config) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
+
+func (m *Config) GetDirectPort() uint32 {
+	if m != nil {
+		return m.DirectPort
+	}
+	return 0
+}
+
+func (m *Config) GetListen() *v2ray_core_common_net.IPOrDomain {
+	if m != nil {
+		return m.Listen
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Config)(nil), "v2ray.core.app.api.Config")
+}
+
+func init() { proto.RegisterFile("v2ray.com/core/app/api/config.proto", fileDescriptor1) }
+
+var fileDescriptor1 = []byte{
+	// 208 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x8e, 0xb1, 0x4a, 0x04, 0x31,
+	0x10, 0x40, 0xc9, 0x09, 0x5b, 0xe4, 0xb0, 0x49, 0x21, 0x87, 0x8d, 0xa7, 0x16, 0x5e, 0x35, 0x81,
+	0xd5, 0xc6, 0xf2, 0x5c, 0x1b, 0x2b, 0x97, 0x2d, 0x2c, 0x6c, 0x64, 0x4c, 0xa2, 0x0c, 0x98, 0xcc,
+	0x30, 0x1b, 0x84, 0xfd, 0x25, 0xbf, 0x52, 0xdc, 0x55, 0x10, 0x6d, 0x67, 0xe6, 0xcd, 0x7b, 0xf6,
+	0xfc, 0xbd, 0x55, 0x9c, 0x20, 0x70, 0xf6, 0x81, 0x35, 0x79, 0x14, 0xf1, 0x28, 0xe4, 0x03, 0x97,
+	0x17, 0x7a, 0x05, 0x51, 0xae, 0xec, 0xdc, 0xcf, 0x91, 0x26, 0x40, 0x11, 0x40, 0xa1, 0xe3, 0x8b,
+	0x3f, 0x60, 0xe0, 0x9c, 0xb9, 0xf8, 0x92, 0xaa, 0xc7, 0x18, 0x35, 0x8d, 0xe3, 0x02, 0x9f, 0x45,
+	0xdb, 0x74, 0xf3, 0x33, 0x77, 0x62, 0xd7, 0x91, 0x34, 0x85, 0xfa, 0x24, 0xac, 0x75, 0x63, 0xb6,
+	0x66, 0x77, 0x38, 0xd8, 0x65, 0xd4, 0xb3, 0x56, 0x77, 0x6d, 0x9b, 0x37, 0x1a, 0x6b, 0x2a, 0x9b,
+	0xd5, 0xd6, 0xec, 0xd6, 0xed, 0x29, 0xfc, 0x12, 0x2f, 0x02, 0x28, 0xa9, 0xc2, 0x5d, 0x7f, 0xaf,
+	0xb7, 0x9c, 0x91, 0xca, 0xf0, 0x0d, 0xdc, 0x5c, 0xd9, 0xa3, 0xc0, 0x19, 0xfe, 0x87, 0xf6, 0xe6,
+	0xf1, 0x00, 0x85, 0x3e, 0x56, 0xee, 0xa1, 0x1d, 0x70, 0x82, 0xee, 0x6b, 0xb7, 0x17, 0x81, 0xbd,
+	0xd0, 0x73, 0x33, 0x27, 0x5e, 0x7e, 0x0e, 0x00, 0x42, 0x5d, 0xca, 0x81, 0x06, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/conntrack"
+	"v2ray.com/core/common"
+)
+
+// connectionServer implements ConnectionServiceServer.
+type connectionServer struct {
+	tracker *conntrack.Tracker
+}
+
+func toConnection(s *conntrack.Session) *Connection {
+	c := &Connection{
+		Id:          s.ID,
+		InboundTag:  s.InboundTag,
+		User:        s.User,
+		Domain:      s.Domain(),
+		OutboundTag: s.OutboundTag(),
+		StartTime:   s.Start.Unix(),
+		Uplink:      s.Uplink.Value(),
+		Downlink:    s.Downlink.Value(),
+	}
+	if s.Source.IsValid() {
+		c.Source = s.Source.String()
+	}
+	if s.Destination.IsValid() {
+		c.Destination = s.Destination.String()
+	}
+	return c
+}
+
+func (s *connectionServer) ListConnections(ctx context.Context, request *ListConnectionsRequest) (*ListConnectionsResponse, error) {
+	response := &ListConnectionsResponse{}
+	for _, session := range s.tracker.Sessions() {
+		if len(request.User) > 0 && !strings.EqualFold(session.User, request.User) {
+			continue
+		}
+		if len(request.InboundTag) > 0 && session.InboundTag != request.InboundTag {
+			continue
+		}
+		response.Connection = append(response.Connection, toConnection(session))
+	}
+	return response, nil
+}
+
+func (s *connectionServer) CloseConnections(ctx context.Context, request *CloseConnectionsRequest) (*CloseConnectionsResponse, error) {
+	if len(request.Id) == 0 && len(request.User) == 0 {
+		return nil, newError("neither connection ID nor user is specified")
+	}
+	var closed uint32
+	for _, id := range request.Id {
+		if s.tracker.CloseSession(id) {
+			closed++
+		}
+	}
+	if len(request.User) > 0 {
+		closed += uint32(s.tracker.CloseUser(request.User))
+	}
+	return &CloseConnectionsResponse{
+		Closed: closed,
+	}, nil
+}
+
+// Register implements Service.
+func (s *connectionServer) Register(server *grpc.Server) {
+	RegisterConnectionServiceServer(server, s)
+}
+
+func init() {
+	common.Must(RegisterService(func(space app.Space) (Service, error) {
+		tracker := conntrack.FromSpace(space)
+		if tracker == nil {
+			// Connection tracking is optional.
+			return nil, nil
+		}
+		return &connectionServer{
+			tracker: tracker,
+		}, nil
+	}))
+}
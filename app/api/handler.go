@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/proxyman"
+	"v2ray.com/core/common"
+	"v2ray.com/core/proxy"
+)
+
+// handlerServer implements HandlerServiceServer.
+type handlerServer struct {
+	ctx context.Context
+	ihm proxyman.InboundHandlerManager
+	ohm proxyman.OutboundHandlerManager
+}
+
+func (s *handlerServer) AddInbound(ctx context.Context, request *AddInboundRequest) (*AddInboundResponse, error) {
+	if request.Inbound == nil {
+		return nil, newError("inbound config is not specified")
+	}
+	if err := s.ihm.AddHandler(s.ctx, request.Inbound); err != nil {
+		return nil, newError("failed to add inbound handler").Base(err)
+	}
+	return &AddInboundResponse{}, nil
+}
+
+func (s *handlerServer) RemoveInbound(ctx context.Context, request *RemoveInboundRequest) (*RemoveInboundResponse, error) {
+	if err := s.ihm.RemoveHandler(s.ctx, request.Tag); err != nil {
+		return nil, newError("failed to remove inbound handler").Base(err)
+	}
+	return &RemoveInboundResponse{}, nil
+}
+
+func (s *handlerServer) AlterInbound(ctx context.Context, request *AlterInboundRequest) (*AlterInboundResponse, error) {
+	if request.Operation == nil {
+		return nil, newError("operation is not specified")
+	}
+	rawOperation, err := request.Operation.GetInstance()
+	if err != nil {
+		return nil, newError("unknown operation").Base(err)
+	}
+	operation, ok := rawOperation.(InboundOperation)
+	if !ok {
+		return nil, newError("not an inbound operation")
+	}
+
+	handler, err := s.ihm.GetHandler(s.ctx, request.Tag)
+	if err != nil {
+		return nil, newError("failed to get inbound handler").Base(err)
+	}
+	if err := operation.ApplyInbound(proxy.ContextWithInboundTag(s.ctx, request.Tag), handler); err != nil {
+		return nil, newError("failed to alter inbound handler").Base(err)
+	}
+	return &AlterInboundResponse{}, nil
+}
+
+func (s *handlerServer) AddOutbound(ctx context.Context, request *AddOutboundRequest) (*AddOutboundResponse, error) {
+	if request.Outbound == nil {
+		return nil, newError("outbound config is not specified")
+	}
+	if err := s.ohm.AddHandler(s.ctx, request.Outbound); err != nil {
+		return nil, newError("failed to add outbound handler").Base(err)
+	}
+	return &AddOutboundResponse{}, nil
+}
+
+func (s *handlerServer) RemoveOutbound(ctx context.Context, request *RemoveOutboundRequest) (*RemoveOutboundResponse, error) {
+	if err := s.ohm.RemoveHandler(s.ctx, request.Tag); err != nil {
+		return nil, newError("failed to remove outbound handler").Base(err)
+	}
+	return &RemoveOutboundResponse{}, nil
+}
+
+func (s *handlerServer) GetOutboundHealth(ctx context.Context, request *GetOutboundHealthRequest) (*GetOutboundHealthResponse, error) {
+	tags := request.Tag
+	if len(tags) == 0 {
+		tags = s.ohm.Select([]string{""})
+	}
+	response := &GetOutboundHealthResponse{}
+	for _, tag := range tags {
+		handler := s.ohm.GetHandler(tag)
+		if handler == nil {
+			return nil, newError("outbound handler not found: ", tag)
+		}
+		health := &OutboundHealth{
+			Tag:     tag,
+			Healthy: true,
+		}
+		if reporter, ok := handler.(proxyman.HealthReporter); ok {
+			status := reporter.HealthStatus()
+			health.Healthy = status.Healthy
+			health.SuccessRate = float32(status.SuccessRate)
+			health.Rtt = uint32(status.RTT / time.Millisecond)
+			if !status.LastProbe.IsZero() {
+				health.LastProbeTime = status.LastProbe.Unix()
+			}
+			if status.LastError != nil {
+				health.LastError = status.LastError.Error()
+			}
+		}
+		response.Health = append(response.Health, health)
+	}
+	return response, nil
+}
+
+// Register implements Service.
+func (s *handlerServer) Register(server *grpc.Server) {
+	RegisterHandlerServiceServer(server, s)
+}
+
+func init() {
+	common.Must(RegisterService(func(space app.Space) (Service, error) {
+		ihm := proxyman.InboundHandlerManagerFromSpace(space)
+		if ihm == nil {
+			return nil, newError("InboundHandlerManager is not found in the space")
+		}
+		ohm := proxyman.OutboundHandlerManagerFromSpace(space)
+		if ohm == nil {
+			return nil, newError("OutboundHandlerManager is not found in the space")
+		}
+		return &handlerServer{
+			ctx: app.ContextWithSpace(context.Background(), space),
+			ihm: ihm,
+			ohm: ohm,
+		}, nil
+	}))
+}
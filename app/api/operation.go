@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+
+	"v2ray.com/core/app/proxyman"
+	"v2ray.com/core/proxy"
+)
+
+// InboundOperation is an operation that can be applied to an inbound handler through AlterInbound.
+type InboundOperation interface {
+	// ApplyInbound applies this operation to the given inbound handler.
+	ApplyInbound(context.Context, proxyman.InboundHandler) error
+}
+
+// getUserManager returns the UserManager of the given inbound handler. Only handlers that share a single
+// proxy among all workers are supported, as proxies of other handlers are recreated from time to time.
+func getUserManager(handler proxyman.InboundHandler) (proxy.UserManager, error) {
+	gi, ok := handler.(interface {
+		GetInbound() proxy.Inbound
+	})
+	if !ok {
+		return nil, newError("handler doesn't support altering users")
+	}
+	um, ok := gi.GetInbound().(proxy.UserManager)
+	if !ok {
+		return nil, newError("proxy is not a UserManager")
+	}
+	return um, nil
+}
+
+// ApplyInbound implements InboundOperation.
+func (op *AddUserOperation) ApplyInbound(ctx context.Context, handler proxyman.InboundHandler) error {
+	if op.User == nil {
+		return newError("user is not specified")
+	}
+	um, err := getUserManager(handler)
+	if err != nil {
+		return err
+	}
+	return um.AddUser(ctx, op.User)
+}
+
+// ApplyInbound implements InboundOperation.
+func (op *RemoveUserOperation) ApplyInbound(ctx context.Context, handler proxyman.InboundHandler) error {
+	um, err := getUserManager(handler)
+	if err != nil {
+		return err
+	}
+	return um.RemoveUser(ctx, op.Email)
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/router"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+)
+
+// routingServer implements RoutingServiceServer.
+type routingServer struct {
+	router *router.Router
+}
+
+func toRouteQuery(request *TestRouteRequest) (*router.RouteQuery, error) {
+	dest, err := net.ParseDestination(request.Destination)
+	if err != nil {
+		return nil, newError("invalid destination").Base(err)
+	}
+	query := &router.RouteQuery{
+		Destination:     dest,
+		InboundTag:      request.InboundTag,
+		User:            request.User,
+		SniffedDomain:   request.SniffedDomain,
+		SniffedProtocol: request.SniffedProtocol,
+		Attributes:      request.Attributes,
+	}
+	if request.LocalPort > 0 {
+		port, err := net.PortFromInt(request.LocalPort)
+		if err != nil {
+			return nil, newError("invalid local port").Base(err)
+		}
+		query.LocalPort = port
+	}
+	if len(request.Source) > 0 {
+		source, err := net.ParseDestination(request.Source)
+		if err != nil {
+			return nil, newError("invalid source").Base(err)
+		}
+		query.Source = source
+	}
+	return query, nil
+}
+
+func toTestRouteResponse(trace *router.Trace) *TestRouteResponse {
+	response := &TestRouteResponse{
+		DomainStrategy: trace.DomainStrategy.String(),
+		Resolved:       trace.Resolved,
+		MatchedRule:    int32(trace.MatchedRule),
+		OutboundTag:    trace.OutboundTag,
+	}
+	for _, rule := range trace.Rules {
+		response.Rule = append(response.Rule, &RuleEvaluation{
+			Index:           int32(rule.Index),
+			Tag:             rule.Tag,
+			Reason:          rule.Reason,
+			AfterResolution: rule.AfterResolution,
+		})
+	}
+	for _, ip := range trace.ResolvedIPs {
+		response.ResolvedIp = append(response.ResolvedIp, ip.String())
+	}
+	return response
+}
+
+func (s *routingServer) TestRoute(ctx context.Context, request *TestRouteRequest) (*TestRouteResponse, error) {
+	query, err := toRouteQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	return toTestRouteResponse(s.router.Trace(query.Context())), nil
+}
+
+// Register implements Service.
+func (s *routingServer) Register(server *grpc.Server) {
+	RegisterRoutingServiceServer(server, s)
+}
+
+func init() {
+	common.Must(RegisterService(func(space app.Space) (Service, error) {
+		r := router.FromSpace(space)
+		if r == nil {
+			// Router is optional.
+			return nil, nil
+		}
+		return &routingServer{
+			router: r,
+		}, nil
+	}))
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/stats"
+	"v2ray.com/core/common"
+)
+
+// statsServer implements StatsServiceServer.
+type statsServer struct {
+	stats *stats.Manager
+}
+
+func (s *statsServer) GetStats(ctx context.Context, request *GetStatsRequest) (*GetStatsResponse, error) {
+	c := s.stats.GetCounter(request.Name)
+	if c == nil {
+		return nil, newError(request.Name, " not found.")
+	}
+	var value int64
+	if request.Reset_ {
+		value = c.Set(0)
+	} else {
+		value = c.Value()
+	}
+	return &GetStatsResponse{
+		Stat: &Stat{
+			Name:  request.Name,
+			Value: value,
+		},
+	}, nil
+}
+
+func (s *statsServer) QueryStats(ctx context.Context, request *QueryStatsRequest) (*QueryStatsResponse, error) {
+	response := &QueryStatsResponse{}
+	s.stats.Visit(func(name string, c *stats.Counter) bool {
+		if !strings.Contains(name, request.Pattern) {
+			return true
+		}
+		var value int64
+		if request.Reset_ {
+			value = c.Set(0)
+		} else {
+			value = c.Value()
+		}
+		response.Stat = append(response.Stat, &Stat{
+			Name:  name,
+			Value: value,
+		})
+		return true
+	})
+	return response, nil
+}
+
+// Register implements Service.
+func (s *statsServer) Register(server *grpc.Server) {
+	RegisterStatsServiceServer(server, s)
+}
+
+func init() {
+	common.Must(RegisterService(func(space app.Space) (Service, error) {
+		sm := stats.FromSpace(space)
+		if sm == nil {
+			// Stats is optional.
+			return nil, nil
+		}
+		return &statsServer{
+			stats: sm,
+		}, nil
+	}))
+}
@@ -0,0 +1,44 @@
+package conntrack
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Config is the settings of the connection tracker. When it is present in the config,
+// all dispatched sessions are recorded, and can be listed and closed through the API.
+type Config struct {
+}
+
+func (m *Config) Reset()                    { *m = Config{} }
+func (m *Config) String() string            { return proto.CompactTextString(m) }
+func (*Config) ProtoMessage()               {}
+func (*Config) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func init() {
+	proto.RegisterType((*Config)(nil), "v2ray.core.app.conntrack.Config")
+}
+
+func init() { proto.RegisterFile("v2ray.com/core/app/conntrack/config.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 122 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0x2c, 0x33, 0x2a, 0x4a,
+	0xac, 0xd4, 0x4b, 0xce, 0xcf, 0xd5, 0x4f, 0xce, 0x2f, 0x4a, 0xd5, 0x4f, 0x2c, 0x28, 0xd0, 0x4f,
+	0xce, 0xcf, 0xcb, 0x2b, 0x29, 0x4a, 0x4c, 0xce, 0x06, 0xb1, 0xd2, 0x32, 0xd3, 0xf5, 0x0a, 0x8a,
+	0xf2, 0x4b, 0xf2, 0x85, 0x24, 0x60, 0x4a, 0x8b, 0x52, 0xf5, 0x12, 0x0b, 0x0a, 0xf4, 0xe0, 0xca,
+	0x94, 0x38, 0xb8, 0xd8, 0x9c, 0xc1, 0x2a, 0x9d, 0xdc, 0xb8, 0x64, 0x92, 0xf3, 0x73, 0xf5, 0x70,
+	0xa9, 0x0c, 0x60, 0x8c, 0xe2, 0x84, 0x73, 0x56, 0x31, 0x49, 0x84, 0x19, 0x05, 0x25, 0x56, 0xea,
+	0x39, 0x83, 0xd4, 0x39, 0x16, 0x14, 0xe8, 0x39, 0xc3, 0xa4, 0x92, 0xd8, 0xc0, 0x56, 0x1a, 0x03,
+	0x06, 0x00, 0x9e, 0x97, 0x19, 0x1b, 0x9f, 0x00, 0x00, 0x00,
+}
@@ -0,0 +1,133 @@
+// Package conntrack records dispatched sessions, so that they can be inspected and closed at runtime.
+package conntrack
+
+//go:generate go run $GOPATH/src/v2ray.com/core/tools/generrorgen/main.go -pkg conntrack -path App,Conntrack
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/common"
+)
+
+// Tracker is an application that keeps a table of all live sessions.
+type Tracker struct {
+	access   sync.RWMutex
+	sessions map[uint64]*Session
+	lastID   uint64
+}
+
+// NewTracker creates a new Tracker with the given config.
+func NewTracker(ctx context.Context, config *Config) (*Tracker, error) {
+	return &Tracker{
+		sessions: make(map[uint64]*Session),
+	}, nil
+}
+
+// Interface implements app.Application.
+func (*Tracker) Interface() interface{} {
+	return (*Tracker)(nil)
+}
+
+// Start implements app.Application.
+func (*Tracker) Start() error {
+	return nil
+}
+
+// Close implements app.Application.
+func (*Tracker) Close() {}
+
+// Add assigns an ID to the given session and adds it into the table.
+func (t *Tracker) Add(s *Session) {
+	t.access.Lock()
+	defer t.access.Unlock()
+
+	t.lastID++
+	s.ID = t.lastID
+	t.sessions[s.ID] = s
+}
+
+// Remove removes the session with the given ID from the table.
+func (t *Tracker) Remove(id uint64) {
+	t.access.Lock()
+	defer t.access.Unlock()
+
+	delete(t.sessions, id)
+}
+
+// Get returns the session with the given ID, or nil if it doesn't exist.
+func (t *Tracker) Get(id uint64) *Session {
+	t.access.RLock()
+	defer t.access.RUnlock()
+
+	return t.sessions[id]
+}
+
+// Sessions returns all sessions in the table, ordered by ID.
+func (t *Tracker) Sessions() []*Session {
+	t.access.RLock()
+	sessions := make([]*Session, 0, len(t.sessions))
+	for _, s := range t.sessions {
+		sessions = append(sessions, s)
+	}
+	t.access.RUnlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].ID < sessions[j].ID
+	})
+	return sessions
+}
+
+// CloseSession closes the session with the given ID. It returns false if the session doesn't exist.
+func (t *Tracker) CloseSession(id uint64) bool {
+	s := t.Get(id)
+	if s == nil {
+		return false
+	}
+	s.Close()
+	t.Remove(id)
+	return true
+}
+
+// CloseUser closes all sessions of the user with the given email, and returns the number of closed sessions.
+func (t *Tracker) CloseUser(email string) int {
+	return t.closeMatching(func(s *Session) bool {
+		return strings.EqualFold(s.User, email)
+	})
+}
+
+// CloseInboundUser closes all sessions of the user with the given email from the inbound handler with the given tag,
+// and returns the number of closed sessions.
+func (t *Tracker) CloseInboundUser(tag string, email string) int {
+	return t.closeMatching(func(s *Session) bool {
+		return s.InboundTag == tag && strings.EqualFold(s.User, email)
+	})
+}
+
+func (t *Tracker) closeMatching(match func(*Session) bool) int {
+	closed := 0
+	for _, s := range t.Sessions() {
+		if len(s.User) > 0 && match(s) && t.CloseSession(s.ID) {
+			closed++
+		}
+	}
+	return closed
+}
+
+// FromSpace returns the Tracker in the given space, or nil if it doesn't exist.
+func FromSpace(space app.Space) *Tracker {
+	app := space.GetApplication((*Tracker)(nil))
+	if app == nil {
+		return nil
+	}
+	return app.(*Tracker)
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return NewTracker(ctx, config.(*Config))
+	}))
+}
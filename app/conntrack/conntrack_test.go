@@ -0,0 +1,119 @@
+package conntrack_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	. "v2ray.com/core/app/conntrack"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/testing/assert"
+	"v2ray.com/core/transport/ray"
+)
+
+func TestTracker(t *testing.T) {
+	assert := assert.On(t)
+
+	tracker, err := NewTracker(context.Background(), &Config{})
+	assert.Error(err).IsNil()
+
+	closed := make(map[string]int)
+	newSession := func(user string) *Session {
+		s := NewSession(func() {
+			closed[user]++
+		})
+		s.User = user
+		tracker.Add(s)
+		return s
+	}
+
+	s1 := newSession("a@v2ray.com")
+	s2 := newSession("b@v2ray.com")
+	newSession("a@v2ray.com")
+
+	sessions := tracker.Sessions()
+	assert.Int(len(sessions)).Equals(3)
+	assert.Bool(sessions[0] == s1).IsTrue()
+	assert.Bool(sessions[1] == s2).IsTrue()
+
+	assert.Bool(tracker.CloseSession(s2.ID)).IsTrue()
+	assert.Bool(tracker.CloseSession(s2.ID)).IsFalse()
+	assert.Int(closed["b@v2ray.com"]).Equals(1)
+
+	assert.Int(tracker.CloseUser("A@v2ray.com")).Equals(2)
+	assert.Int(closed["a@v2ray.com"]).Equals(2)
+	assert.Int(len(tracker.Sessions())).Equals(0)
+}
+
+func TestCloseInboundUser(t *testing.T) {
+	assert := assert.On(t)
+
+	tracker, err := NewTracker(context.Background(), &Config{})
+	assert.Error(err).IsNil()
+
+	closed := make(map[string]int)
+	newSession := func(tag string, user string) {
+		s := NewSession(func() {
+			closed[tag]++
+		})
+		s.InboundTag = tag
+		s.User = user
+		tracker.Add(s)
+	}
+
+	newSession("in1", "a@v2ray.com")
+	newSession("in1", "b@v2ray.com")
+	newSession("in2", "a@v2ray.com")
+
+	assert.Int(tracker.CloseInboundUser("in1", "A@v2ray.com")).Equals(1)
+	assert.Int(closed["in1"]).Equals(1)
+	assert.Int(closed["in2"]).Equals(0)
+	assert.Int(len(tracker.Sessions())).Equals(2)
+}
+
+func TestSessionContext(t *testing.T) {
+	assert := assert.On(t)
+
+	ctx := context.Background()
+	assert.Bool(SessionFromContext(ctx) == nil).IsTrue()
+
+	s := NewSession(nil)
+	ctx = ContextWithSession(ctx, s)
+	assert.Bool(SessionFromContext(ctx) == s).IsTrue()
+
+	s.SetDomain("v2ray.com")
+	s.SetOutboundTag("direct")
+	assert.String(s.Domain()).Equals("v2ray.com")
+	assert.String(s.OutboundTag()).Equals("direct")
+}
+
+func TestTrackedRay(t *testing.T) {
+	assert := assert.On(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := ray.NewRay(ctx)
+	outboundEnded := 0
+	inboundEnded := 0
+	outbound := NewTrackedOutboundRay(r, func() {
+		outboundEnded++
+	})
+	inbound := NewTrackedInboundRay(r, func() {
+		inboundEnded++
+	})
+
+	b := buf.New()
+	b.AppendBytes('a')
+	assert.Error(outbound.OutboundOutput().Write(buf.NewMultiBufferValue(b))).IsNil()
+	assert.Int(outboundEnded).Equals(0)
+	outbound.OutboundOutput().Close()
+	assert.Int(outboundEnded).Equals(1)
+
+	_, err := inbound.InboundOutput().Read()
+	assert.Error(err).IsNil()
+	assert.Int(inboundEnded).Equals(0)
+	_, err = inbound.InboundOutput().Read()
+	assert.Error(err).Equals(io.EOF)
+	assert.Int(inboundEnded).Equals(1)
+}
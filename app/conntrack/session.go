@@ -0,0 +1,169 @@
+package conntrack
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"v2ray.com/core/app/stats"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/transport/ray"
+)
+
+// Session is a dispatched connection that is being tracked.
+type Session struct {
+	ID          uint64
+	InboundTag  string
+	Source      net.Destination
+	User        string
+	Destination net.Destination
+	Start       time.Time
+
+	// Uplink and Downlink count the bytes of this session.
+	Uplink   stats.Counter
+	Downlink stats.Counter
+
+	access      sync.RWMutex
+	domain      string
+	outboundTag string
+	closer      func()
+}
+
+// NewSession creates a new Session. The given function is called when the session is closed by Close().
+func NewSession(closer func()) *Session {
+	return &Session{
+		Start:  time.Now(),
+		closer: closer,
+	}
+}
+
+// SetDomain records the domain that is sniffed from the traffic of this session.
+func (s *Session) SetDomain(domain string) {
+	s.access.Lock()
+	s.domain = domain
+	s.access.Unlock()
+}
+
+// Domain returns the sniffed domain of this session, or empty if none.
+func (s *Session) Domain() string {
+	s.access.RLock()
+	defer s.access.RUnlock()
+	return s.domain
+}
+
+// SetOutboundTag records the tag of the outbound handler that runs this session.
+func (s *Session) SetOutboundTag(tag string) {
+	s.access.Lock()
+	s.outboundTag = tag
+	s.access.Unlock()
+}
+
+// OutboundTag returns the tag of the outbound handler that runs this session.
+func (s *Session) OutboundTag() string {
+	s.access.RLock()
+	defer s.access.RUnlock()
+	return s.outboundTag
+}
+
+// Close forcibly closes this session.
+func (s *Session) Close() {
+	if s.closer != nil {
+		s.closer()
+	}
+}
+
+type key int
+
+const (
+	sessionKey key = iota
+)
+
+// ContextWithSession returns a new context with the given session.
+func ContextWithSession(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, sessionKey, s)
+}
+
+// SessionFromContext returns the session in the context, or nil if the session is not tracked.
+func SessionFromContext(ctx context.Context) *Session {
+	s, _ := ctx.Value(sessionKey).(*Session)
+	return s
+}
+
+type trackedInputStream struct {
+	ray.InputStream
+	onEnd func()
+}
+
+func (s *trackedInputStream) Read() (buf.MultiBuffer, error) {
+	mb, err := s.InputStream.Read()
+	if err != nil {
+		s.onEnd()
+	}
+	return mb, err
+}
+
+func (s *trackedInputStream) ReadTimeout(timeout time.Duration) (buf.MultiBuffer, error) {
+	mb, err := s.InputStream.ReadTimeout(timeout)
+	if err != nil && err != buf.ErrReadTimeout {
+		s.onEnd()
+	}
+	return mb, err
+}
+
+type trackedInboundRay struct {
+	ray.InboundRay
+	output ray.InputStream
+}
+
+func (r *trackedInboundRay) InboundOutput() ray.InputStream {
+	return r.output
+}
+
+// NewTrackedInboundRay returns an InboundRay that calls onEnd when the inbound connection fails to read more from
+// the downlink, which means the session has ended. onEnd may be called more than once.
+func NewTrackedInboundRay(r ray.InboundRay, onEnd func()) ray.InboundRay {
+	return &trackedInboundRay{
+		InboundRay: r,
+		output: &trackedInputStream{
+			InputStream: r.InboundOutput(),
+			onEnd:       onEnd,
+		},
+	}
+}
+
+type trackedOutputStream struct {
+	ray.OutputStream
+	onEnd func()
+}
+
+func (s *trackedOutputStream) Close() {
+	s.OutputStream.Close()
+	s.onEnd()
+}
+
+func (s *trackedOutputStream) CloseError() {
+	s.OutputStream.CloseError()
+	s.onEnd()
+}
+
+type trackedOutboundRay struct {
+	ray.OutboundRay
+	output ray.OutputStream
+}
+
+func (r *trackedOutboundRay) OutboundOutput() ray.OutputStream {
+	return r.output
+}
+
+// NewTrackedOutboundRay returns an OutboundRay that calls onEnd when the outbound connection closes the downlink,
+// which means the session has ended. onEnd may be called more than once.
+func NewTrackedOutboundRay(r ray.OutboundRay, onEnd func()) ray.OutboundRay {
+	return &trackedOutboundRay{
+		OutboundRay: r,
+		output: &trackedOutputStream{
+			OutputStream: r.OutboundOutput(),
+			onEnd:        onEnd,
+		},
+	}
+}
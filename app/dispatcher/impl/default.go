@@ -4,16 +4,22 @@ package impl
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"v2ray.com/core/app"
+	"v2ray.com/core/app/conntrack"
 	"v2ray.com/core/app/dispatcher"
+	"v2ray.com/core/app/dns"
 	"v2ray.com/core/app/log"
+	"v2ray.com/core/app/metrics"
 	"v2ray.com/core/app/proxyman"
 	"v2ray.com/core/app/router"
+	"v2ray.com/core/app/stats"
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
 	"v2ray.com/core/proxy"
 	"v2ray.com/core/transport/ray"
 )
@@ -22,14 +28,39 @@ var (
 	errSniffingTimeout = newError("timeout on sniffing")
 )
 
+var (
+	dispatchTotal = metrics.NewCounterVec("v2ray_dispatch_total", "Number of dispatched connections by routing result and outbound tag.", "result", "tag")
+	sniffingTotal = metrics.NewCounterVec("v2ray_dispatch_sniffing_total", "Number of protocol sniffing attempts by result.", "result")
+)
+
 var (
 	_ app.Application = (*DefaultDispatcher)(nil)
 )
 
+// routingSniffers are the protocols sniffed when routing rules depend on the sniffing result.
+var routingSniffers = []proxyman.KnownProtocols{
+	proxyman.KnownProtocols_HTTP,
+	proxyman.KnownProtocols_TLS,
+	proxyman.KnownProtocols_BitTorrent,
+}
+
+// containsProtocol returns true if the sniffed protocol name is in the list.
+func containsProtocol(list []proxyman.KnownProtocols, protocol string) bool {
+	for _, p := range list {
+		if strings.EqualFold(p.String(), protocol) {
+			return true
+		}
+	}
+	return false
+}
+
 // DefaultDispatcher is a default implementation of Dispatcher.
 type DefaultDispatcher struct {
-	ohm    proxyman.OutboundHandlerManager
-	router *router.Router
+	ohm     proxyman.OutboundHandlerManager
+	router  *router.Router
+	stats   *stats.Manager
+	tracker *conntrack.Tracker
+	fakeDNS dns.FakeDNS
 }
 
 // NewDefaultDispatcher create a new DefaultDispatcher.
@@ -45,6 +76,9 @@ func NewDefaultDispatcher(ctx context.Context, config *dispatcher.Config) (*Defa
 			return newError("OutboundHandlerManager is not found in the space")
 		}
 		d.router = router.FromSpace(space)
+		d.stats = stats.FromSpace(space)
+		d.tracker = conntrack.FromSpace(space)
+		d.fakeDNS = dns.FakeDNSFromSpace(space)
 		return nil
 	})
 	return d, nil
@@ -68,50 +102,149 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 	if !destination.IsValid() {
 		panic("Dispatcher: Invalid destination.")
 	}
+	if d.fakeDNS != nil && !destination.Address.Family().IsDomain() {
+		if domain := d.fakeDNS.GetDomainFromFakeIP(destination.Address.IP()); len(domain) > 0 {
+			log.Trace(newError("restoring domain ", domain, " from fake IP ", destination.Address).AtDebug())
+			destination.Address = net.DomainAddress(domain)
+		}
+	}
 	ctx = proxy.ContextWithTarget(ctx, destination)
 
-	outbound := ray.NewRay(ctx)
+	var outbound ray.OutboundRay
+	var inbound ray.InboundRay
+	if d.tracker != nil {
+		ctx, outbound, inbound = d.track(ctx, destination)
+	} else {
+		r := ray.NewRay(ctx)
+		outbound, inbound = r, r
+	}
+	inbound = d.withStats(ctx, inbound)
+
 	sniferList := proxyman.ProtocoSniffersFromContext(ctx)
-	if destination.Address.Family().IsDomain() || len(sniferList) == 0 {
+	overrideDomain := !destination.Address.Family().IsDomain() && len(sniferList) > 0
+	sniffForRouting := d.router != nil && d.router.NeedsSniffing()
+	if !overrideDomain && !sniffForRouting {
 		go d.routedDispatch(ctx, outbound, destination)
 	} else {
-		go func() {
-			domain, err := snifer(ctx, sniferList, outbound)
+		protocols := sniferList
+		httpHeaders := false
+		if sniffForRouting {
+			protocols = routingSniffers
+			httpHeaders = d.router.NeedsHTTPHeaders()
+		}
+		go func(ctx context.Context) {
+			result, err := snifer(ctx, NewSniffer(protocols, httpHeaders), outbound)
 			if err == nil {
-				log.Trace(newError("sniffed domain: ", domain))
-				destination.Address = net.ParseAddress(domain)
-				ctx = proxy.ContextWithTarget(ctx, destination)
+				sniffingTotal.With("success").Inc()
+				log.Trace(newError("sniffed protocol: ", result.Protocol, ", domain: ", result.Domain))
+				if overrideDomain && len(result.Domain) > 0 && containsProtocol(sniferList, result.Protocol) {
+					if session := conntrack.SessionFromContext(ctx); session != nil {
+						session.SetDomain(result.Domain)
+					}
+					destination.Address = net.ParseAddress(result.Domain)
+					ctx = proxy.ContextWithTarget(ctx, destination)
+				}
+			} else {
+				sniffingTotal.With("failure").Inc()
+				result = new(proxy.SniffingResult)
 			}
+			ctx = proxy.ContextWithSniffingResult(ctx, result)
 			d.routedDispatch(ctx, outbound, destination)
-		}()
+		}(ctx)
 	}
-	return outbound, nil
+	return inbound, nil
 }
 
-func snifer(ctx context.Context, sniferList []proxyman.KnownProtocols, outbound ray.OutboundRay) (string, error) {
+// track creates a new ray for a session and records the session in the tracker. Closing the session breaks
+// the ray. The session is removed from the tracker when the context is done, the outbound closes the downlink, or
+// the inbound fails to read more from the downlink.
+func (d *DefaultDispatcher) track(ctx context.Context, destination net.Destination) (context.Context, ray.OutboundRay, ray.InboundRay) {
+	ctx, cancel := context.WithCancel(ctx)
+	r := ray.NewRay(ctx)
+
+	// Streams return EOF when the context is done, so the session is closed by breaking the streams instead,
+	// in order that both sides see an error.
+	session := conntrack.NewSession(func() {
+		r.InboundInput().CloseError()
+		r.InboundOutput().CloseError()
+	})
+	if tag, ok := proxy.InboundTagFromContext(ctx); ok {
+		session.InboundTag = tag
+	}
+	if source, ok := proxy.SourceFromContext(ctx); ok {
+		session.Source = source
+	}
+	if user := protocol.UserFromContext(ctx); user != nil {
+		session.User = user.Email
+	}
+	session.Destination = destination
+
+	d.tracker.Add(session)
+	remove := func() {
+		d.tracker.Remove(session.ID)
+	}
+	go func() {
+		<-ctx.Done()
+		remove()
+	}()
+
+	// The outbound may close the downlink while the inbound is still sending the remaining data to the client, so
+	// only the record is removed then, without cancelling the context.
+	outbound := conntrack.NewTrackedOutboundRay(r, remove)
+	inbound := conntrack.NewTrackedInboundRay(r, cancel)
+	inbound = ray.NewStatInboundRay(inbound, &session.Uplink, &session.Downlink)
+	return conntrack.ContextWithSession(ctx, session), outbound, inbound
+}
+
+// withStats wraps the given ray so that its traffic is counted for the inbound tag and the user in the context.
+func (d *DefaultDispatcher) withStats(ctx context.Context, inbound ray.InboundRay) ray.InboundRay {
+	if d.stats == nil {
+		return inbound
+	}
+	if tag, ok := proxy.InboundTagFromContext(ctx); ok && len(tag) > 0 {
+		inbound = ray.NewStatInboundRay(inbound,
+			d.stats.GetOrRegisterCounter(stats.InboundTrafficCounterName(tag, stats.Uplink)),
+			d.stats.GetOrRegisterCounter(stats.InboundTrafficCounterName(tag, stats.Downlink)))
+	}
+	if user := protocol.UserFromContext(ctx); user != nil && len(user.Email) > 0 {
+		inbound = ray.NewStatInboundRay(inbound,
+			d.stats.GetOrRegisterCounter(stats.UserTrafficCounterName(user.Email, stats.Uplink)),
+			d.stats.GetOrRegisterCounter(stats.UserTrafficCounterName(user.Email, stats.Downlink)))
+	}
+	return inbound
+}
+
+// snifer sniffs the payload from the ray until the sniffer succeeds or fails. If the payload stays incomplete, the
+// partial result of the sniffer is used.
+func snifer(ctx context.Context, sniffer *Sniffer, outbound ray.OutboundRay) (*proxy.SniffingResult, error) {
 	payload := buf.New()
 	defer payload.Release()
 
-	sniffer := NewSniffer(sniferList)
 	totalAttempt := 0
 	for {
 		select {
 		case <-ctx.Done():
-			return "", ctx.Err()
+			return nil, ctx.Err()
 		default:
 			totalAttempt++
 			if totalAttempt > 5 {
-				return "", errSniffingTimeout
+				if result := sniffer.Partial(); result != nil {
+					return result, nil
+				}
+				return nil, errSniffingTimeout
 			}
 			outbound.OutboundInput().Peek(payload)
 			if !payload.IsEmpty() {
-				domain, err := sniffer.Sniff(payload.Bytes())
+				result, err := sniffer.Sniff(payload.Bytes())
 				if err != ErrMoreData {
-					return domain, err
+					return result, err
 				}
 			}
 			if payload.IsFull() {
-				return "", ErrInvalidData
+				if result := sniffer.Partial(); result != nil {
+					return result, nil
+				}
+				return nil, ErrInvalidData
 			}
 			time.Sleep(time.Millisecond * 100)
 		}
@@ -120,18 +253,23 @@ func snifer(ctx context.Context, sniferList []proxyman.KnownProtocols, outbound
 
 func (d *DefaultDispatcher) routedDispatch(ctx context.Context, outbound ray.OutboundRay, destination net.Destination) {
 	dispatcher := d.ohm.GetDefaultHandler()
+	result, resultTag := "default", ""
 	if d.router != nil {
 		if tag, err := d.router.TakeDetour(ctx); err == nil {
+			resultTag = tag
 			if handler := d.ohm.GetHandler(tag); handler != nil {
 				log.Trace(newError("taking detour [", tag, "] for [", destination, "]"))
 				dispatcher = handler
+				result = "routed"
 			} else {
 				log.Trace(newError("nonexisting tag: ", tag).AtWarning())
+				result = "missing_tag"
 			}
 		} else {
 			log.Trace(newError("default route for ", destination))
 		}
 	}
+	dispatchTotal.With(result, resultTag).Inc()
 	dispatcher.Dispatch(ctx, outbound)
 }
 
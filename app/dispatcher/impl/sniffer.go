@@ -2,10 +2,12 @@ package impl
 
 import (
 	"bytes"
+	"net/http"
 	"strings"
 
 	"v2ray.com/core/app/proxyman"
 	"v2ray.com/core/common/serial"
+	"v2ray.com/core/proxy"
 )
 
 var (
@@ -26,30 +28,92 @@ func ContainsValidHTTPMethod(b []byte) bool {
 }
 
 func SniffHTTP(b []byte) (string, error) {
+	result, err := sniffHTTPRequest(b)
+	if err != nil {
+		return "", err
+	}
+	return result.Domain, nil
+}
+
+// sniffHTTPRequest parses the request line and headers of a HTTP request. It succeeds once the Host header is
+// found, with all complete header lines in the payload.
+func sniffHTTPRequest(b []byte) (*proxy.SniffingResult, error) {
+	result, _, err := parseHTTPRequest(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Domain) == 0 {
+		return nil, ErrMoreData
+	}
+	return result, nil
+}
+
+// sniffHTTPHeaders is like sniffHTTPRequest, but it succeeds only after all the headers of the request are received.
+// Until then, it returns ErrMoreData, along with the headers so far if the Host header is found.
+func sniffHTTPHeaders(b []byte) (*proxy.SniffingResult, error) {
+	result, complete, err := parseHTTPRequest(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Domain) == 0 {
+		return nil, ErrMoreData
+	}
+	if !complete {
+		return result, ErrMoreData
+	}
+	return result, nil
+}
+
+// parseHTTPRequest parses the request line and all complete header lines in the payload. complete is true if the
+// payload contains the end of the headers.
+func parseHTTPRequest(b []byte) (result *proxy.SniffingResult, complete bool, err error) {
 	if len(b) == 0 {
-		return "", ErrMoreData
+		return nil, false, ErrMoreData
 	}
-	headers := bytes.Split(b, []byte{'\n'})
-	if !ContainsValidHTTPMethod(headers[0]) {
-		return "", ErrInvalidData
+	lines := bytes.Split(b, []byte{'\n'})
+	if !ContainsValidHTTPMethod(lines[0]) {
+		return nil, false, ErrInvalidData
 	}
-	for i := 1; i < len(headers); i++ {
-		header := headers[i]
-		if len(header) == 0 {
-			return "", ErrInvalidData
+
+	result = &proxy.SniffingResult{
+		Protocol:   "http",
+		HTTPHeader: make(http.Header),
+	}
+	requestLine := strings.Fields(string(lines[0]))
+	result.HTTPMethod = strings.ToUpper(requestLine[0])
+	if len(requestLine) > 1 {
+		result.HTTPPath = requestLine[1]
+	}
+
+	// The last line is either incomplete, or empty after the last line break.
+	lines = lines[:len(lines)-1]
+
+	for i := 1; i < len(lines); i++ {
+		header := bytes.TrimRight(lines[i], "\r")
+		if len(bytes.TrimSpace(header)) == 0 {
+			// End of headers.
+			if len(result.Domain) == 0 {
+				return nil, false, ErrInvalidData
+			}
+			return result, true, nil
 		}
 		parts := bytes.SplitN(header, []byte{':'}, 2)
 		if len(parts) != 2 {
-			return "", ErrInvalidData
+			if len(result.Domain) > 0 {
+				// Headers after a malformed line are ignored.
+				return result, true, nil
+			}
+			return nil, false, ErrInvalidData
 		}
-		key := strings.ToLower(string(parts[0]))
-		value := strings.ToLower(string(bytes.Trim(parts[1], " ")))
-		if key == "host" {
-			domain := strings.Split(value, ":")
-			return strings.TrimSpace(domain[0]), nil
+		key := strings.TrimSpace(string(parts[0]))
+		value := strings.TrimSpace(string(parts[1]))
+		result.HTTPHeader.Add(key, value)
+		if strings.ToLower(key) == "host" && len(result.Domain) == 0 {
+			domain := strings.Split(strings.ToLower(value), ":")
+			result.Domain = strings.TrimSpace(domain[0])
 		}
 	}
-	return "", ErrMoreData
+	return result, false, nil
 }
 
 func IsValidTLSVersion(major, minor byte) bool {
@@ -151,38 +215,91 @@ func ReadClientHello(data []byte) (string, error) {
 }
 
 func SniffTLS(b []byte) (string, error) {
+	result, err := sniffTLS(b)
+	if err != nil {
+		return "", err
+	}
+	return result.Domain, nil
+}
+
+func sniffTLS(b []byte) (*proxy.SniffingResult, error) {
 	if len(b) < 5 {
-		return "", ErrMoreData
+		return nil, ErrMoreData
 	}
 
 	if b[0] != 0x16 /* TLS Handshake */ {
-		return "", ErrInvalidData
+		return nil, ErrInvalidData
 	}
 	if !IsValidTLSVersion(b[1], b[2]) {
-		return "", ErrInvalidData
+		return nil, ErrInvalidData
 	}
 	headerLen := int(serial.BytesToUint16(b[3:5]))
 	if 5+headerLen > len(b) {
-		return "", ErrMoreData
+		return nil, ErrMoreData
+	}
+	domain, err := ReadClientHello(b[5 : 5+headerLen])
+	if err != nil {
+		return nil, err
+	}
+	return &proxy.SniffingResult{
+		Protocol: "tls",
+		Domain:   domain,
+	}, nil
+}
+
+var bitTorrentHandshake = []byte("\x13BitTorrent protocol")
+
+// SniffBitTorrent recognizes the BitTorrent peer handshake, and DHT messages which are bencoded dictionaries.
+func SniffBitTorrent(b []byte) (*proxy.SniffingResult, error) {
+	if len(b) == 0 {
+		return nil, ErrMoreData
 	}
-	return ReadClientHello(b[5 : 5+headerLen])
+	switch b[0] {
+	case bitTorrentHandshake[0]:
+		if len(b) < len(bitTorrentHandshake) {
+			if !bytes.HasPrefix(bitTorrentHandshake, b) {
+				return nil, ErrInvalidData
+			}
+			return nil, ErrMoreData
+		}
+		if !bytes.HasPrefix(b, bitTorrentHandshake) {
+			return nil, ErrInvalidData
+		}
+	case 'd':
+		if !bytes.HasPrefix(b, []byte("d1:")) || !bytes.Contains(b, []byte("1:y1:")) {
+			return nil, ErrInvalidData
+		}
+	default:
+		return nil, ErrInvalidData
+	}
+	return &proxy.SniffingResult{
+		Protocol: "bittorrent",
+	}, nil
 }
 
 type Sniffer struct {
-	slist []func([]byte) (string, error)
-	err   []error
+	slist   []func([]byte) (*proxy.SniffingResult, error)
+	err     []error
+	partial *proxy.SniffingResult
 }
 
-func NewSniffer(sniferList []proxyman.KnownProtocols) *Sniffer {
+// NewSniffer creates a Sniffer of the given protocols. If httpHeaders is true, HTTP requests are sniffed until all
+// the headers are received, instead of until the Host header is found.
+func NewSniffer(sniferList []proxyman.KnownProtocols, httpHeaders bool) *Sniffer {
 	s := new(Sniffer)
 
 	for _, protocol := range sniferList {
-		var f func([]byte) (string, error)
+		var f func([]byte) (*proxy.SniffingResult, error)
 		switch protocol {
 		case proxyman.KnownProtocols_HTTP:
-			f = SniffHTTP
+			f = sniffHTTPRequest
+			if httpHeaders {
+				f = sniffHTTPHeaders
+			}
 		case proxyman.KnownProtocols_TLS:
-			f = SniffTLS
+			f = sniffTLS
+		case proxyman.KnownProtocols_BitTorrent:
+			f = SniffBitTorrent
 		default:
 			panic("Unsupported protocol")
 		}
@@ -193,23 +310,33 @@ func NewSniffer(sniferList []proxyman.KnownProtocols) *Sniffer {
 	return s
 }
 
-func (s *Sniffer) Sniff(payload []byte) (string, error) {
+// Sniff returns the result of the first sniffer that recognizes the payload, or ErrMoreData if any sniffer
+// needs more data.
+func (s *Sniffer) Sniff(payload []byte) (*proxy.SniffingResult, error) {
 	sniffed := false
 	for idx, sniffer := range s.slist {
 		if s.err[idx] != nil {
 			continue
 		}
 		sniffed = true
-		domain, err := sniffer(payload)
+		result, err := sniffer(payload)
 		if err == nil {
-			return domain, nil
+			return result, nil
 		}
 		if err != ErrMoreData {
 			s.err[idx] = err
+		} else if result != nil {
+			s.partial = result
 		}
 	}
 	if sniffed {
-		return "", ErrMoreData
+		return nil, ErrMoreData
 	}
-	return "", s.err[0]
+	return nil, s.err[0]
+}
+
+// Partial returns the last result that was recognized but incomplete, for example a HTTP request with the Host header
+// but not all the headers, or nil if there is none.
+func (s *Sniffer) Partial() *proxy.SniffingResult {
+	return s.partial
 }
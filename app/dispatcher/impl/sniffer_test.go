@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	. "v2ray.com/core/app/dispatcher/impl"
+	"v2ray.com/core/app/proxyman"
 	"v2ray.com/core/testing/assert"
 )
 
@@ -184,3 +185,83 @@ func TestTLSHeaders(t *testing.T) {
 		assert.Error(err).Equals(test.err)
 	}
 }
+
+func TestSniffHTTPRequest(t *testing.T) {
+	assert := assert.On(t)
+
+	sniffer := NewSniffer([]proxyman.KnownProtocols{proxyman.KnownProtocols_HTTP, proxyman.KnownProtocols_TLS}, false)
+	result, err := sniffer.Sniff([]byte("GET /api/v1?q=1 HTTP/1.1\r\nHost: www.v2ray.com:8080\r\nUser-Agent: curl/7.54\r\nAccept: */*\r\nAccept: text/html\r\n\r\n"))
+	assert.Error(err).IsNil()
+	assert.String(result.Protocol).Equals("http")
+	assert.String(result.Domain).Equals("www.v2ray.com")
+	assert.String(result.HTTPMethod).Equals("GET")
+	assert.String(result.HTTPPath).Equals("/api/v1?q=1")
+	assert.String(result.HTTPHeader.Get("user-agent")).Equals("curl/7.54")
+	assert.Int(len(result.HTTPHeader["Accept"])).Equals(2)
+
+	sniffer = NewSniffer([]proxyman.KnownProtocols{proxyman.KnownProtocols_HTTP, proxyman.KnownProtocols_TLS}, false)
+	_, err = sniffer.Sniff([]byte("GET / HTTP/1.1\r\nUser-"))
+	assert.Error(err).Equals(ErrMoreData)
+}
+
+func TestSniffHTTPHeaders(t *testing.T) {
+	assert := assert.On(t)
+
+	sniffer := NewSniffer([]proxyman.KnownProtocols{proxyman.KnownProtocols_HTTP}, false)
+	result, err := sniffer.Sniff([]byte("GET / HTTP/1.1\r\nHost: www.v2ray.com\r\nUser-Agent: curl/7.54\r\n"))
+	assert.Error(err).IsNil()
+	assert.String(result.HTTPHeader.Get("User-Agent")).Equals("curl/7.54")
+
+	sniffer = NewSniffer([]proxyman.KnownProtocols{proxyman.KnownProtocols_HTTP}, true)
+	_, err = sniffer.Sniff([]byte("GET / HTTP/1.1\r\nUser-Agent: curl/7.54\r\n"))
+	assert.Error(err).Equals(ErrMoreData)
+	assert.Pointer(sniffer.Partial()).IsNil()
+	_, err = sniffer.Sniff([]byte("GET / HTTP/1.1\r\nUser-Agent: curl/7.54\r\nHost: www.v2ray.com\r\n"))
+	assert.Error(err).Equals(ErrMoreData)
+	assert.String(sniffer.Partial().Domain).Equals("www.v2ray.com")
+	result, err = sniffer.Sniff([]byte("GET / HTTP/1.1\r\nUser-Agent: curl/7.54\r\nHost: www.v2ray.com\r\nX-Token: abc\r\n\r\n"))
+	assert.Error(err).IsNil()
+	assert.String(result.HTTPHeader.Get("X-Token")).Equals("abc")
+}
+
+func TestSniffBitTorrent(t *testing.T) {
+	assert := assert.On(t)
+
+	cases := []struct {
+		input string
+		err   error
+	}{
+		{
+			input: "\x13BitTorrent protocol\x00\x00\x00\x00\x00\x10\x00\x05",
+			err:   nil,
+		},
+		{
+			input: "\x13BitTorr",
+			err:   ErrMoreData,
+		},
+		{
+			input: "\x13BitTorrent protocoX",
+			err:   ErrInvalidData,
+		},
+		{
+			input: "d1:ad2:id20:abcdefghij0123456789e1:q4:ping1:t2:aa1:y1:qe",
+			err:   nil,
+		},
+		{
+			input: "d4:name5:v2ray",
+			err:   ErrInvalidData,
+		},
+		{
+			input: "GET / HTTP/1.1",
+			err:   ErrInvalidData,
+		},
+	}
+
+	for _, test := range cases {
+		result, err := SniffBitTorrent([]byte(test.input))
+		assert.Error(err).Equals(test.err)
+		if err == nil {
+			assert.String(result.Protocol).Equals("bittorrent")
+		}
+	}
+}
@@ -18,3 +18,23 @@ func (c *Config) GetInternalHosts() map[string]net.IP {
 	}
 	return hosts
 }
+
+// Select returns the addresses to use among the given IPv4 and IPv6 addresses.
+func (p IPPreference) Select(ipv4, ipv6 []net.IP) []net.IP {
+	switch p {
+	case IPPreference_IPv4Only:
+		return ipv4
+	case IPPreference_IPv6Only:
+		return ipv6
+	case IPPreference_PreferIPv6:
+		if len(ipv6) > 0 {
+			return ipv6
+		}
+		return ipv4
+	default:
+		if len(ipv4) > 0 {
+			return ipv4
+		}
+		return ipv6
+	}
+}
@@ -17,18 +17,122 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// IPPreference specifies which address families are used when resolving a domain.
+type IPPreference int32
+
+const (
+	// Use IPv4 addresses if there are any, otherwise IPv6 addresses.
+	IPPreference_PreferIPv4 IPPreference = 0
+	// Use IPv6 addresses if there are any, otherwise IPv4 addresses.
+	IPPreference_PreferIPv6 IPPreference = 1
+	// Use IPv4 addresses only.
+	IPPreference_IPv4Only IPPreference = 2
+	// Use IPv6 addresses only.
+	IPPreference_IPv6Only IPPreference = 3
+)
+
+var IPPreference_name = map[int32]string{
+	0: "PreferIPv4",
+	1: "PreferIPv6",
+	2: "IPv4Only",
+	3: "IPv6Only",
+}
+var IPPreference_value = map[string]int32{
+	"PreferIPv4": 0,
+	"PreferIPv6": 1,
+	"IPv4Only":   2,
+	"IPv6Only":   3,
+}
+
+func (x IPPreference) String() string {
+	return proto.EnumName(IPPreference_name, int32(x))
+}
+func (IPPreference) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type NameServer struct {
+	// URL of the name server. The scheme selects the protocol:
+	// "udp://8.8.8.8:53", "tcp://8.8.8.8:53", "https://dns.google/dns-query" (DoH using POST)
+	// or "https+get://dns.google/dns-query" (DoH using GET). Port defaults to 53 for udp and tcp.
+	// A special value 'localhost' uses DNS on local system.
+	Url string `protobuf:"bytes,1,opt,name=url" json:"url,omitempty"`
+	// Domain suffixes this server is authoritative for, e.g. "corp.example.com" matches the domain and its subdomains.
+	// If any of the suffixes or regular expressions matches a domain, the domain is resolved only by the servers
+	// matching it. Servers without any suffix or regular expression resolve the other domains.
+	Domain []string `protobuf:"bytes,2,rep,name=domain" json:"domain,omitempty"`
+	// Regular expressions of domains this server is authoritative for.
+	Regex []string `protobuf:"bytes,3,rep,name=regex" json:"regex,omitempty"`
+}
+
+func (m *NameServer) Reset()                    { *m = NameServer{} }
+func (m *NameServer) String() string            { return proto.CompactTextString(m) }
+func (*NameServer) ProtoMessage()               {}
+func (*NameServer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *NameServer) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *NameServer) GetDomain() []string {
+	if m != nil {
+		return m.Domain
+	}
+	return nil
+}
+
+func (m *NameServer) GetRegex() []string {
+	if m != nil {
+		return m.Regex
+	}
+	return nil
+}
+
+// FakeDNS hands out fake IPs from a reserved pool for domains, and remembers the domain of each fake IP.
+type FakeDNSConfig struct {
+	// CIDR of the fake IPs. Default to "198.18.0.0/15".
+	IpPool string `protobuf:"bytes,1,opt,name=ip_pool,json=ipPool" json:"ip_pool,omitempty"`
+	// Maximum number of domains mapped at the same time. The least recently used mapping is dropped to make room for
+	// a new one. Default to 65535, or the number of IPs in the pool if less.
+	PoolSize uint32 `protobuf:"varint,2,opt,name=pool_size,json=poolSize" json:"pool_size,omitempty"`
+}
+
+func (m *FakeDNSConfig) Reset()                    { *m = FakeDNSConfig{} }
+func (m *FakeDNSConfig) String() string            { return proto.CompactTextString(m) }
+func (*FakeDNSConfig) ProtoMessage()               {}
+func (*FakeDNSConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *FakeDNSConfig) GetIpPool() string {
+	if m != nil {
+		return m.IpPool
+	}
+	return ""
+}
+
+func (m *FakeDNSConfig) GetPoolSize() uint32 {
+	if m != nil {
+		return m.PoolSize
+	}
+	return 0
+}
+
 type Config struct {
-	// Nameservers used by this DNS. Only traditional UDP servers are support at the moment.
+	// Nameservers used by this DNS. Only traditional UDP servers are support in this field.
 	// A special value 'localhost' as a domain address can be set to use DNS on local system.
 	NameServers []*v2ray_core_common_net2.Endpoint `protobuf:"bytes,1,rep,name=NameServers" json:"NameServers,omitempty"`
 	// Static hosts. Domain to IP.
 	Hosts map[string]*v2ray_core_common_net.IPOrDomain `protobuf:"bytes,2,rep,name=Hosts" json:"Hosts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Nameservers specified by URL. They are queried after the ones in NameServers.
+	NameServer []*NameServer `protobuf:"bytes,3,rep,name=name_server,json=nameServer" json:"name_server,omitempty"`
+	// Fake DNS for clients of the DNS inbound. Disabled if not set.
+	FakeDns *FakeDNSConfig `protobuf:"bytes,4,opt,name=fake_dns,json=fakeDns" json:"fake_dns,omitempty"`
 }
 
 func (m *Config) Reset()                    { *m = Config{} }
 func (m *Config) String() string            { return proto.CompactTextString(m) }
 func (*Config) ProtoMessage()               {}
-func (*Config) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (*Config) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
 func (m *Config) GetNameServers() []*v2ray_core_common_net2.Endpoint {
 	if m != nil {
@@ -44,30 +148,59 @@ func (m *Config) GetHosts() map[string]*v2ray_core_common_net.IPOrDomain {
 	return nil
 }
 
+func (m *Config) GetNameServer() []*NameServer {
+	if m != nil {
+		return m.NameServer
+	}
+	return nil
+}
+
+func (m *Config) GetFakeDns() *FakeDNSConfig {
+	if m != nil {
+		return m.FakeDns
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterType((*NameServer)(nil), "v2ray.core.app.dns.NameServer")
+	proto.RegisterType((*FakeDNSConfig)(nil), "v2ray.core.app.dns.FakeDNSConfig")
 	proto.RegisterType((*Config)(nil), "v2ray.core.app.dns.Config")
+	proto.RegisterEnum("v2ray.core.app.dns.IPPreference", IPPreference_name, IPPreference_value)
 }
 
 func init() { proto.RegisterFile("v2ray.com/core/app/dns/config.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 286 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0x41, 0x4b, 0x33, 0x31,
-	0x10, 0x86, 0xc9, 0x96, 0x16, 0xbe, 0xf4, 0xf2, 0x91, 0x83, 0x2c, 0xbd, 0x58, 0x15, 0xb1, 0x20,
-	0x4c, 0x60, 0x15, 0x14, 0x3d, 0xd5, 0xb6, 0xa0, 0x17, 0x2d, 0x2b, 0x78, 0xd0, 0x53, 0xdc, 0x44,
-	0x59, 0x34, 0x33, 0x21, 0x89, 0x0b, 0xfb, 0x97, 0xfc, 0x5f, 0xfe, 0x0f, 0xe9, 0x06, 0xb1, 0xa8,
-	0xbd, 0x85, 0xe4, 0x79, 0xf2, 0xbe, 0x33, 0x7c, 0xaf, 0x29, 0xbc, 0x6a, 0xa1, 0x22, 0x2b, 0x2b,
-	0xf2, 0x46, 0x2a, 0xe7, 0xa4, 0xc6, 0x20, 0x2b, 0xc2, 0xa7, 0xfa, 0x19, 0x9c, 0xa7, 0x48, 0x42,
-	0x7c, 0x41, 0xde, 0x80, 0x72, 0x0e, 0x34, 0x86, 0xd1, 0xc1, 0x0f, 0xb1, 0x22, 0x6b, 0x09, 0x25,
-	0x9a, 0x28, 0x95, 0xd6, 0xde, 0x84, 0x90, 0xe4, 0xd1, 0xe1, 0x66, 0x50, 0x9b, 0x10, 0x6b, 0x54,
-	0xb1, 0x26, 0x4c, 0xf0, 0xee, 0x07, 0xe3, 0x83, 0x59, 0x17, 0x2d, 0xa6, 0x7c, 0x78, 0xad, 0xac,
-	0xb9, 0x35, 0xbe, 0x31, 0x3e, 0xe4, 0x6c, 0xdc, 0x9b, 0x0c, 0x8b, 0x6d, 0x58, 0xab, 0x92, 0x7e,
-	0x02, 0x34, 0x11, 0x16, 0xa8, 0x1d, 0xd5, 0x18, 0xcb, 0x75, 0x47, 0x9c, 0xf3, 0xfe, 0x25, 0x85,
-	0x18, 0xf2, 0xac, 0x93, 0xf7, 0xe1, 0xf7, 0x1c, 0x90, 0xd2, 0xa0, 0xe3, 0x16, 0x18, 0x7d, 0x5b,
-	0x26, 0x67, 0xf4, 0xc0, 0xf9, 0xf7, 0xa5, 0xf8, 0xcf, 0x7b, 0x2f, 0xa6, 0xcd, 0xd9, 0x98, 0x4d,
-	0xfe, 0x95, 0xab, 0xa3, 0x38, 0xe1, 0xfd, 0x46, 0xbd, 0xbe, 0x99, 0x3c, 0x1b, 0xb3, 0xc9, 0xb0,
-	0xd8, 0xd9, 0xd0, 0xec, 0x6a, 0x79, 0xe3, 0xe7, 0x64, 0x55, 0x8d, 0x65, 0xe2, 0xcf, 0xb2, 0x53,
-	0x76, 0x71, 0xcc, 0xb7, 0x2a, 0xb2, 0x7f, 0xf4, 0x59, 0xb2, 0xfb, 0x9e, 0xc6, 0xf0, 0x9e, 0x89,
-	0xbb, 0xa2, 0x54, 0x2d, 0xcc, 0x56, 0x6f, 0x53, 0xe7, 0x60, 0x8e, 0xe1, 0x71, 0xd0, 0x2d, 0xe9,
-	0xe8, 0x33, 0x00, 0x00, 0xff, 0xff, 0x2d, 0xe1, 0x96, 0x19, 0xb5, 0x01, 0x00, 0x00,
+	// 466 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x61, 0x6b, 0xd3, 0x50,
+	0x14, 0x35, 0x89, 0xed, 0xda, 0x9b, 0x4d, 0xca, 0x43, 0x66, 0xa8, 0xa0, 0xb5, 0x22, 0x16, 0x85,
+	0x17, 0x88, 0x63, 0x8a, 0x0a, 0x32, 0xd7, 0x8a, 0x15, 0xd9, 0x42, 0x0a, 0x7e, 0xd0, 0x0f, 0xe5,
+	0x99, 0xdc, 0x8e, 0xd0, 0xe6, 0xbe, 0xc7, 0x7b, 0x59, 0x31, 0xfb, 0x49, 0xfe, 0x2e, 0x7f, 0x88,
+	0x24, 0x69, 0x6d, 0x37, 0xbb, 0x6f, 0x39, 0xf7, 0x9d, 0x73, 0xde, 0x3b, 0xe7, 0x06, 0x9e, 0x2e,
+	0x03, 0x2d, 0x0a, 0x1e, 0xcb, 0xcc, 0x8f, 0xa5, 0x46, 0x5f, 0x28, 0xe5, 0x27, 0x64, 0xfc, 0x58,
+	0xd2, 0x2c, 0xbd, 0xe0, 0x4a, 0xcb, 0x5c, 0x32, 0xb6, 0x26, 0x69, 0xe4, 0x42, 0x29, 0x9e, 0x90,
+	0xe9, 0x3e, 0xbf, 0x21, 0x8c, 0x65, 0x96, 0x49, 0xf2, 0x09, 0x73, 0x5f, 0x24, 0x89, 0x46, 0x63,
+	0x6a, 0x71, 0xf7, 0xe5, 0xed, 0xc4, 0x04, 0x4d, 0x9e, 0x92, 0xc8, 0x53, 0x49, 0x35, 0xb9, 0xff,
+	0x15, 0xe0, 0x4c, 0x64, 0x38, 0x41, 0xbd, 0x44, 0xcd, 0x3a, 0xe0, 0x5c, 0xea, 0x85, 0x67, 0xf5,
+	0xac, 0x41, 0x3b, 0x2a, 0x3f, 0xd9, 0x21, 0x34, 0x13, 0x99, 0x89, 0x94, 0x3c, 0xbb, 0xe7, 0x0c,
+	0xda, 0xd1, 0x0a, 0xb1, 0xfb, 0xd0, 0xd0, 0x78, 0x81, 0xbf, 0x3c, 0xa7, 0x1a, 0xd7, 0xa0, 0x3f,
+	0x82, 0x83, 0x4f, 0x62, 0x8e, 0xc3, 0xb3, 0xc9, 0x69, 0x15, 0x87, 0x3d, 0x80, 0xbd, 0x54, 0x4d,
+	0x95, 0x94, 0x6b, 0xd3, 0x66, 0xaa, 0x42, 0x29, 0x17, 0xec, 0x21, 0xb4, 0xcb, 0xe9, 0xd4, 0xa4,
+	0x57, 0xe8, 0xd9, 0x3d, 0x6b, 0x70, 0x10, 0xb5, 0xca, 0xc1, 0x24, 0xbd, 0xc2, 0xfe, 0x1f, 0x1b,
+	0x9a, 0x2b, 0x83, 0x13, 0x70, 0x37, 0xef, 0x33, 0x9e, 0xd5, 0x73, 0x06, 0x6e, 0xf0, 0x98, 0x6f,
+	0xf5, 0x53, 0xc7, 0xe3, 0x84, 0x39, 0x1f, 0x51, 0xa2, 0x64, 0x4a, 0x79, 0xb4, 0xad, 0x61, 0xef,
+	0xa0, 0xf1, 0x59, 0x9a, 0xdc, 0x54, 0x09, 0xdc, 0xe0, 0x19, 0xff, 0xbf, 0x5c, 0x5e, 0xdf, 0xc6,
+	0x2b, 0xde, 0x88, 0x72, 0x5d, 0x44, 0xb5, 0x86, 0x7d, 0x00, 0x97, 0x44, 0x86, 0x53, 0x53, 0x99,
+	0x55, 0x69, 0xdd, 0xe0, 0xd1, 0x2e, 0x8b, 0xcd, 0x95, 0x11, 0xd0, 0xbf, 0x6f, 0xf6, 0x1e, 0x5a,
+	0x33, 0x31, 0xc7, 0x69, 0x42, 0xc6, 0xbb, 0xdb, 0xb3, 0x06, 0x6e, 0xf0, 0x64, 0x97, 0xfa, 0x5a,
+	0x6d, 0xd1, 0x5e, 0x29, 0x19, 0x92, 0xe9, 0xfe, 0x00, 0xd8, 0xbc, 0xa9, 0x5c, 0xcf, 0x1c, 0x8b,
+	0xf5, 0x7a, 0xe6, 0x58, 0xb0, 0xd7, 0xd0, 0x58, 0x8a, 0xc5, 0x65, 0x5d, 0xe1, 0x0d, 0xeb, 0xad,
+	0x62, 0xc6, 0xe1, 0xb9, 0x1e, 0x56, 0x8b, 0x8b, 0x6a, 0xfe, 0x5b, 0xfb, 0x8d, 0xf5, 0xe2, 0x0b,
+	0xec, 0x8f, 0xc3, 0x50, 0xe3, 0x0c, 0x35, 0x52, 0x8c, 0xec, 0x1e, 0x40, 0x8d, 0xc6, 0xe1, 0xf2,
+	0xa8, 0x73, 0xe7, 0x1a, 0x3e, 0xee, 0x58, 0x6c, 0x1f, 0x5a, 0xe5, 0xc9, 0x39, 0x2d, 0x8a, 0x8e,
+	0xbd, 0x42, 0xc7, 0x15, 0x72, 0x3e, 0x1e, 0xc1, 0x61, 0x2c, 0xb3, 0x1d, 0xc9, 0x42, 0xeb, 0xbb,
+	0x93, 0x90, 0xf9, 0x6d, 0xb3, 0x6f, 0x41, 0x24, 0x0a, 0x7e, 0x5a, 0x9e, 0x9d, 0x28, 0xc5, 0x87,
+	0x64, 0x7e, 0x36, 0xab, 0x9f, 0xf0, 0xd5, 0xdf, 0x01, 0x00, 0xc0, 0xde, 0x54, 0x04, 0x15, 0x03,
+	0x00, 0x00,
 }
@@ -10,7 +10,11 @@ import (
 
 // A Server is a DNS server for responding DNS queries.
 type Server interface {
+	// Get returns the IPs of the given domain, preferring IPv4 addresses.
 	Get(domain string) []net.IP
+
+	// GetIP returns the IPs of the given domain, selected by the given preference.
+	GetIP(domain string, preference IPPreference) []net.IP
 }
 
 // FromSpace fetches a DNS server from context.
@@ -21,3 +25,22 @@ func FromSpace(space app.Space) Server {
 	}
 	return app.(Server)
 }
+
+// FakeDNS hands out fake IPs for domains, so that connections to the fake IPs can be restored to the domains.
+type FakeDNS interface {
+	// GetFakeIP returns the fake IP of the domain, allocating one if necessary.
+	GetFakeIP(domain string) net.IP
+
+	// GetDomainFromFakeIP returns the domain of the fake IP, or empty if the IP is not mapped to any domain.
+	GetDomainFromFakeIP(ip net.IP) string
+}
+
+// FakeDNSFromSpace fetches the fake DNS of the DNS server in space. It returns nil if fake DNS is not enabled.
+func FakeDNSFromSpace(space app.Space) FakeDNS {
+	if s, ok := FromSpace(space).(interface {
+		FakeDNS() FakeDNS
+	}); ok {
+		return s.FakeDNS()
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/miekg/dns"
+	"v2ray.com/core/app/dispatcher"
+	"v2ray.com/core/app/log"
+	"v2ray.com/core/app/proxyman/outbound"
+	v2net "v2ray.com/core/common/net"
+)
+
+const dnsMessageType = "application/dns-message"
+
+// DoHNameServer is a NameServer that queries over HTTPS as described in RFC 8484.
+// Connections to the server are established through the dispatcher.
+type DoHNameServer struct {
+	url    *url.URL
+	method string
+	client *http.Client
+}
+
+// NewDoHNameServer creates a DoHNameServer that sends queries to the given URL, using either GET or POST method.
+func NewDoHNameServer(u *url.URL, method string, dispatcher dispatcher.Interface) *DoHNameServer {
+	return &DoHNameServer{
+		url:    u,
+		method: method,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(_ context.Context, network, addr string) (net.Conn, error) {
+					host, port, err := net.SplitHostPort(addr)
+					if err != nil {
+						return nil, err
+					}
+					p, err := v2net.PortFromString(port)
+					if err != nil {
+						return nil, err
+					}
+					dest := v2net.TCPDestination(v2net.ParseAddress(host), p)
+					// The connection may be kept alive for later queries, so it must not be bound to the context of a single request.
+					stream, err := dispatcher.Dispatch(context.Background(), dest)
+					if err != nil {
+						return nil, err
+					}
+					return outbound.NewConnection(stream), nil
+				},
+				IdleConnTimeout: time.Second * 60,
+			},
+			Timeout: QueryTimeout,
+		},
+	}
+}
+
+func (s *DoHNameServer) QueryA(domain string) <-chan *ARecord {
+	return s.lookup(domain, dns.TypeA)
+}
+
+func (s *DoHNameServer) QueryAAAA(domain string) <-chan *ARecord {
+	return s.lookup(domain, dns.TypeAAAA)
+}
+
+func (s *DoHNameServer) lookup(domain string, qtype uint16) <-chan *ARecord {
+	response := make(chan *ARecord, 1)
+
+	go func() {
+		defer close(response)
+
+		// RFC 8484 recommends ID 0 for cache friendliness.
+		msg, err := s.query(newQuery(domain, 0, qtype))
+		if err != nil {
+			log.Trace(newError("failed to query ", domain, " from ", s.url).Base(err).AtWarning())
+			return
+		}
+		if record := parseARecord(msg); record != nil {
+			response <- record
+		}
+	}()
+
+	return response
+}
+
+func (s *DoHNameServer) newRequest(payload []byte) (*http.Request, error) {
+	if s.method == http.MethodGet {
+		u := *s.url
+		query := u.Query()
+		query.Set("dns", base64.RawURLEncoding.EncodeToString(payload))
+		u.RawQuery = query.Encode()
+		return http.NewRequest(http.MethodGet, u.String(), nil)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url.String(), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", dnsMessageType)
+	return req, nil
+}
+
+func (s *DoHNameServer) query(msg *dns.Msg) (*dns.Msg, error) {
+	payload, err := msg.Pack()
+	if err != nil {
+		return nil, newError("failed to pack DNS message").Base(err)
+	}
+	req, err := s.newRequest(payload)
+	if err != nil {
+		return nil, newError("failed to create request").Base(err)
+	}
+	req.Header.Set("Accept", dnsMessageType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, newError("failed to send request").Base(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newError("unexpected status: ", resp.Status)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, dns.MaxMsgSize))
+	if err != nil {
+		return nil, newError("failed to read response").Base(err)
+	}
+	reply := new(dns.Msg)
+	if err := reply.Unpack(body); err != nil {
+		return nil, newError("failed to parse DNS response").Base(err)
+	}
+	return reply, nil
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"regexp"
+	"strings"
+
+	"v2ray.com/core/app/dns"
+)
+
+// domainScope is the set of domains a name server is authoritative for.
+type domainScope struct {
+	suffixes []string
+	regexes  []*regexp.Regexp
+}
+
+// newDomainScope returns the scope of the given name server, or nil if it resolves any domain.
+func newDomainScope(config *dns.NameServer) (*domainScope, error) {
+	if len(config.Domain) == 0 && len(config.Regex) == 0 {
+		return nil, nil
+	}
+	scope := new(domainScope)
+	for _, suffix := range config.Domain {
+		suffix = strings.Trim(strings.ToLower(suffix), ".")
+		if len(suffix) == 0 {
+			return nil, newError("empty domain for name server ", config.Url)
+		}
+		scope.suffixes = append(scope.suffixes, suffix)
+	}
+	for _, expr := range config.Regex {
+		r, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, newError("invalid regex for name server ", config.Url).Base(err)
+		}
+		scope.regexes = append(scope.regexes, r)
+	}
+	return scope, nil
+}
+
+// Match returns true if the given domain, without the trailing dot, is in this scope.
+func (s *domainScope) Match(domain string) bool {
+	domain = strings.ToLower(domain)
+	for _, suffix := range s.suffixes {
+		if domain == suffix || strings.HasSuffix(domain, "."+suffix) {
+			return true
+		}
+	}
+	for _, r := range s.regexes {
+		if r.MatchString(domain) {
+			return true
+		}
+	}
+	return false
+}
+
+type scopedNameServer struct {
+	scope  *domainScope
+	server NameServer
+}
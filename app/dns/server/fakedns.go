@@ -0,0 +1,133 @@
+package server
+
+import (
+	"container/list"
+	"net"
+	"strings"
+	"sync"
+
+	"v2ray.com/core/app/dns"
+)
+
+const (
+	defaultFakeIPPool   = "198.18.0.0/15"
+	defaultFakePoolSize = 65535
+)
+
+type fakeEntry struct {
+	domain string
+	ip     net.IP
+}
+
+// FakeIPPool maps domains to IPs in a reserved network and back. When the pool is full, the least recently used
+// mapping is dropped and its IP is given to the new domain.
+type FakeIPPool struct {
+	sync.Mutex
+	network  *net.IPNet
+	capacity uint32
+	next     uint32
+	lru      *list.List
+	byDomain map[string]*list.Element
+	byIP     map[string]*list.Element
+}
+
+func NewFakeIPPool(config *dns.FakeDNSConfig) (*FakeIPPool, error) {
+	cidr := config.IpPool
+	if len(cidr) == 0 {
+		cidr = defaultFakeIPPool
+	}
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, newError("invalid fake IP pool: ", cidr).Base(err)
+	}
+
+	// The first IP in the network is not used.
+	ones, bits := network.Mask.Size()
+	available := uint64(1<<32 - 1)
+	if bits-ones < 32 {
+		available = uint64(1)<<uint(bits-ones) - 1
+	}
+	capacity := uint64(config.PoolSize)
+	if capacity == 0 {
+		capacity = defaultFakePoolSize
+	}
+	if capacity > available {
+		capacity = available
+	}
+	if capacity == 0 {
+		return nil, newError("fake IP pool is too small: ", cidr)
+	}
+
+	return &FakeIPPool{
+		network:  network,
+		capacity: uint32(capacity),
+		next:     1,
+		lru:      list.New(),
+		byDomain: make(map[string]*list.Element),
+		byIP:     make(map[string]*list.Element),
+	}, nil
+}
+
+// ipAt returns the IP at the given offset in the network.
+func (p *FakeIPPool) ipAt(offset uint32) net.IP {
+	ip := make(net.IP, len(p.network.IP))
+	copy(ip, p.network.IP)
+	carry := uint64(offset)
+	for i := len(ip) - 1; i >= 0 && carry > 0; i-- {
+		sum := uint64(ip[i]) + carry&0xff
+		ip[i] = byte(sum)
+		carry = carry>>8 + sum>>8
+	}
+	return ip
+}
+
+// GetFakeIP implements dns.FakeDNS.
+func (p *FakeIPPool) GetFakeIP(domain string) net.IP {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	p.Lock()
+	defer p.Unlock()
+
+	if e, found := p.byDomain[domain]; found {
+		p.lru.MoveToFront(e)
+		return e.Value.(*fakeEntry).ip
+	}
+
+	var ip net.IP
+	if uint32(p.lru.Len()) < p.capacity {
+		ip = p.ipAt(p.next)
+		p.next++
+	} else {
+		oldest := p.lru.Remove(p.lru.Back()).(*fakeEntry)
+		delete(p.byDomain, oldest.domain)
+		delete(p.byIP, string(oldest.ip))
+		ip = oldest.ip
+	}
+
+	e := p.lru.PushFront(&fakeEntry{
+		domain: domain,
+		ip:     ip,
+	})
+	p.byDomain[domain] = e
+	p.byIP[string(ip)] = e
+	return ip
+}
+
+// GetDomainFromFakeIP implements dns.FakeDNS.
+func (p *FakeIPPool) GetDomainFromFakeIP(ip net.IP) string {
+	if !p.network.Contains(ip) {
+		return ""
+	}
+	if ip4 := ip.To4(); ip4 != nil && len(p.network.IP) == net.IPv4len {
+		ip = ip4
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if e, found := p.byIP[string(ip)]; found {
+		p.lru.MoveToFront(e)
+		return e.Value.(*fakeEntry).domain
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"net"
+	"testing"
+
+	"v2ray.com/core/app/dns"
+	"v2ray.com/core/testing/assert"
+)
+
+func TestFakeIPPool(t *testing.T) {
+	assert := assert.On(t)
+
+	pool, err := NewFakeIPPool(&dns.FakeDNSConfig{
+		IpPool:   "198.18.0.0/16",
+		PoolSize: 2,
+	})
+	assert.Error(err).IsNil()
+
+	assert.String(pool.GetFakeIP("v2ray.com").String()).Equals("198.18.0.1")
+	assert.String(pool.GetFakeIP("github.com.").String()).Equals("198.18.0.2")
+	assert.String(pool.GetFakeIP("V2Ray.com").String()).Equals("198.18.0.1")
+	assert.String(pool.GetDomainFromFakeIP(net.ParseIP("198.18.0.2"))).Equals("github.com")
+
+	// v2ray.com is the least recently used, so its IP is given to the new domain.
+	assert.String(pool.GetFakeIP("google.com").String()).Equals("198.18.0.1")
+	assert.String(pool.GetDomainFromFakeIP(net.ParseIP("198.18.0.1"))).Equals("google.com")
+	assert.String(pool.GetDomainFromFakeIP(net.IP{198, 18, 0, 2})).Equals("github.com")
+	assert.String(pool.GetDomainFromFakeIP(net.ParseIP("198.18.0.3"))).IsEmpty()
+	assert.String(pool.GetDomainFromFakeIP(net.ParseIP("10.0.0.1"))).IsEmpty()
+}
+
+func TestFakeIPPoolSize(t *testing.T) {
+	assert := assert.On(t)
+
+	pool, err := NewFakeIPPool(&dns.FakeDNSConfig{
+		IpPool: "fc00::ff/120",
+	})
+	assert.Error(err).IsNil()
+	assert.Int(int(pool.capacity)).Equals(255)
+	for i := 0; i < 255; i++ {
+		pool.GetFakeIP(string(rune('a'+i%26)) + string(rune('a'+i/26)))
+	}
+	assert.String(pool.GetDomainFromFakeIP(net.ParseIP("fc00::ff"))).Equals("uj")
+	assert.String(pool.GetFakeIP("v2ray.com").String()).Equals("fc00::1")
+
+	_, err = NewFakeIPPool(&dns.FakeDNSConfig{
+		IpPool: "198.18.0.1/32",
+	})
+	assert.Error(err).IsNotNil()
+	_, err = NewFakeIPPool(&dns.FakeDNSConfig{
+		IpPool: "198.18.0.0",
+	})
+	assert.Error(err).IsNotNil()
+}
@@ -25,13 +25,79 @@ var (
 	pseudoDestination = v2net.UDPDestination(v2net.LocalHostIP, v2net.Port(53))
 )
 
+// ARecord holds the addresses in the response of an A or AAAA query.
 type ARecord struct {
 	IPs    []net.IP
 	Expire time.Time
 }
 
+// NameServer resolves domains. The returned channel is closed without a record if the query fails.
 type NameServer interface {
 	QueryA(domain string) <-chan *ARecord
+	QueryAAAA(domain string) <-chan *ARecord
+}
+
+// newQuery creates a recursive query for records of the given type, either dns.TypeA or dns.TypeAAAA.
+func newQuery(domain string, id uint16, qtype uint16) *dns.Msg {
+	msg := new(dns.Msg)
+	msg.Id = id
+	msg.RecursionDesired = true
+	msg.Question = []dns.Question{
+		{
+			Name:   dns.Fqdn(domain),
+			Qtype:  qtype,
+			Qclass: dns.ClassINET,
+		}}
+	return msg
+}
+
+// parseARecord collects the addresses in the answer section of a DNS response. The record expires after the minimum TTL among them.
+// A response without addresses expires after the negative caching TTL in its SOA record, or FailureTTL if there is none.
+// It returns nil if the response is an error, such as SERVFAIL or REFUSED.
+func parseARecord(msg *dns.Msg) *ARecord {
+	if msg.Rcode != dns.RcodeSuccess {
+		log.Trace(newError("DNS response ", dns.RcodeToString[msg.Rcode], " for ", msg.Question).AtDebug())
+		return nil
+	}
+	record := &ARecord{
+		IPs: make([]net.IP, 0, 16),
+	}
+	ttl := DefaultTTL
+	for _, rr := range msg.Answer {
+		switch rr := rr.(type) {
+		case *dns.A:
+			record.IPs = append(record.IPs, rr.A)
+			if rr.Hdr.Ttl < ttl {
+				ttl = rr.Hdr.Ttl
+			}
+		case *dns.AAAA:
+			record.IPs = append(record.IPs, rr.AAAA)
+			if rr.Hdr.Ttl < ttl {
+				ttl = rr.Hdr.Ttl
+			}
+		}
+	}
+	if len(record.IPs) == 0 {
+		record.Expire = time.Now().Add(negativeTTL(msg))
+		return record
+	}
+	record.Expire = time.Now().Add(time.Second * time.Duration(ttl))
+	return record
+}
+
+// negativeTTL returns how long a response without addresses can be cached, which is the smaller one of the TTL and
+// the minimum field of the SOA record in the authority section, as in RFC 2308.
+func negativeTTL(msg *dns.Msg) time.Duration {
+	for _, rr := range msg.Ns {
+		if soa, ok := rr.(*dns.SOA); ok {
+			ttl := soa.Hdr.Ttl
+			if soa.Minttl < ttl {
+				ttl = soa.Minttl
+			}
+			return time.Second * time.Duration(ttl)
+		}
+	}
+	return FailureTTL
 }
 
 type PendingRequest struct {
@@ -107,11 +173,7 @@ func (v *UDPNameServer) HandleResponse(payload *buf.Buffer) {
 		log.Trace(newError("failed to parse DNS response").Base(err).AtWarning())
 		return
 	}
-	record := &ARecord{
-		IPs: make([]net.IP, 0, 16),
-	}
 	id := msg.Id
-	ttl := DefaultTTL
 	log.Trace(newError("handling response for id ", id, " content: ", msg.String()).AtDebug())
 
 	v.Lock()
@@ -123,37 +185,14 @@ func (v *UDPNameServer) HandleResponse(payload *buf.Buffer) {
 	delete(v.requests, id)
 	v.Unlock()
 
-	for _, rr := range msg.Answer {
-		switch rr := rr.(type) {
-		case *dns.A:
-			record.IPs = append(record.IPs, rr.A)
-			if rr.Hdr.Ttl < ttl {
-				ttl = rr.Hdr.Ttl
-			}
-		case *dns.AAAA:
-			record.IPs = append(record.IPs, rr.AAAA)
-			if rr.Hdr.Ttl < ttl {
-				ttl = rr.Hdr.Ttl
-			}
-		}
+	if record := parseARecord(msg); record != nil {
+		request.response <- record
 	}
-	record.Expire = time.Now().Add(time.Second * time.Duration(ttl))
-
-	request.response <- record
 	close(request.response)
 }
 
-func (v *UDPNameServer) BuildQueryA(domain string, id uint16) *buf.Buffer {
-
-	msg := new(dns.Msg)
-	msg.Id = id
-	msg.RecursionDesired = true
-	msg.Question = []dns.Question{
-		{
-			Name:   dns.Fqdn(domain),
-			Qtype:  dns.TypeA,
-			Qclass: dns.ClassINET,
-		}}
+func (v *UDPNameServer) BuildQuery(domain string, id uint16, qtype uint16) *buf.Buffer {
+	msg := newQuery(domain, id, qtype)
 
 	buffer := buf.New()
 	buffer.AppendSupplier(func(b []byte) (int, error) {
@@ -165,11 +204,19 @@ func (v *UDPNameServer) BuildQueryA(domain string, id uint16) *buf.Buffer {
 }
 
 func (v *UDPNameServer) QueryA(domain string) <-chan *ARecord {
+	return v.query(domain, dns.TypeA)
+}
+
+func (v *UDPNameServer) QueryAAAA(domain string) <-chan *ARecord {
+	return v.query(domain, dns.TypeAAAA)
+}
+
+func (v *UDPNameServer) query(domain string, qtype uint16) <-chan *ARecord {
 	response := make(chan *ARecord, 1)
 	id := v.AssignUnusedID(response)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*8)
-	v.udpServer.Dispatch(ctx, v.address, v.BuildQueryA(domain, id), v.HandleResponse)
+	v.udpServer.Dispatch(ctx, v.address, v.BuildQuery(domain, id, qtype), v.HandleResponse)
 
 	go func() {
 		for i := 0; i < 2; i++ {
@@ -178,7 +225,7 @@ func (v *UDPNameServer) QueryA(domain string) <-chan *ARecord {
 			_, found := v.requests[id]
 			v.Unlock()
 			if found {
-				v.udpServer.Dispatch(ctx, v.address, v.BuildQueryA(domain, id), v.HandleResponse)
+				v.udpServer.Dispatch(ctx, v.address, v.BuildQuery(domain, id, qtype), v.HandleResponse)
 			} else {
 				break
 			}
@@ -193,15 +240,39 @@ type LocalNameServer struct {
 }
 
 func (v *LocalNameServer) QueryA(domain string) <-chan *ARecord {
+	return v.query(domain, "ip4")
+}
+
+func (v *LocalNameServer) QueryAAAA(domain string) <-chan *ARecord {
+	return v.query(domain, "ip6")
+}
+
+// isNotFound returns true if the lookup error means that the domain has no address of the requested family.
+func isNotFound(err error) bool {
+	switch err := err.(type) {
+	case *net.DNSError:
+		return err.IsNotFound
+	case *net.AddrError:
+		// Addresses are found, but none of them is of the requested family.
+		return true
+	default:
+		return false
+	}
+}
+
+func (v *LocalNameServer) query(domain string, network string) <-chan *ARecord {
 	response := make(chan *ARecord, 1)
 
 	go func() {
 		defer close(response)
 
-		ips, err := net.LookupIP(domain)
+		ips, err := net.DefaultResolver.LookupIP(context.Background(), network, domain)
 		if err != nil {
-			log.Trace(newError("failed to lookup IPs for domain ", domain).Base(err))
-			return
+			if !isNotFound(err) {
+				log.Trace(newError("failed to lookup IPs for domain ", domain).Base(err))
+				return
+			}
+			ips = nil
 		}
 
 		response <- &ARecord{
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"v2ray.com/core/common/buf"
+	v2net "v2ray.com/core/common/net"
+	"v2ray.com/core/testing/assert"
+	"v2ray.com/core/transport/ray"
+)
+
+// testDispatcher connects to the destination directly.
+type testDispatcher struct{}
+
+func (testDispatcher) Dispatch(ctx context.Context, dest v2net.Destination) (ray.InboundRay, error) {
+	conn, err := net.Dial("tcp", dest.NetAddr())
+	if err != nil {
+		return nil, err
+	}
+	stream := ray.NewRay(ctx)
+	go func() {
+		buf.Copy(stream.OutboundInput(), buf.NewWriter(conn))
+		conn.Close()
+	}()
+	go func() {
+		buf.Copy(buf.NewReader(conn), stream.OutboundOutput())
+		stream.OutboundOutput().Close()
+	}()
+	return stream, nil
+}
+
+func answerA(query *dns.Msg) *dns.Msg {
+	reply := new(dns.Msg)
+	reply.SetReply(query)
+	rr, _ := dns.NewRR(query.Question[0].Name + " 60 IN A 1.2.3.4")
+	reply.Answer = append(reply.Answer, rr)
+	return reply
+}
+
+func receiveA(t *testing.T, response <-chan *ARecord) *ARecord {
+	select {
+	case record := <-response:
+		return record
+	case <-time.After(time.Second * 5):
+		t.Fatal("timeout")
+		return nil
+	}
+}
+
+func TestTCPNameServer(t *testing.T) {
+	assert := assert.On(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Error(err).IsNil()
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		query, err := ReadTCPMessage(conn)
+		if err != nil {
+			return
+		}
+		WriteTCPMessage(conn, answerA(query))
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	server := NewTCPNameServer(v2net.TCPDestination(v2net.IPAddress(addr.IP), v2net.Port(addr.Port)), testDispatcher{})
+	record := receiveA(t, server.QueryA("v2ray.com"))
+	assert.Pointer(record).IsNotNil()
+	assert.Int(len(record.IPs)).Equals(1)
+	assert.String(record.IPs[0].String()).Equals("1.2.3.4")
+	assert.Bool(record.Expire.Before(time.Now().Add(time.Second * 61))).IsTrue()
+}
+
+func TestDoHNameServer(t *testing.T) {
+	assert := assert.On(t)
+
+	httpServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload []byte
+		var err error
+		switch r.Method {
+		case http.MethodGet:
+			payload, err = base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+		case http.MethodPost:
+			if r.Header.Get("Content-Type") != dnsMessageType {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+			payload, err = ioutil.ReadAll(r.Body)
+		}
+		query := new(dns.Msg)
+		if err != nil || query.Unpack(payload) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		reply, _ := answerA(query).Pack()
+		w.Header().Set("Content-Type", dnsMessageType)
+		w.Write(reply)
+	}))
+	defer httpServer.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(httpServer.Certificate())
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		u, err := url.Parse(httpServer.URL + "/dns-query")
+		assert.Error(err).IsNil()
+		server := NewDoHNameServer(u, method, testDispatcher{})
+		server.client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{RootCAs: roots}
+
+		record := receiveA(t, server.QueryA("v2ray.com"))
+		assert.Pointer(record).IsNotNil()
+		assert.Int(len(record.IPs)).Equals(1)
+		assert.String(record.IPs[0].String()).Equals("1.2.3.4")
+	}
+}
+
+func TestNewNameServer(t *testing.T) {
+	assert := assert.On(t)
+
+	cases := []struct {
+		url    string
+		server string
+	}{
+		{url: "localhost", server: "*server.LocalNameServer"},
+		{url: "udp://8.8.8.8", server: "*server.UDPNameServer"},
+		{url: "tcp://8.8.8.8:5353", server: "*server.TCPNameServer"},
+		{url: "https://1.1.1.1/dns-query", server: "*server.DoHNameServer"},
+		{url: "https+get://1.1.1.1/dns-query", server: "*server.DoHNameServer"},
+	}
+	for _, test := range cases {
+		ns, err := newNameServer(test.url, testDispatcher{})
+		assert.Error(err).IsNil()
+		assert.String(fmt.Sprintf("%T", ns)).Equals(test.server)
+	}
+
+	ns, _ := newNameServer("tcp://8.8.8.8:5353", testDispatcher{})
+	assert.String(ns.(*TCPNameServer).address.String()).Equals("tcp:8.8.8.8:5353")
+	ns, _ = newNameServer("https+get://1.1.1.1/dns-query", testDispatcher{})
+	assert.String(ns.(*DoHNameServer).url.String()).Equals("https://1.1.1.1/dns-query")
+	assert.String(ns.(*DoHNameServer).method).Equals(http.MethodGet)
+
+	for _, u := range []string{"tls://8.8.8.8", "udp://", "tcp://8.8.8.8:dns"} {
+		_, err := newNameServer(u, testDispatcher{})
+		assert.Error(err).IsNotNil()
+	}
+}
+
+func TestParseARecord(t *testing.T) {
+	assert := assert.On(t)
+
+	query := newQuery("v2ray.com", 1, dns.TypeAAAA)
+
+	failure := new(dns.Msg)
+	failure.SetRcode(query, dns.RcodeServerFailure)
+	assert.Pointer(parseARecord(failure)).IsNil()
+
+	refused := new(dns.Msg)
+	refused.SetRcode(query, dns.RcodeRefused)
+	assert.Pointer(parseARecord(refused)).IsNil()
+
+	noData := new(dns.Msg)
+	noData.SetReply(query)
+	record := parseARecord(noData)
+	assert.Int(len(record.IPs)).Equals(0)
+	assert.Bool(record.Expire.Before(time.Now().Add(FailureTTL + time.Second))).IsTrue()
+
+	noData.Ns = append(noData.Ns, &dns.SOA{
+		Hdr:    dns.RR_Header{Name: "v2ray.com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 600},
+		Minttl: 5,
+	})
+	record = parseARecord(noData)
+	assert.Int(len(record.IPs)).Equals(0)
+	assert.Bool(record.Expire.Before(time.Now().Add(time.Second * 6))).IsTrue()
+}
@@ -5,6 +5,9 @@ package server
 import (
 	"context"
 	"net"
+	"net/http"
+	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,16 +16,26 @@ import (
 	"v2ray.com/core/app/dispatcher"
 	"v2ray.com/core/app/dns"
 	"v2ray.com/core/app/log"
+	"v2ray.com/core/app/metrics"
 	"v2ray.com/core/common"
 	v2net "v2ray.com/core/common/net"
 )
 
 const (
 	QueryTimeout = time.Second * 8
+	// FailureTTL is how long an empty record is cached for an address family that no name server answers, or that a
+	// name server answers without addresses or SOA record.
+	FailureTTL = time.Second * 60
+)
+
+var (
+	cacheHits   = metrics.NewCounter("v2ray_dns_cache_hits_total", "Number of DNS queries answered from cache.")
+	cacheMisses = metrics.NewCounter("v2ray_dns_cache_misses_total", "Number of DNS queries not found in cache.")
 )
 
 type DomainRecord struct {
-	A *ARecord
+	A    *ARecord
+	AAAA *ARecord
 }
 
 type CacheServer struct {
@@ -30,6 +43,8 @@ type CacheServer struct {
 	hosts   map[string]net.IP
 	records map[string]*DomainRecord
 	servers []NameServer
+	scoped  []scopedNameServer
+	fake    *FakeIPPool
 }
 
 func NewCacheServer(ctx context.Context, config *dns.Config) (*CacheServer, error) {
@@ -39,29 +54,45 @@ func NewCacheServer(ctx context.Context, config *dns.Config) (*CacheServer, erro
 	}
 	server := &CacheServer{
 		records: make(map[string]*DomainRecord),
-		servers: make([]NameServer, len(config.NameServers)),
 		hosts:   config.GetInternalHosts(),
 	}
+	if config.FakeDns != nil {
+		fake, err := NewFakeIPPool(config.FakeDns)
+		if err != nil {
+			return nil, err
+		}
+		server.fake = fake
+	}
 	space.OnInitialize(func() error {
 		disp := dispatcher.FromSpace(space)
 		if disp == nil {
 			return newError("dispatcher is not found in the space")
 		}
-		for idx, destPB := range config.NameServers {
+		for _, destPB := range config.NameServers {
 			address := destPB.Address.AsAddress()
 			if address.Family().IsDomain() && address.Domain() == "localhost" {
-				server.servers[idx] = &LocalNameServer{}
-			} else {
-				dest := destPB.AsDestination()
-				if dest.Network == v2net.Network_Unknown {
-					dest.Network = v2net.Network_UDP
-				}
-				if dest.Network == v2net.Network_UDP {
-					server.servers[idx] = NewUDPNameServer(dest, disp)
-				}
+				server.servers = append(server.servers, &LocalNameServer{})
+				continue
+			}
+			dest := destPB.AsDestination()
+			switch dest.Network {
+			case v2net.Network_TCP:
+				server.servers = append(server.servers, NewTCPNameServer(dest, disp))
+			default:
+				dest.Network = v2net.Network_UDP
+				server.servers = append(server.servers, NewUDPNameServer(dest, disp))
 			}
 		}
-		if len(config.NameServers) == 0 {
+		for _, ns := range config.NameServer {
+			nameServer, err := newNameServer(ns.Url, disp)
+			if err != nil {
+				return err
+			}
+			if err := server.addNameServer(nameServer, ns); err != nil {
+				return err
+			}
+		}
+		if len(server.servers) == 0 {
 			server.servers = append(server.servers, &LocalNameServer{})
 		}
 		return nil
@@ -69,57 +100,288 @@ func NewCacheServer(ctx context.Context, config *dns.Config) (*CacheServer, erro
 	return server, nil
 }
 
+// addNameServer adds a name server, which only resolves the domains in its scope if the config has any.
+func (s *CacheServer) addNameServer(nameServer NameServer, config *dns.NameServer) error {
+	scope, err := newDomainScope(config)
+	if err != nil {
+		return err
+	}
+	if scope == nil {
+		s.servers = append(s.servers, nameServer)
+	} else {
+		s.scoped = append(s.scoped, scopedNameServer{
+			scope:  scope,
+			server: nameServer,
+		})
+	}
+	return nil
+}
+
+// serversFor returns the name servers to resolve the given domain. They are the servers whose scope matches the
+// domain if there are any, otherwise the servers without scope.
+func (s *CacheServer) serversFor(domain string) []NameServer {
+	domain = strings.TrimSuffix(domain, ".")
+	var servers []NameServer
+	for _, ns := range s.scoped {
+		if ns.scope.Match(domain) {
+			servers = append(servers, ns.server)
+		}
+	}
+	if len(servers) > 0 {
+		log.Trace(newError("resolving ", domain, " with ", len(servers), " scoped name servers").AtDebug())
+		return servers
+	}
+	return s.servers
+}
+
+// newNameServer creates a NameServer from its URL. The scheme of the URL selects the protocol.
+func newNameServer(rawURL string, disp dispatcher.Interface) (NameServer, error) {
+	if rawURL == "localhost" {
+		return &LocalNameServer{}, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, newError("invalid name server URL: ", rawURL).Base(err)
+	}
+	switch u.Scheme {
+	case "udp", "tcp":
+		if len(u.Hostname()) == 0 {
+			return nil, newError("missing host in name server URL: ", rawURL)
+		}
+		port := v2net.Port(53)
+		if len(u.Port()) > 0 {
+			port, err = v2net.PortFromString(u.Port())
+			if err != nil {
+				return nil, newError("invalid port in name server URL: ", rawURL).Base(err)
+			}
+		}
+		address := v2net.ParseAddress(u.Hostname())
+		if u.Scheme == "tcp" {
+			return NewTCPNameServer(v2net.TCPDestination(address, port), disp), nil
+		}
+		return NewUDPNameServer(v2net.UDPDestination(address, port), disp), nil
+	case "https":
+		return NewDoHNameServer(u, http.MethodPost, disp), nil
+	case "https+get":
+		u.Scheme = "https"
+		return NewDoHNameServer(u, http.MethodGet, disp), nil
+	default:
+		return nil, newError("unsupported name server URL: ", rawURL)
+	}
+}
+
 func (*CacheServer) Interface() interface{} {
 	return (*dns.Server)(nil)
 }
 
+// FakeDNS returns the fake IP pool of this server, or nil if fake DNS is not enabled.
+func (s *CacheServer) FakeDNS() dns.FakeDNS {
+	if s.fake == nil {
+		return nil
+	}
+	return s.fake
+}
+
 func (*CacheServer) Start() error {
 	return nil
 }
 
 func (*CacheServer) Close() {}
 
-func (s *CacheServer) GetCached(domain string) []net.IP {
+// Reload replaces the static hosts of this server with the ones in the given config.
+// Changes to name servers are not applied.
+func (s *CacheServer) Reload(config *dns.Config) error {
+	hosts := config.GetInternalHosts()
+
+	s.Lock()
+	s.hosts = hosts
+	s.Unlock()
+
+	return nil
+}
+
+func (s *CacheServer) getHost(domain string) (net.IP, bool) {
 	s.RLock()
 	defer s.RUnlock()
 
-	if record, found := s.records[domain]; found && record.A.Expire.After(time.Now()) {
-		return record.A.IPs
+	ip, found := s.hosts[domain]
+	return ip, found
+}
+
+// getCached returns the unexpired A and AAAA records of the domain in cache.
+func (s *CacheServer) getCached(domain string) (*ARecord, *ARecord) {
+	s.RLock()
+	defer s.RUnlock()
+
+	record, found := s.records[domain]
+	if !found {
+		return nil, nil
 	}
-	return nil
+	now := time.Now()
+	var a, aaaa *ARecord
+	if record.A != nil && record.A.Expire.After(now) {
+		a = record.A
+	}
+	if record.AAAA != nil && record.AAAA.Expire.After(now) {
+		aaaa = record.AAAA
+	}
+	return a, aaaa
 }
 
-func (s *CacheServer) Get(domain string) []net.IP {
-	if ip, found := s.hosts[domain]; found {
-		return []net.IP{ip}
+func (s *CacheServer) cache(domain string, a *ARecord, aaaa *ARecord) {
+	s.Lock()
+	defer s.Unlock()
+
+	record, found := s.records[domain]
+	if !found {
+		record = new(DomainRecord)
+		s.records[domain] = record
 	}
+	if a != nil {
+		record.A = a
+	}
+	if aaaa != nil {
+		record.AAAA = aaaa
+	}
+}
 
-	domain = dnsmsg.Fqdn(domain)
-	ips := s.GetCached(domain)
-	if ips != nil {
-		return ips
+// recordsComplete returns true if the given records are enough to select IPs by the preference. A nil record is
+// unknown, while a record without IPs means the domain has no address of that family.
+func recordsComplete(preference dns.IPPreference, a *ARecord, aaaa *ARecord) bool {
+	switch preference {
+	case dns.IPPreference_IPv4Only:
+		return a != nil
+	case dns.IPPreference_IPv6Only:
+		return aaaa != nil
+	case dns.IPPreference_PreferIPv6:
+		return aaaa != nil && (len(aaaa.IPs) > 0 || a != nil)
+	default:
+		return a != nil && (len(a.IPs) > 0 || aaaa != nil)
 	}
+}
 
-	for _, server := range s.servers {
-		response := server.QueryA(domain)
+// waitRecords waits for the responses of A and AAAA queries sent in parallel, until the records are complete for the
+// preference. a and aaaa are the records known before the queries, and a nil channel stands for a query not sent.
+// It returns the records received, which are nil if not received.
+func waitRecords(preference dns.IPPreference, a *ARecord, aaaa *ARecord, responseA <-chan *ARecord, responseAAAA <-chan *ARecord) (*ARecord, *ARecord) {
+	var newA, newAAAA *ARecord
+	timeout := time.After(QueryTimeout)
+	for responseA != nil || responseAAAA != nil {
 		select {
-		case a, open := <-response:
-			if !open || a == nil {
-				continue
+		case newA = <-responseA:
+			responseA = nil
+			if newA != nil {
+				a = newA
 			}
-			s.Lock()
-			s.records[domain] = &DomainRecord{
-				A: a,
+		case newAAAA = <-responseAAAA:
+			responseAAAA = nil
+			if newAAAA != nil {
+				aaaa = newAAAA
 			}
-			s.Unlock()
-			log.Trace(newError("returning ", len(a.IPs), " IPs for domain ", domain).AtDebug())
-			return a.IPs
-		case <-time.After(QueryTimeout):
+		case <-timeout:
+			return newA, newAAAA
+		}
+		if recordsComplete(preference, a, aaaa) {
+			break
 		}
 	}
 
-	log.Trace(newError("returning nil for domain ", domain).AtDebug())
-	return nil
+	// Take the other response as well if it has arrived, so that it is cached.
+	select {
+	case record := <-responseA:
+		newA = record
+	default:
+	}
+	select {
+	case record := <-responseAAAA:
+		newAAAA = record
+	default:
+	}
+	return newA, newAAAA
+}
+
+func ipsOf(record *ARecord) []net.IP {
+	if record == nil {
+		return nil
+	}
+	return record.IPs
+}
+
+func (s *CacheServer) Get(domain string) []net.IP {
+	return s.GetIP(domain, dns.IPPreference_PreferIPv4)
+}
+
+// GetIP implements dns.Server. A and AAAA queries are sent in parallel to each name server in turn, until the records
+// are complete for the preference. Only A queries are sent for IPv4Only preference, and only AAAA queries for IPv6Only.
+// For PreferIPv4 and PreferIPv6, the IPs of the preferred family are returned without waiting for the other one.
+// A static host is used if its address matches the preference, otherwise the domain is resolved by the name servers.
+func (s *CacheServer) GetIP(domain string, preference dns.IPPreference) []net.IP {
+	if ip, found := s.getHost(domain); found {
+		var ips []net.IP
+		if ip.To4() != nil {
+			ips = preference.Select([]net.IP{ip}, nil)
+		} else {
+			ips = preference.Select(nil, []net.IP{ip})
+		}
+		if len(ips) > 0 {
+			return ips
+		}
+		log.Trace(newError("static host of ", domain, " has no address for ", preference, ", resolving by name servers").AtDebug())
+	}
+
+	domain = dnsmsg.Fqdn(domain)
+	needA := preference != dns.IPPreference_IPv6Only
+	needAAAA := preference != dns.IPPreference_IPv4Only
+
+	a, aaaa := s.getCached(domain)
+	if recordsComplete(preference, a, aaaa) {
+		cacheHits.Inc()
+		return preference.Select(ipsOf(a), ipsOf(aaaa))
+	}
+	cacheMisses.Inc()
+
+	for _, server := range s.serversFor(domain) {
+		var responseA, responseAAAA <-chan *ARecord
+		if needA && a == nil {
+			responseA = server.QueryA(domain)
+		}
+		if needAAAA && aaaa == nil {
+			responseAAAA = server.QueryAAAA(domain)
+		}
+		newA, newAAAA := waitRecords(preference, a, aaaa, responseA, responseAAAA)
+		s.cache(domain, newA, newAAAA)
+		if newA != nil {
+			a = newA
+		}
+		if newAAAA != nil {
+			aaaa = newAAAA
+		}
+		if recordsComplete(preference, a, aaaa) {
+			ips := preference.Select(ipsOf(a), ipsOf(aaaa))
+			log.Trace(newError("returning ", len(ips), " IPs for domain ", domain).AtDebug())
+			return ips
+		}
+	}
+
+	if a == nil && aaaa == nil {
+		log.Trace(newError("returning nil for domain ", domain).AtDebug())
+		return nil
+	}
+
+	// One family is answered but the other is not, so the missing one is cached as empty for a while, in order not to
+	// query it on every lookup.
+	failure := &ARecord{
+		Expire: time.Now().Add(FailureTTL),
+	}
+	if needA && a == nil {
+		a = failure
+		s.cache(domain, a, nil)
+	}
+	if needAAAA && aaaa == nil {
+		aaaa = failure
+		s.cache(domain, nil, aaaa)
+	}
+	return preference.Select(ipsOf(a), ipsOf(aaaa))
 }
 
 func init() {
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"v2ray.com/core/app/dns"
+	"v2ray.com/core/testing/assert"
+)
+
+// staticNameServer answers queries from fixed records, and counts the queries.
+type staticNameServer struct {
+	sync.Mutex
+	a       map[string][]net.IP
+	aaaa    map[string][]net.IP
+	queries int
+}
+
+func (s *staticNameServer) answer(records map[string][]net.IP, domain string) <-chan *ARecord {
+	s.Lock()
+	s.queries++
+	s.Unlock()
+
+	response := make(chan *ARecord, 1)
+	response <- &ARecord{
+		IPs:    records[domain],
+		Expire: time.Now().Add(time.Minute),
+	}
+	close(response)
+	return response
+}
+
+func (s *staticNameServer) QueryA(domain string) <-chan *ARecord {
+	return s.answer(s.a, domain)
+}
+
+func (s *staticNameServer) QueryAAAA(domain string) <-chan *ARecord {
+	return s.answer(s.aaaa, domain)
+}
+
+func TestCacheServerIPPreference(t *testing.T) {
+	assert := assert.On(t)
+
+	nameServer := &staticNameServer{
+		a: map[string][]net.IP{
+			"v2ray.com.": {net.ParseIP("1.2.3.4")},
+		},
+		aaaa: map[string][]net.IP{
+			"v2ray.com.": {net.ParseIP("2001:db8::1")},
+			"ipv6.com.":  {net.ParseIP("2001:db8::2")},
+			"dual.com.":  {net.ParseIP("2001:db8::3")},
+		},
+	}
+	server := &CacheServer{
+		hosts: map[string]net.IP{
+			"static.com": net.ParseIP("10.0.0.1"),
+			"dual.com":   net.ParseIP("10.0.0.2"),
+		},
+		records: make(map[string]*DomainRecord),
+		servers: []NameServer{nameServer},
+	}
+
+	cases := []struct {
+		domain     string
+		preference dns.IPPreference
+		ips        []string
+		queries    int
+	}{
+		{domain: "v2ray.com", preference: dns.IPPreference_IPv6Only, ips: []string{"2001:db8::1"}, queries: 1},
+		{domain: "v2ray.com", preference: dns.IPPreference_PreferIPv4, ips: []string{"1.2.3.4"}, queries: 2},
+		{domain: "v2ray.com", preference: dns.IPPreference_PreferIPv6, ips: []string{"2001:db8::1"}, queries: 2},
+		{domain: "v2ray.com", preference: dns.IPPreference_IPv4Only, ips: []string{"1.2.3.4"}, queries: 2},
+		{domain: "ipv6.com", preference: dns.IPPreference_PreferIPv4, ips: []string{"2001:db8::2"}, queries: 4},
+		{domain: "ipv6.com", preference: dns.IPPreference_IPv4Only, ips: []string{}, queries: 4},
+		{domain: "static.com", preference: dns.IPPreference_PreferIPv6, ips: []string{"10.0.0.1"}, queries: 4},
+		{domain: "static.com", preference: dns.IPPreference_IPv6Only, ips: []string{}, queries: 5},
+		{domain: "dual.com", preference: dns.IPPreference_IPv4Only, ips: []string{"10.0.0.2"}, queries: 5},
+		{domain: "dual.com", preference: dns.IPPreference_IPv6Only, ips: []string{"2001:db8::3"}, queries: 6},
+	}
+	for _, test := range cases {
+		ips := server.GetIP(test.domain, test.preference)
+		assert.Int(len(ips)).Equals(len(test.ips))
+		for idx, ip := range ips {
+			assert.String(ip.String()).Equals(test.ips[idx])
+		}
+		assert.Int(nameServer.queries).Equals(test.queries)
+	}
+}
+
+// ipv4NameServer answers A queries from fixed records. Its AAAA queries fail, or never complete if hang is true.
+type ipv4NameServer struct {
+	*staticNameServer
+	hang bool
+}
+
+func (s *ipv4NameServer) QueryAAAA(domain string) <-chan *ARecord {
+	s.Lock()
+	s.queries++
+	s.Unlock()
+
+	response := make(chan *ARecord, 1)
+	if !s.hang {
+		close(response)
+	}
+	return response
+}
+
+func TestCacheServerFailedFamily(t *testing.T) {
+	assert := assert.On(t)
+
+	nameServer := &ipv4NameServer{
+		staticNameServer: &staticNameServer{
+			a: map[string][]net.IP{
+				"v2ray.com.": {net.ParseIP("1.2.3.4")},
+			},
+		},
+		hang: true,
+	}
+	server := &CacheServer{
+		records: make(map[string]*DomainRecord),
+		servers: []NameServer{nameServer},
+	}
+
+	// The IPv4 answer is returned without waiting for the AAAA query.
+	start := time.Now()
+	ips := server.GetIP("v2ray.com", dns.IPPreference_PreferIPv4)
+	assert.Bool(time.Since(start) < QueryTimeout/2).IsTrue()
+	assert.Int(len(ips)).Equals(1)
+	assert.String(ips[0].String()).Equals("1.2.3.4")
+	assert.Int(nameServer.queries).Equals(2)
+
+	ips = server.GetIP("v2ray.com", dns.IPPreference_PreferIPv4)
+	assert.Int(len(ips)).Equals(1)
+	assert.Int(nameServer.queries).Equals(2)
+
+	// The failed AAAA query is cached as empty.
+	nameServer.hang = false
+	ips = server.GetIP("v2ray.com", dns.IPPreference_PreferIPv6)
+	assert.Int(len(ips)).Equals(1)
+	assert.String(ips[0].String()).Equals("1.2.3.4")
+	assert.Int(nameServer.queries).Equals(3)
+
+	ips = server.GetIP("v2ray.com", dns.IPPreference_PreferIPv6)
+	assert.Int(len(ips)).Equals(1)
+	assert.Int(nameServer.queries).Equals(3)
+}
+
+func TestCacheServerScopedNameServers(t *testing.T) {
+	assert := assert.On(t)
+
+	corp := &staticNameServer{
+		a: map[string][]net.IP{
+			"git.corp.example.com.": {net.ParseIP("10.0.0.2")},
+			"corp.example.com.":     {net.ParseIP("10.0.0.1")},
+			"intranet.":             {net.ParseIP("10.0.0.3")},
+		},
+	}
+	public := &staticNameServer{
+		a: map[string][]net.IP{
+			"git.corp.example.com.": {net.ParseIP("1.1.1.2")},
+			"example.com.":          {net.ParseIP("1.1.1.1")},
+			"notcorp.example.com.":  {net.ParseIP("1.1.1.3")},
+		},
+	}
+	server := &CacheServer{
+		records: make(map[string]*DomainRecord),
+	}
+	assert.Error(server.addNameServer(public, &dns.NameServer{})).IsNil()
+	assert.Error(server.addNameServer(corp, &dns.NameServer{
+		Domain: []string{"Corp.Example.com"},
+		Regex:  []string{"^intranet$"},
+	})).IsNil()
+
+	cases := []struct {
+		domain string
+		ip     string
+	}{
+		{domain: "git.corp.example.com", ip: "10.0.0.2"},
+		{domain: "corp.example.com", ip: "10.0.0.1"},
+		{domain: "intranet", ip: "10.0.0.3"},
+		{domain: "example.com", ip: "1.1.1.1"},
+		{domain: "notcorp.example.com", ip: "1.1.1.3"},
+	}
+	for _, test := range cases {
+		ips := server.GetIP(test.domain, dns.IPPreference_IPv4Only)
+		assert.Int(len(ips)).Equals(1)
+		assert.String(ips[0].String()).Equals(test.ip)
+	}
+	assert.Int(corp.queries).Equals(3)
+	assert.Int(public.queries).Equals(2)
+
+	assert.Error(server.addNameServer(corp, &dns.NameServer{Regex: []string{"("}})).IsNotNil()
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+
+	"github.com/miekg/dns"
+	"v2ray.com/core/app/dispatcher"
+	"v2ray.com/core/app/log"
+	"v2ray.com/core/app/proxyman/outbound"
+	"v2ray.com/core/common/dice"
+	v2net "v2ray.com/core/common/net"
+)
+
+// TCPNameServer is a NameServer that queries over TCP, using the 2-byte length prefix framing in RFC 7766.
+// Each query is sent through the dispatcher on its own connection.
+type TCPNameServer struct {
+	address    v2net.Destination
+	dispatcher dispatcher.Interface
+}
+
+func NewTCPNameServer(address v2net.Destination, dispatcher dispatcher.Interface) *TCPNameServer {
+	address.Network = v2net.Network_TCP
+	return &TCPNameServer{
+		address:    address,
+		dispatcher: dispatcher,
+	}
+}
+
+func (s *TCPNameServer) QueryA(domain string) <-chan *ARecord {
+	return s.lookup(domain, dns.TypeA)
+}
+
+func (s *TCPNameServer) QueryAAAA(domain string) <-chan *ARecord {
+	return s.lookup(domain, dns.TypeAAAA)
+}
+
+func (s *TCPNameServer) lookup(domain string, qtype uint16) <-chan *ARecord {
+	response := make(chan *ARecord, 1)
+
+	go func() {
+		defer close(response)
+
+		msg, err := s.query(newQuery(domain, dice.RollUint16(), qtype))
+		if err != nil {
+			log.Trace(newError("failed to query ", domain, " from ", s.address).Base(err).AtWarning())
+			return
+		}
+		if record := parseARecord(msg); record != nil {
+			response <- record
+		}
+	}()
+
+	return response
+}
+
+func (s *TCPNameServer) query(msg *dns.Msg) (*dns.Msg, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), QueryTimeout)
+	defer cancel()
+
+	stream, err := s.dispatcher.Dispatch(ctx, s.address)
+	if err != nil {
+		return nil, newError("failed to dispatch query").Base(err)
+	}
+	conn := outbound.NewConnection(stream)
+	defer conn.Close()
+
+	if err := WriteTCPMessage(conn, msg); err != nil {
+		return nil, err
+	}
+	for {
+		reply, err := ReadTCPMessage(conn)
+		if err != nil {
+			return nil, err
+		}
+		if reply.Id == msg.Id {
+			return reply, nil
+		}
+	}
+}
+
+// WriteTCPMessage writes a DNS message with a 2-byte length prefix.
+func WriteTCPMessage(writer io.Writer, msg *dns.Msg) error {
+	payload, err := msg.Pack()
+	if err != nil {
+		return newError("failed to pack DNS message").Base(err)
+	}
+	b := make([]byte, 2+len(payload))
+	binary.BigEndian.PutUint16(b, uint16(len(payload)))
+	copy(b[2:], payload)
+	_, err = writer.Write(b)
+	return err
+}
+
+// ReadTCPMessage reads a DNS message with a 2-byte length prefix.
+func ReadTCPMessage(reader io.Reader) (*dns.Msg, error) {
+	var length [2]byte
+	if _, err := io.ReadFull(reader, length[:]); err != nil {
+		return nil, newError("failed to read message length").Base(err)
+	}
+	payload := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return nil, newError("failed to read message").Base(err)
+	}
+	msg := new(dns.Msg)
+	if err := msg.Unpack(payload); err != nil {
+		return nil, newError("failed to parse DNS message").Base(err)
+	}
+	return msg, nil
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import v2ray_core_common_net "v2ray.com/core/common/net"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Config struct {
+	// Port that the metrics server listens on. Must not be 0.
+	Port uint32 `protobuf:"varint,1,opt,name=port" json:"port,omitempty"`
+	// Address that the metrics server listens on. Default to 127.0.0.1 if unset.
+	Listen *v2ray_core_common_net.IPOrDomain `protobuf:"bytes,2,opt,name=listen" json:"listen,omitempty"`
+	// HTTP path of the metrics endpoint. Default to "/metrics" if unset.
+	Path string `protobuf:"bytes,3,opt,name=path" json:"path,omitempty"`
+}
+
+func (m *Config) Reset()                    { *m = Config{} }
+func (m *Config) String() string            { return proto.CompactTextString(m) }
+func (*Config) ProtoMessage()               {}
+func (*Config) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *Config) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Config) GetListen() *v2ray_core_common_net.IPOrDomain {
+	if m != nil {
+		return m.Listen
+	}
+	return nil
+}
+
+func (m *Config) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Config)(nil), "v2ray.core.app.metrics.Config")
+}
+
+func init() { proto.RegisterFile("v2ray.com/core/app/metrics/config.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 214 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x8e, 0x31, 0x4b, 0x04, 0x31,
+	0x10, 0x46, 0xc9, 0x29, 0x2b, 0x46, 0x6c, 0x52, 0x1c, 0xcb, 0x55, 0xab, 0x8d, 0x5b, 0x4d, 0x60,
+	0xad, 0xec, 0xd4, 0xb3, 0xb1, 0x10, 0x8f, 0x14, 0x16, 0x76, 0x31, 0x1b, 0x35, 0x68, 0x32, 0xc3,
+	0x24, 0x08, 0xfb, 0x97, 0xfc, 0x95, 0x62, 0x76, 0x05, 0x11, 0xbb, 0x07, 0x79, 0x79, 0xf3, 0xc9,
+	0xb3, 0x8f, 0x81, 0xed, 0x04, 0x0e, 0xa3, 0x76, 0xc8, 0x5e, 0x5b, 0x22, 0x1d, 0x7d, 0xe1, 0xe0,
+	0xb2, 0x76, 0x98, 0x9e, 0xc3, 0x0b, 0x10, 0x63, 0x41, 0xb5, 0xfe, 0x11, 0xd9, 0x83, 0x25, 0x82,
+	0x45, 0xda, 0xfc, 0x0d, 0x38, 0x8c, 0x11, 0x93, 0x4e, 0xbe, 0x68, 0x3b, 0x8e, 0xec, 0x73, 0x9e,
+	0x03, 0xa7, 0x6f, 0xb2, 0xd9, 0xd6, 0xa0, 0x52, 0x72, 0x9f, 0x90, 0x4b, 0x2b, 0x3a, 0xd1, 0x1f,
+	0x9b, 0xca, 0xea, 0x42, 0x36, 0xef, 0x21, 0x17, 0x9f, 0xda, 0x55, 0x27, 0xfa, 0xa3, 0xe1, 0x04,
+	0x7e, 0xdd, 0x9b, 0x9b, 0x90, 0x7c, 0x81, 0xdb, 0xdd, 0x3d, 0xdf, 0x60, 0xb4, 0x21, 0x99, 0xe5,
+	0x43, 0xcd, 0xd9, 0xf2, 0xda, 0xee, 0x75, 0xa2, 0x3f, 0x34, 0x95, 0xaf, 0x2f, 0xe5, 0xc6, 0x61,
+	0x84, 0xff, 0x37, 0xef, 0xc4, 0xe3, 0xc1, 0x82, 0x9f, 0xab, 0xf5, 0xc3, 0x60, 0xec, 0x04, 0xdb,
+	0x6f, 0xe7, 0x8a, 0x08, 0xee, 0xe6, 0x87, 0xa7, 0xa6, 0xae, 0x3e, 0xff, 0x1a, 0x00, 0xc8, 0x95,
+	0xf5, 0x03, 0x21, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,231 @@
+// Package metrics provides counters and gauges of the running V2Ray instance,
+// and a server that exposes them in Prometheus text format.
+package metrics
+
+//go:generate go run $GOPATH/src/v2ray.com/core/tools/generrorgen/main.go -pkg metrics -path App,Metrics
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"v2ray.com/core/common"
+)
+
+// Counter is a metric whose value only goes up.
+type Counter struct {
+	value int64
+}
+
+// Inc increases the counter by 1.
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add increases the counter by delta, which must not be negative.
+func (c *Counter) Add(delta int64) {
+	atomic.AddInt64(&c.value, delta)
+}
+
+// Value returns the current value of the counter.
+func (c *Counter) Value() int64 {
+	return atomic.LoadInt64(&c.value)
+}
+
+// Gauge is a metric whose value may go up and down.
+type Gauge struct {
+	value int64
+}
+
+// Inc increases the gauge by 1.
+func (g *Gauge) Inc() {
+	g.Add(1)
+}
+
+// Dec decreases the gauge by 1.
+func (g *Gauge) Dec() {
+	g.Add(-1)
+}
+
+// Add adds delta to the gauge.
+func (g *Gauge) Add(delta int64) {
+	atomic.AddInt64(&g.value, delta)
+}
+
+// Set sets the gauge to the given value.
+func (g *Gauge) Set(value int64) {
+	atomic.StoreInt64(&g.value, value)
+}
+
+// Value returns the current value of the gauge.
+func (g *Gauge) Value() int64 {
+	return atomic.LoadInt64(&g.value)
+}
+
+type valuer interface {
+	Value() int64
+}
+
+type series struct {
+	labelValues []string
+	metric      valuer
+}
+
+type family struct {
+	sync.RWMutex
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+	series     map[string]*series
+}
+
+func (f *family) get(labelValues []string, create func() valuer) valuer {
+	if len(labelValues) != len(f.labelNames) {
+		panic("metrics: wrong number of label values for " + f.name)
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	f.RLock()
+	s, found := f.series[key]
+	f.RUnlock()
+	if found {
+		return s.metric
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	if s, found := f.series[key]; found {
+		return s.metric
+	}
+	s = &series{
+		labelValues: append([]string(nil), labelValues...),
+		metric:      create(),
+	}
+	f.series[key] = s
+	return s.metric
+}
+
+var (
+	registryAccess sync.RWMutex
+	registry       = make(map[string]*family)
+)
+
+// register returns the family with the given name, creating it if it is not registered yet. Registering the same name
+// again returns the existing family, so that the metric is shared. It panics if the existing family has a different kind
+// or different label names.
+func register(name string, help string, kind string, labelNames []string) *family {
+	registryAccess.Lock()
+	defer registryAccess.Unlock()
+
+	if f, found := registry[name]; found {
+		if f.kind != kind || strings.Join(f.labelNames, "\xff") != strings.Join(labelNames, "\xff") {
+			common.Must(newError("metric ", name, " already registered with a different kind or label names"))
+		}
+		return f
+	}
+	f := &family{
+		name:       name,
+		help:       help,
+		kind:       kind,
+		labelNames: labelNames,
+		series:     make(map[string]*series),
+	}
+	registry[name] = f
+	return f
+}
+
+// CounterVec is a group of counters with the same name but different label values.
+type CounterVec struct {
+	family *family
+}
+
+// NewCounterVec registers a new group of counters with the given name and label names.
+func NewCounterVec(name string, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{
+		family: register(name, help, "counter", labelNames),
+	}
+}
+
+// With returns the counter with the given label values, which must match the label names in order.
+func (v *CounterVec) With(labelValues ...string) *Counter {
+	return v.family.get(labelValues, func() valuer { return new(Counter) }).(*Counter)
+}
+
+// NewCounter registers a new counter without labels.
+func NewCounter(name string, help string) *Counter {
+	return NewCounterVec(name, help).With()
+}
+
+// GaugeVec is a group of gauges with the same name but different label values.
+type GaugeVec struct {
+	family *family
+}
+
+// NewGaugeVec registers a new group of gauges with the given name and label names.
+func NewGaugeVec(name string, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{
+		family: register(name, help, "gauge", labelNames),
+	}
+}
+
+// With returns the gauge with the given label values, which must match the label names in order.
+func (v *GaugeVec) With(labelValues ...string) *Gauge {
+	return v.family.get(labelValues, func() valuer { return new(Gauge) }).(*Gauge)
+}
+
+// NewGauge registers a new gauge without labels.
+func NewGauge(name string, help string) *Gauge {
+	return NewGaugeVec(name, help).With()
+}
+
+var labelValueReplacer = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n")
+
+// WriteText writes all registered metrics into the given writer, in Prometheus text format.
+func WriteText(writer io.Writer) error {
+	registryAccess.RLock()
+	families := make([]*family, 0, len(registry))
+	for _, f := range registry {
+		families = append(families, f)
+	}
+	registryAccess.RUnlock()
+
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].name < families[j].name
+	})
+
+	w := bufio.NewWriter(writer)
+	for _, f := range families {
+		w.WriteString("# HELP " + f.name + " " + f.help + "\n")
+		w.WriteString("# TYPE " + f.name + " " + f.kind + "\n")
+
+		f.RLock()
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			s := f.series[key]
+			w.WriteString(f.name)
+			if len(f.labelNames) > 0 {
+				w.WriteByte('{')
+				for idx, name := range f.labelNames {
+					if idx > 0 {
+						w.WriteByte(',')
+					}
+					w.WriteString(name + "=\"" + labelValueReplacer.Replace(s.labelValues[idx]) + "\"")
+				}
+				w.WriteByte('}')
+			}
+			w.WriteString(" " + strconv.FormatInt(s.metric.Value(), 10) + "\n")
+		}
+		f.RUnlock()
+	}
+	return w.Flush()
+}
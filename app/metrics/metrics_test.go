@@ -0,0 +1,86 @@
+package metrics_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "v2ray.com/core/app/metrics"
+	"v2ray.com/core/common"
+	v2net "v2ray.com/core/common/net"
+	"v2ray.com/core/testing/assert"
+)
+
+var (
+	testRequests = NewCounterVec("test_requests_total", "Number of test requests.", "code", "path")
+	testSessions = NewGauge("test_sessions", "Number of test sessions.")
+	testServer   = NewCounter("test_server_total", "Number of test server requests.")
+)
+
+func TestWriteText(t *testing.T) {
+	assert := assert.On(t)
+
+	ok := testRequests.With("200", "/")
+	ok.Add(3)
+	notFound := testRequests.With("404", "/a\"b")
+	notFound.Inc()
+	testSessions.Set(1)
+
+	buffer := new(bytes.Buffer)
+	assert.Error(WriteText(buffer)).IsNil()
+	assert.Bool(strings.Contains(buffer.String(), `# HELP test_requests_total Number of test requests.
+# TYPE test_requests_total counter
+test_requests_total{code="200",path="/"} `+strconv.FormatInt(ok.Value(), 10)+`
+test_requests_total{code="404",path="/a\"b"} `+strconv.FormatInt(notFound.Value(), 10)+`
+`)).IsTrue()
+	assert.Bool(strings.Contains(buffer.String(), `# HELP test_sessions Number of test sessions.
+# TYPE test_sessions gauge
+test_sessions 1
+`)).IsTrue()
+}
+
+func TestRegisterTwice(t *testing.T) {
+	assert := assert.On(t)
+
+	counter := NewCounterVec("test_shared_total", "Number of shared test events.", "tag").With("a")
+	counter.Inc()
+	shared := NewCounterVec("test_shared_total", "Number of shared test events.", "tag").With("a")
+	shared.Inc()
+	assert.Int(int(counter.Value())).Equals(2)
+	assert.Pointer(shared).Equals(counter)
+}
+
+func TestServer(t *testing.T) {
+	assert := assert.On(t)
+
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	common.Must(err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	testServer.Inc()
+
+	_, err = NewServer(context.Background(), &Config{})
+	assert.Error(err).IsNotNil()
+
+	server, err := NewServer(context.Background(), &Config{
+		Port: uint32(port),
+	})
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	resp, err := http.Get("http://" + v2net.TCPDestination(v2net.LocalHostIP, v2net.Port(port)).NetAddr() + "/metrics")
+	assert.Error(err).IsNil()
+	defer resp.Body.Close()
+
+	assert.Int(resp.StatusCode).Equals(200)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.Error(err).IsNil()
+	assert.Bool(strings.Contains(string(body), "test_server_total "+strconv.FormatInt(testServer.Value(), 10)+"\n")).IsTrue()
+}
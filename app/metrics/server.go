@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/log"
+	"v2ray.com/core/common"
+	v2net "v2ray.com/core/common/net"
+)
+
+const (
+	readHeaderTimeout = time.Second * 10
+	readTimeout       = time.Second * 30
+	idleTimeout       = time.Second * 120
+)
+
+// Handler returns an http.Handler that serves all metrics in Prometheus text format.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := WriteText(w); err != nil {
+			log.Trace(newError("failed to write metrics").Base(err).AtWarning())
+		}
+	})
+}
+
+// Server is an application that serves metrics over HTTP.
+type Server struct {
+	config *Config
+	server *http.Server
+}
+
+// NewServer creates a new Server with the given config.
+func NewServer(ctx context.Context, config *Config) (*Server, error) {
+	if config.Port == 0 {
+		return nil, newError("port is not specified")
+	}
+	path := config.Path
+	if len(path) == 0 {
+		path = "/metrics"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, Handler())
+
+	return &Server{
+		config: config,
+		server: &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+			ReadTimeout:       readTimeout,
+			IdleTimeout:       idleTimeout,
+		},
+	}, nil
+}
+
+// Interface implements app.Application.
+func (*Server) Interface() interface{} {
+	return (*Server)(nil)
+}
+
+// Start implements app.Application.
+func (s *Server) Start() error {
+	address := v2net.LocalHostIP
+	if s.config.Listen != nil {
+		address = s.config.Listen.AsAddress()
+	}
+	listener, err := net.Listen("tcp", v2net.TCPDestination(address, v2net.Port(s.config.Port)).NetAddr())
+	if err != nil {
+		return newError("failed to listen on ", address, ":", s.config.Port).Base(err)
+	}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Trace(newError("metrics server stopped").Base(err).AtWarning())
+		}
+	}()
+
+	log.Trace(newError("metrics server listening on ", listener.Addr()).AtWarning())
+	return nil
+}
+
+// Close implements app.Application.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// FromSpace returns the metrics Server in the given space, or nil if it doesn't exist.
+func FromSpace(space app.Space) *Server {
+	app := space.GetApplication((*Server)(nil))
+	if app == nil {
+		return nil
+	}
+	return app.(*Server)
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return NewServer(ctx, config.(*Config))
+	}))
+}
@@ -24,15 +24,19 @@ type KnownProtocols int32
 const (
 	KnownProtocols_HTTP KnownProtocols = 0
 	KnownProtocols_TLS  KnownProtocols = 1
+	// BitTorrent carries no domain. It is only sniffed for routing.
+	KnownProtocols_BitTorrent KnownProtocols = 2
 )
 
 var KnownProtocols_name = map[int32]string{
 	0: "HTTP",
 	1: "TLS",
+	2: "BitTorrent",
 }
 var KnownProtocols_value = map[string]int32{
-	"HTTP": 0,
-	"TLS":  1,
+	"HTTP":       0,
+	"TLS":        1,
+	"BitTorrent": 2,
 }
 
 func (x KnownProtocols) String() string {
@@ -162,6 +166,8 @@ type ReceiverConfig struct {
 	StreamSettings             *v2ray_core_transport_internet.StreamConfig `protobuf:"bytes,4,opt,name=stream_settings,json=streamSettings" json:"stream_settings,omitempty"`
 	ReceiveOriginalDestination bool                                        `protobuf:"varint,5,opt,name=receive_original_destination,json=receiveOriginalDestination" json:"receive_original_destination,omitempty"`
 	DomainOverride             []KnownProtocols                            `protobuf:"varint,7,rep,packed,name=domain_override,json=domainOverride,enum=v2ray.core.app.proxyman.KnownProtocols" json:"domain_override,omitempty"`
+	// Attributes attached to all connections of this inbound, for routing rules to match on.
+	Attributes map[string]string `protobuf:"bytes,8,rep,name=attributes" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *ReceiverConfig) Reset()                    { *m = ReceiverConfig{} }
@@ -211,6 +217,13 @@ func (m *ReceiverConfig) GetDomainOverride() []KnownProtocols {
 	return nil
 }
 
+func (m *ReceiverConfig) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
 type InboundHandlerConfig struct {
 	Tag              string                                 `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
 	ReceiverSettings *v2ray_core_common_serial.TypedMessage `protobuf:"bytes,2,opt,name=receiver_settings,json=receiverSettings" json:"receiver_settings,omitempty"`
@@ -243,13 +256,77 @@ func (m *InboundHandlerConfig) GetProxySettings() *v2ray_core_common_serial.Type
 	return nil
 }
 
+// Health probing of outbound handlers. Each probe sends a HTTP GET request to the probe URL through an outbound.
+// Outbounds that fail consecutive probes are marked unhealthy, and skipped by routing, until a probe succeeds.
+type HealthCheckConfig struct {
+	// URL to probe. Default to "http://www.gstatic.com/generate_204".
+	ProbeUrl string `protobuf:"bytes,1,opt,name=probe_url,json=probeUrl" json:"probe_url,omitempty"`
+	// Seconds between probes. Default to 60.
+	Interval uint32 `protobuf:"varint,2,opt,name=interval" json:"interval,omitempty"`
+	// Seconds before a probe fails. Default to 10.
+	Timeout uint32 `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+	// Number of consecutive failed probes before an outbound is marked unhealthy. Default to 3.
+	FailureThreshold uint32 `protobuf:"varint,4,opt,name=failure_threshold,json=failureThreshold" json:"failure_threshold,omitempty"`
+	// Outbounds whose tag begins with any of the selectors are probed. All tagged outbounds are probed if empty.
+	TagSelector []string `protobuf:"bytes,5,rep,name=tag_selector,json=tagSelector" json:"tag_selector,omitempty"`
+}
+
+func (m *HealthCheckConfig) Reset()                    { *m = HealthCheckConfig{} }
+func (m *HealthCheckConfig) String() string            { return proto.CompactTextString(m) }
+func (*HealthCheckConfig) ProtoMessage()               {}
+func (*HealthCheckConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *HealthCheckConfig) GetProbeUrl() string {
+	if m != nil {
+		return m.ProbeUrl
+	}
+	return ""
+}
+
+func (m *HealthCheckConfig) GetInterval() uint32 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *HealthCheckConfig) GetTimeout() uint32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *HealthCheckConfig) GetFailureThreshold() uint32 {
+	if m != nil {
+		return m.FailureThreshold
+	}
+	return 0
+}
+
+func (m *HealthCheckConfig) GetTagSelector() []string {
+	if m != nil {
+		return m.TagSelector
+	}
+	return nil
+}
+
 type OutboundConfig struct {
+	// Health probing is disabled if not set.
+	HealthCheck *HealthCheckConfig `protobuf:"bytes,1,opt,name=health_check,json=healthCheck" json:"health_check,omitempty"`
 }
 
 func (m *OutboundConfig) Reset()                    { *m = OutboundConfig{} }
 func (m *OutboundConfig) String() string            { return proto.CompactTextString(m) }
 func (*OutboundConfig) ProtoMessage()               {}
-func (*OutboundConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*OutboundConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *OutboundConfig) GetHealthCheck() *HealthCheckConfig {
+	if m != nil {
+		return m.HealthCheck
+	}
+	return nil
+}
 
 type SenderConfig struct {
 	// Send traffic through the given IP. Only IP is allowed.
@@ -262,7 +339,7 @@ type SenderConfig struct {
 func (m *SenderConfig) Reset()                    { *m = SenderConfig{} }
 func (m *SenderConfig) String() string            { return proto.CompactTextString(m) }
 func (*SenderConfig) ProtoMessage()               {}
-func (*SenderConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*SenderConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *SenderConfig) GetVia() *v2ray_core_common_net.IPOrDomain {
 	if m != nil {
@@ -303,7 +380,7 @@ type OutboundHandlerConfig struct {
 func (m *OutboundHandlerConfig) Reset()                    { *m = OutboundHandlerConfig{} }
 func (m *OutboundHandlerConfig) String() string            { return proto.CompactTextString(m) }
 func (*OutboundHandlerConfig) ProtoMessage()               {}
-func (*OutboundHandlerConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*OutboundHandlerConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *OutboundHandlerConfig) GetTag() string {
 	if m != nil {
@@ -350,7 +427,7 @@ type MultiplexingConfig struct {
 func (m *MultiplexingConfig) Reset()                    { *m = MultiplexingConfig{} }
 func (m *MultiplexingConfig) String() string            { return proto.CompactTextString(m) }
 func (*MultiplexingConfig) ProtoMessage()               {}
-func (*MultiplexingConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*MultiplexingConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *MultiplexingConfig) GetEnabled() bool {
 	if m != nil {
@@ -373,6 +450,7 @@ func init() {
 	proto.RegisterType((*AllocationStrategy_AllocationStrategyRefresh)(nil), "v2ray.core.app.proxyman.AllocationStrategy.AllocationStrategyRefresh")
 	proto.RegisterType((*ReceiverConfig)(nil), "v2ray.core.app.proxyman.ReceiverConfig")
 	proto.RegisterType((*InboundHandlerConfig)(nil), "v2ray.core.app.proxyman.InboundHandlerConfig")
+	proto.RegisterType((*HealthCheckConfig)(nil), "v2ray.core.app.proxyman.HealthCheckConfig")
 	proto.RegisterType((*OutboundConfig)(nil), "v2ray.core.app.proxyman.OutboundConfig")
 	proto.RegisterType((*SenderConfig)(nil), "v2ray.core.app.proxyman.SenderConfig")
 	proto.RegisterType((*OutboundHandlerConfig)(nil), "v2ray.core.app.proxyman.OutboundHandlerConfig")
@@ -384,57 +462,69 @@ func init() {
 func init() { proto.RegisterFile("v2ray.com/core/app/proxyman/config.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 822 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xd1, 0x8e, 0xdb, 0x44,
-	0x14, 0xad, 0xe3, 0x34, 0xc9, 0xde, 0xed, 0x7a, 0xdd, 0xa1, 0xd0, 0x10, 0x40, 0x0a, 0x01, 0xd1,
-	0xa8, 0x20, 0xa7, 0xa4, 0xe2, 0x81, 0x27, 0x58, 0x76, 0x2b, 0x75, 0x81, 0x55, 0xcc, 0x24, 0xe2,
-	0xa1, 0x42, 0xb2, 0x66, 0xed, 0xa9, 0x19, 0x61, 0xcf, 0x58, 0x33, 0x93, 0x74, 0xfd, 0x4b, 0x7c,
-	0x05, 0x8f, 0x3c, 0xf0, 0x05, 0xfc, 0x0a, 0x2f, 0xc8, 0x9e, 0x71, 0x76, 0xb7, 0x49, 0x5a, 0x96,
-	0xaa, 0x6f, 0x33, 0xc9, 0x39, 0xc7, 0x73, 0xcf, 0x3d, 0x77, 0x06, 0xc6, 0xab, 0xa9, 0x24, 0x65,
-	0x10, 0x8b, 0x7c, 0x12, 0x0b, 0x49, 0x27, 0xa4, 0x28, 0x26, 0x85, 0x14, 0x17, 0x65, 0x4e, 0xf8,
-	0x24, 0x16, 0xfc, 0x39, 0x4b, 0x83, 0x42, 0x0a, 0x2d, 0xd0, 0xfd, 0x06, 0x29, 0x69, 0x40, 0x8a,
-	0x22, 0x68, 0x50, 0x83, 0x47, 0x2f, 0x49, 0xc4, 0x22, 0xcf, 0x05, 0x9f, 0x28, 0x2a, 0x19, 0xc9,
-	0x26, 0xba, 0x2c, 0x68, 0x12, 0xe5, 0x54, 0x29, 0x92, 0x52, 0x23, 0x35, 0x78, 0xb0, 0x9d, 0xc1,
-	0xa9, 0x9e, 0x90, 0x24, 0x91, 0x54, 0x29, 0x0b, 0xfc, 0x74, 0x37, 0xb0, 0x10, 0x52, 0x5b, 0x54,
-	0xf0, 0x12, 0x4a, 0x4b, 0xc2, 0x55, 0xf5, 0xff, 0x84, 0x71, 0x4d, 0x65, 0x85, 0xbe, 0x5a, 0xc9,
-	0xe8, 0x10, 0x0e, 0x4e, 0xf9, 0xb9, 0x58, 0xf2, 0xe4, 0xb8, 0xfe, 0x79, 0xf4, 0x87, 0x0b, 0xe8,
-	0x28, 0xcb, 0x44, 0x4c, 0x34, 0x13, 0x7c, 0xae, 0x25, 0xd1, 0x34, 0x2d, 0xd1, 0x09, 0xb4, 0xab,
-	0xd3, 0xf7, 0x9d, 0xa1, 0x33, 0xf6, 0xa6, 0x8f, 0x82, 0x1d, 0x06, 0x04, 0x9b, 0xd4, 0x60, 0x51,
-	0x16, 0x14, 0xd7, 0x6c, 0xf4, 0x1b, 0xec, 0xc7, 0x82, 0xc7, 0x4b, 0x29, 0x29, 0x8f, 0xcb, 0x7e,
-	0x6b, 0xe8, 0x8c, 0xf7, 0xa7, 0xa7, 0x37, 0x11, 0xdb, 0xfc, 0xe9, 0xf8, 0x52, 0x10, 0x5f, 0x55,
-	0x47, 0x11, 0x74, 0x25, 0x7d, 0x2e, 0xa9, 0xfa, 0xb5, 0xef, 0xd6, 0x1f, 0x7a, 0xf2, 0x66, 0x1f,
-	0xc2, 0x46, 0x0c, 0x37, 0xaa, 0x83, 0xaf, 0xe0, 0xa3, 0x57, 0x1e, 0x07, 0xdd, 0x83, 0xdb, 0x2b,
-	0x92, 0x2d, 0x8d, 0x6b, 0x07, 0xd8, 0x6c, 0x06, 0x5f, 0xc2, 0xfb, 0x3b, 0xc5, 0xb7, 0x53, 0x46,
-	0x5f, 0x40, 0xbb, 0x72, 0x11, 0x01, 0x74, 0x8e, 0xb2, 0x17, 0xa4, 0x54, 0xfe, 0xad, 0x6a, 0x8d,
-	0x09, 0x4f, 0x44, 0xee, 0x3b, 0xe8, 0x0e, 0xf4, 0x9e, 0x5c, 0x54, 0xed, 0x25, 0x99, 0xdf, 0x1a,
-	0xfd, 0xed, 0x82, 0x87, 0x69, 0x4c, 0xd9, 0x8a, 0x4a, 0xd3, 0x55, 0xf4, 0x0d, 0x40, 0x15, 0x82,
-	0x48, 0x12, 0x9e, 0x1a, 0xed, 0xfd, 0xe9, 0xf0, 0xaa, 0x1d, 0x26, 0x4d, 0x01, 0xa7, 0x3a, 0x08,
-	0x85, 0xd4, 0xb8, 0xc2, 0xe1, 0xbd, 0xa2, 0x59, 0xa2, 0xaf, 0xa1, 0x93, 0x31, 0xa5, 0x29, 0xb7,
-	0x4d, 0xfb, 0x78, 0x07, 0xf9, 0x34, 0x9c, 0xc9, 0x13, 0x91, 0x13, 0xc6, 0xb1, 0x25, 0xa0, 0x5f,
-	0xe0, 0x1d, 0xb2, 0xae, 0x37, 0x52, 0xb6, 0x60, 0xdb, 0x93, 0xcf, 0x6f, 0xd0, 0x13, 0x8c, 0xc8,
-	0x66, 0x30, 0x17, 0x70, 0xa8, 0xb4, 0xa4, 0x24, 0x8f, 0x14, 0xd5, 0x9a, 0xf1, 0x54, 0xf5, 0xdb,
-	0x9b, 0xca, 0xeb, 0x31, 0x08, 0x9a, 0x31, 0x08, 0xe6, 0x35, 0xcb, 0xf8, 0x83, 0x3d, 0xa3, 0x31,
-	0xb7, 0x12, 0xe8, 0x5b, 0xf8, 0x50, 0x1a, 0x07, 0x23, 0x21, 0x59, 0xca, 0x38, 0xc9, 0xa2, 0x84,
-	0x2a, 0xcd, 0x78, 0xfd, 0xf5, 0xfe, 0xed, 0xa1, 0x33, 0xee, 0xe1, 0x81, 0xc5, 0xcc, 0x2c, 0xe4,
-	0xe4, 0x12, 0x81, 0x42, 0x38, 0x4c, 0x6a, 0x1f, 0x22, 0xb1, 0xa2, 0x52, 0xb2, 0x84, 0xf6, 0xbb,
-	0x43, 0x77, 0xec, 0x4d, 0x1f, 0xec, 0xac, 0xf8, 0x07, 0x2e, 0x5e, 0xf0, 0xb0, 0x1a, 0xcb, 0x58,
-	0x64, 0x0a, 0x7b, 0x86, 0x3f, 0xb3, 0xf4, 0xef, 0xdb, 0xbd, 0x8e, 0xdf, 0x1d, 0xfd, 0xe5, 0xc0,
-	0x3d, 0x3b, 0xb1, 0x4f, 0x09, 0x4f, 0xb2, 0x75, 0x8b, 0x7d, 0x70, 0x35, 0x49, 0xeb, 0xde, 0xee,
-	0xe1, 0x6a, 0x89, 0xe6, 0x70, 0xd7, 0x1e, 0x50, 0x5e, 0x9a, 0x63, 0xda, 0xf7, 0xd9, 0x96, 0xf6,
-	0x99, 0x4b, 0xaa, 0x1e, 0xd7, 0xe4, 0xcc, 0xdc, 0x51, 0xd8, 0x6f, 0x04, 0xd6, 0xce, 0x9c, 0x81,
-	0x57, 0x1f, 0xf8, 0x52, 0xd1, 0xbd, 0x91, 0xe2, 0x41, 0xcd, 0x6e, 0xe4, 0x46, 0x3e, 0x78, 0xb3,
-	0xa5, 0xbe, 0x7a, 0x01, 0xfd, 0xd9, 0x82, 0x3b, 0x73, 0xca, 0x93, 0x75, 0x61, 0x8f, 0xc1, 0x5d,
-	0x31, 0x62, 0x43, 0xfb, 0x1f, 0x72, 0x57, 0xa1, 0xb7, 0xc5, 0xa2, 0xf5, 0xe6, 0xb1, 0xf8, 0x69,
-	0x47, 0xf1, 0x0f, 0x5f, 0x23, 0x1a, 0x56, 0x24, 0xab, 0x79, 0xdd, 0x00, 0xf4, 0x0c, 0x50, 0xbe,
-	0xcc, 0x34, 0x2b, 0x32, 0x7a, 0xf1, 0xca, 0x08, 0x5f, 0x8b, 0xca, 0x59, 0x43, 0x61, 0x3c, 0xb5,
-	0xba, 0x77, 0xd7, 0x32, 0x6b, 0x73, 0xff, 0x71, 0xe0, 0xdd, 0xc6, 0xdd, 0xd7, 0x85, 0x65, 0x06,
-	0x87, 0xaa, 0x76, 0xfd, 0xff, 0x46, 0xc5, 0x33, 0xf4, 0xb7, 0x14, 0x14, 0xf4, 0x1e, 0x74, 0xe8,
-	0x45, 0xc1, 0x24, 0xad, 0xbd, 0x71, 0xb1, 0xdd, 0xa1, 0x3e, 0x74, 0x2b, 0x11, 0xca, 0x75, 0x3d,
-	0x94, 0x7b, 0xb8, 0xd9, 0x8e, 0x42, 0x40, 0x9b, 0x36, 0x55, 0x78, 0xca, 0xc9, 0x79, 0x46, 0x93,
-	0xba, 0xfa, 0x1e, 0x6e, 0xb6, 0x68, 0xb8, 0xf9, 0x38, 0x1d, 0x5c, 0x7b, 0x51, 0x1e, 0x7e, 0x02,
-	0xde, 0xf5, 0x19, 0x45, 0x3d, 0x68, 0x3f, 0x5d, 0x2c, 0x42, 0xff, 0x16, 0xea, 0x82, 0xbb, 0xf8,
-	0x71, 0xee, 0x3b, 0xdf, 0x1d, 0xc3, 0x07, 0xb1, 0xc8, 0x77, 0x75, 0x2e, 0x74, 0x9e, 0xf5, 0x9a,
-	0xf5, 0xef, 0xad, 0xfb, 0x3f, 0x4f, 0x31, 0x29, 0x83, 0xe3, 0x0a, 0x75, 0x54, 0x14, 0x26, 0x27,
-	0x39, 0xe1, 0xe7, 0x9d, 0xfa, 0x75, 0x7e, 0xfc, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x25, 0xdf,
-	0x6a, 0xb2, 0x93, 0x08, 0x00, 0x00,
+	// 1019 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x5f, 0x6f, 0xe3, 0xc4,
+	0x17, 0x5d, 0xc7, 0x69, 0x9b, 0xde, 0x34, 0xa9, 0x3b, 0xbf, 0xfd, 0xb1, 0x21, 0x0b, 0x52, 0x36,
+	0x42, 0x6c, 0xd4, 0x45, 0xce, 0x92, 0x0a, 0xf1, 0x47, 0x42, 0xd0, 0x6d, 0x2b, 0xb5, 0x40, 0xd5,
+	0x30, 0x09, 0x20, 0xad, 0x90, 0xac, 0xa9, 0x7d, 0xeb, 0x58, 0xb5, 0x67, 0xac, 0xf1, 0x24, 0x5b,
+	0x7f, 0x25, 0x3e, 0x00, 0xcf, 0x3c, 0xf2, 0xc0, 0xa7, 0xe1, 0x95, 0x17, 0xe4, 0xb1, 0x9d, 0xb4,
+	0x4d, 0xb3, 0x4b, 0x59, 0xf1, 0x36, 0x73, 0x7d, 0xce, 0xb1, 0xef, 0xbd, 0xe7, 0xde, 0x04, 0x7a,
+	0xb3, 0x81, 0x64, 0xa9, 0xed, 0x8a, 0xa8, 0xef, 0x0a, 0x89, 0x7d, 0x16, 0xc7, 0xfd, 0x58, 0x8a,
+	0xab, 0x34, 0x62, 0xbc, 0xef, 0x0a, 0x7e, 0x11, 0xf8, 0x76, 0x2c, 0x85, 0x12, 0xe4, 0x51, 0x89,
+	0x94, 0x68, 0xb3, 0x38, 0xb6, 0x4b, 0x54, 0xfb, 0xf9, 0x2d, 0x09, 0x57, 0x44, 0x91, 0xe0, 0xfd,
+	0x04, 0x65, 0xc0, 0xc2, 0xbe, 0x4a, 0x63, 0xf4, 0x9c, 0x08, 0x93, 0x84, 0xf9, 0x98, 0x4b, 0xb5,
+	0x9f, 0xde, 0xcd, 0xe0, 0xa8, 0xfa, 0xcc, 0xf3, 0x24, 0x26, 0x49, 0x01, 0xfc, 0x60, 0x35, 0x30,
+	0x16, 0x52, 0x15, 0x28, 0xfb, 0x16, 0x4a, 0x49, 0xc6, 0x93, 0xec, 0x79, 0x3f, 0xe0, 0x0a, 0x65,
+	0x86, 0xbe, 0x9e, 0x49, 0x77, 0x1b, 0x1a, 0x27, 0xfc, 0x5c, 0x4c, 0xb9, 0x77, 0xa0, 0xc3, 0xdd,
+	0xdf, 0x4c, 0x20, 0xfb, 0x61, 0x28, 0x5c, 0xa6, 0x02, 0xc1, 0x47, 0x4a, 0x32, 0x85, 0x7e, 0x4a,
+	0x0e, 0xa1, 0x9a, 0x7d, 0x7d, 0xcb, 0xe8, 0x18, 0xbd, 0xe6, 0xe0, 0xb9, 0xbd, 0xa2, 0x00, 0xf6,
+	0x32, 0xd5, 0x1e, 0xa7, 0x31, 0x52, 0xcd, 0x26, 0x97, 0x50, 0x77, 0x05, 0x77, 0xa7, 0x52, 0x22,
+	0x77, 0xd3, 0x56, 0xa5, 0x63, 0xf4, 0xea, 0x83, 0x93, 0xfb, 0x88, 0x2d, 0x87, 0x0e, 0x16, 0x82,
+	0xf4, 0xba, 0x3a, 0x71, 0x60, 0x43, 0xe2, 0x85, 0xc4, 0x64, 0xd2, 0x32, 0xf5, 0x8b, 0x8e, 0xde,
+	0xee, 0x45, 0x34, 0x17, 0xa3, 0xa5, 0x6a, 0xfb, 0x13, 0x78, 0xff, 0xb5, 0x9f, 0x43, 0x1e, 0xc2,
+	0xda, 0x8c, 0x85, 0xd3, 0xbc, 0x6a, 0x0d, 0x9a, 0x5f, 0xda, 0x1f, 0xc3, 0xbb, 0x2b, 0xc5, 0xef,
+	0xa6, 0x74, 0x3f, 0x82, 0x6a, 0x56, 0x45, 0x02, 0xb0, 0xbe, 0x1f, 0xbe, 0x62, 0x69, 0x62, 0x3d,
+	0xc8, 0xce, 0x94, 0x71, 0x4f, 0x44, 0x96, 0x41, 0xb6, 0xa0, 0x76, 0x74, 0x95, 0xb5, 0x97, 0x85,
+	0x56, 0xa5, 0xfb, 0x67, 0x15, 0x9a, 0x14, 0x5d, 0x0c, 0x66, 0x28, 0xf3, 0xae, 0x92, 0xaf, 0x00,
+	0x32, 0x13, 0x38, 0x92, 0x71, 0x3f, 0xd7, 0xae, 0x0f, 0x3a, 0xd7, 0xcb, 0x91, 0xbb, 0xc9, 0xe6,
+	0xa8, 0xec, 0xa1, 0x90, 0x8a, 0x66, 0x38, 0xba, 0x19, 0x97, 0x47, 0xf2, 0x39, 0xac, 0x87, 0x41,
+	0xa2, 0x90, 0x17, 0x4d, 0x7b, 0xb2, 0x82, 0x7c, 0x32, 0x3c, 0x93, 0x87, 0x22, 0x62, 0x01, 0xa7,
+	0x05, 0x81, 0xfc, 0x0c, 0xff, 0x63, 0xf3, 0x7c, 0x9d, 0xa4, 0x48, 0xb8, 0xe8, 0xc9, 0xb3, 0x7b,
+	0xf4, 0x84, 0x12, 0xb6, 0x6c, 0xcc, 0x31, 0x6c, 0x27, 0x4a, 0x22, 0x8b, 0x9c, 0x04, 0x95, 0x0a,
+	0xb8, 0x9f, 0xb4, 0xaa, 0xcb, 0xca, 0xf3, 0x31, 0xb0, 0xcb, 0x31, 0xb0, 0x47, 0x9a, 0x95, 0xd7,
+	0x87, 0x36, 0x73, 0x8d, 0x51, 0x21, 0x41, 0xbe, 0x86, 0xf7, 0x64, 0x5e, 0x41, 0x47, 0xc8, 0xc0,
+	0x0f, 0x38, 0x0b, 0x1d, 0x0f, 0x13, 0x15, 0x70, 0xfd, 0xf6, 0xd6, 0x5a, 0xc7, 0xe8, 0xd5, 0x68,
+	0xbb, 0xc0, 0x9c, 0x15, 0x90, 0xc3, 0x05, 0x82, 0x0c, 0x61, 0xdb, 0xd3, 0x75, 0x70, 0xc4, 0x0c,
+	0xa5, 0x0c, 0x3c, 0x6c, 0x6d, 0x74, 0xcc, 0x5e, 0x73, 0xf0, 0x74, 0x65, 0xc6, 0xdf, 0x72, 0xf1,
+	0x8a, 0x0f, 0xb3, 0xb1, 0x74, 0x45, 0x98, 0xd0, 0x66, 0xce, 0x3f, 0x2b, 0xe8, 0xe4, 0x27, 0x00,
+	0xa6, 0x94, 0x0c, 0xce, 0xa7, 0x0a, 0x93, 0x56, 0xad, 0x63, 0xf6, 0xea, 0x83, 0x4f, 0x57, 0x8a,
+	0xdd, 0x34, 0x80, 0xbd, 0x3f, 0x67, 0x1e, 0x71, 0x25, 0x53, 0x7a, 0x4d, 0xaa, 0xfd, 0x25, 0x6c,
+	0xdf, 0x7a, 0x4c, 0x2c, 0x30, 0x2f, 0x31, 0xd5, 0x46, 0xd9, 0xa4, 0xd9, 0x71, 0x61, 0xcc, 0x8a,
+	0x8e, 0xe5, 0x97, 0x2f, 0x2a, 0x9f, 0x19, 0xdf, 0x54, 0x6b, 0xeb, 0xd6, 0x46, 0xf7, 0x0f, 0x03,
+	0x1e, 0x16, 0x9b, 0xe4, 0x98, 0x71, 0x2f, 0x9c, 0x5b, 0xcf, 0x02, 0x53, 0x31, 0xbf, 0x94, 0x52,
+	0xcc, 0x27, 0x23, 0xd8, 0x29, 0x0a, 0x27, 0x17, 0x4d, 0xcb, 0x6d, 0xf5, 0xe1, 0x1d, 0xb6, 0xca,
+	0x97, 0xa7, 0x5e, 0x23, 0xde, 0x69, 0xbe, 0x3b, 0xa9, 0x55, 0x0a, 0xcc, 0x3b, 0x76, 0x0a, 0x4d,
+	0x9d, 0xfb, 0x42, 0xd1, 0xbc, 0x97, 0x62, 0x43, 0xb3, 0x4b, 0xb9, 0xee, 0xaf, 0x06, 0xec, 0x1c,
+	0x23, 0x0b, 0xd5, 0xe4, 0x60, 0x82, 0xee, 0x65, 0x91, 0xcb, 0x63, 0xd8, 0x8c, 0xa5, 0x38, 0x47,
+	0x67, 0x2a, 0xc3, 0x22, 0xa3, 0x9a, 0x0e, 0xfc, 0x20, 0x43, 0xd2, 0x86, 0x9a, 0x36, 0xd7, 0x8c,
+	0x85, 0x3a, 0x9b, 0x06, 0x9d, 0xdf, 0x49, 0x0b, 0x36, 0x54, 0x10, 0xa1, 0x98, 0x2a, 0xfd, 0x59,
+	0x0d, 0x5a, 0x5e, 0xc9, 0x33, 0xd8, 0xb9, 0x60, 0x41, 0x38, 0x95, 0xe8, 0xa8, 0x49, 0xb6, 0x02,
+	0x44, 0xe8, 0x69, 0x07, 0x37, 0xa8, 0x55, 0x3c, 0x18, 0x97, 0x71, 0xf2, 0x04, 0xb6, 0x14, 0xf3,
+	0x9d, 0x04, 0x43, 0x74, 0x95, 0x90, 0xad, 0xb5, 0x8e, 0xd9, 0xdb, 0xa4, 0x75, 0xc5, 0xfc, 0x51,
+	0x11, 0xea, 0x3a, 0xd0, 0x3c, 0x9b, 0xaa, 0x6b, 0x1b, 0x9d, 0x9c, 0xc2, 0xd6, 0x44, 0x67, 0xe2,
+	0xb8, 0x59, 0x2a, 0xc5, 0xf4, 0xef, 0xae, 0x74, 0xce, 0x52, 0xda, 0xb4, 0x3e, 0x59, 0x84, 0xba,
+	0xbf, 0x57, 0x60, 0x6b, 0x84, 0xdc, 0x9b, 0x37, 0x78, 0x0f, 0xcc, 0x59, 0xc0, 0x5a, 0xc6, 0x3f,
+	0xdd, 0x0b, 0x19, 0xfa, 0xae, 0xb1, 0xad, 0xbc, 0xfd, 0xd8, 0x7e, 0xbf, 0xc2, 0x04, 0xbb, 0x6f,
+	0x10, 0x1d, 0x66, 0xa4, 0x42, 0xf3, 0xa6, 0x11, 0xc8, 0x4b, 0x20, 0xd1, 0x34, 0x54, 0x41, 0x1c,
+	0xe2, 0xd5, 0x6b, 0x57, 0xcc, 0x8d, 0x1a, 0x9e, 0x96, 0x94, 0x80, 0xfb, 0x85, 0xee, 0xce, 0x5c,
+	0x66, 0x6e, 0xb2, 0xbf, 0x0c, 0xf8, 0x7f, 0xd9, 0xac, 0x37, 0x0d, 0xcd, 0x19, 0x6c, 0x27, 0xba,
+	0xea, 0xff, 0x76, 0x64, 0x9a, 0x39, 0xfd, 0x3f, 0x1a, 0x18, 0xf2, 0x0e, 0xac, 0xe3, 0x55, 0x1c,
+	0x48, 0xd4, 0xb5, 0x31, 0x69, 0x71, 0xcb, 0x9c, 0x9f, 0x89, 0x20, 0x57, 0x7a, 0x69, 0x6e, 0xd2,
+	0xf2, 0xda, 0x1d, 0x02, 0x59, 0x2e, 0x53, 0x86, 0x47, 0xce, 0xce, 0x43, 0xf4, 0x74, 0xf6, 0x35,
+	0x5a, 0x5e, 0x49, 0x67, 0xf9, 0xcf, 0x43, 0xe3, 0xc6, 0x2f, 0xfe, 0xee, 0x1e, 0x34, 0x6f, 0xee,
+	0x50, 0x52, 0x83, 0xea, 0xf1, 0x78, 0x3c, 0xb4, 0x1e, 0x90, 0x0d, 0x30, 0xc7, 0xdf, 0x8d, 0x2c,
+	0x83, 0x34, 0x01, 0x5e, 0x04, 0x6a, 0x2c, 0x32, 0x8e, 0xb2, 0x2a, 0x2f, 0x0e, 0xe0, 0xb1, 0x2b,
+	0xa2, 0x55, 0x9d, 0x1c, 0x1a, 0x2f, 0x6b, 0xe5, 0xf9, 0x97, 0xca, 0xa3, 0x1f, 0x07, 0x94, 0xa5,
+	0xf6, 0x41, 0x86, 0xda, 0x8f, 0xe3, 0xdc, 0x37, 0x11, 0xe3, 0xe7, 0xeb, 0xfa, 0xdf, 0xd4, 0xde,
+	0xdf, 0x03, 0x00, 0x0a, 0x5b, 0x91, 0xdf, 0x43, 0x0a, 0x00, 0x00,
 }
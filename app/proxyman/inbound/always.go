@@ -46,6 +46,7 @@ func NewAlwaysOnInboundHandler(ctx context.Context, tag string, receiverConfig *
 				tag:          tag,
 				dispatcher:   h.mux,
 				sniffers:     receiverConfig.DomainOverride,
+				attributes:   receiverConfig.Attributes,
 			}
 			h.workers = append(h.workers, worker)
 		}
@@ -58,6 +59,7 @@ func NewAlwaysOnInboundHandler(ctx context.Context, tag string, receiverConfig *
 				port:         net.Port(port),
 				recvOrigDest: receiverConfig.ReceiveOriginalDestination,
 				dispatcher:   h.mux,
+				attributes:   receiverConfig.Attributes,
 			}
 			h.workers = append(h.workers, worker)
 		}
@@ -79,6 +81,12 @@ func (h *AlwaysOnInboundHandler) Close() {
 	for _, worker := range h.workers {
 		worker.Close()
 	}
+	h.mux.Close()
+}
+
+// GetInbound returns the proxy that is shared by all workers of this handler.
+func (h *AlwaysOnInboundHandler) GetInbound() proxy.Inbound {
+	return h.proxy
 }
 
 func (h *AlwaysOnInboundHandler) GetRandomInboundProxy() (proxy.Inbound, net.Port, int) {
@@ -60,8 +60,8 @@ func (h *DynamicInboundHandler) allocatePort() v2net.Port {
 	}
 }
 
-func (h *DynamicInboundHandler) waitAnyCloseWorkers(ctx context.Context, cancel context.CancelFunc, workers []worker, duration time.Duration) {
-	time.Sleep(duration)
+func (h *DynamicInboundHandler) waitAnyCloseWorkers(ctx context.Context, cancel context.CancelFunc, workers []worker) {
+	<-ctx.Done()
 	cancel()
 	ports2Del := make([]v2net.Port, len(workers))
 	for idx, worker := range workers {
@@ -106,6 +106,7 @@ func (h *DynamicInboundHandler) refresh() error {
 				recvOrigDest: h.receiverConfig.ReceiveOriginalDestination,
 				dispatcher:   h.mux,
 				sniffers:     h.receiverConfig.DomainOverride,
+				attributes:   h.receiverConfig.Attributes,
 			}
 			if err := worker.Start(); err != nil {
 				log.Trace(newError("failed to create TCP worker").Base(err).AtWarning())
@@ -122,6 +123,7 @@ func (h *DynamicInboundHandler) refresh() error {
 				port:         port,
 				recvOrigDest: h.receiverConfig.ReceiveOriginalDestination,
 				dispatcher:   h.mux,
+				attributes:   h.receiverConfig.Attributes,
 			}
 			if err := worker.Start(); err != nil {
 				log.Trace(newError("failed to create UDP worker").Base(err).AtWarning())
@@ -135,7 +137,7 @@ func (h *DynamicInboundHandler) refresh() error {
 	h.worker = workers
 	h.workerMutex.Unlock()
 
-	go h.waitAnyCloseWorkers(ctx, cancel, workers, timeout)
+	go h.waitAnyCloseWorkers(ctx, cancel, workers)
 
 	return nil
 }
@@ -162,6 +164,7 @@ func (h *DynamicInboundHandler) Start() error {
 
 func (h *DynamicInboundHandler) Close() {
 	h.cancel()
+	h.mux.Close()
 }
 
 func (h *DynamicInboundHandler) GetRandomInboundProxy() (proxy.Inbound, v2net.Port, int) {
@@ -4,6 +4,7 @@ package inbound
 
 import (
 	"context"
+	"sync"
 
 	"v2ray.com/core/app/proxyman"
 	"v2ray.com/core/common"
@@ -11,8 +12,10 @@ import (
 
 // Manager is to manage all inbound handlers.
 type Manager struct {
+	sync.RWMutex
 	handlers       []proxyman.InboundHandler
 	taggedHandlers map[string]proxyman.InboundHandler
+	running        bool
 }
 
 func New(ctx context.Context, config *proxyman.InboundConfig) (*Manager, error) {
@@ -55,6 +58,18 @@ func (m *Manager) AddHandler(ctx context.Context, config *proxyman.InboundHandle
 		return newError("unknown allocation strategy: ", receiverSettings.AllocationStrategy.Type)
 	}
 
+	m.Lock()
+	defer m.Unlock()
+
+	if _, found := m.taggedHandlers[tag]; found && len(tag) > 0 {
+		return newError("existing handler found for tag: ", tag)
+	}
+	if m.running {
+		if err := handler.Start(); err != nil {
+			return err
+		}
+	}
+
 	m.handlers = append(m.handlers, handler)
 	if len(tag) > 0 {
 		m.taggedHandlers[tag] = handler
@@ -63,6 +78,9 @@ func (m *Manager) AddHandler(ctx context.Context, config *proxyman.InboundHandle
 }
 
 func (m *Manager) GetHandler(ctx context.Context, tag string) (proxyman.InboundHandler, error) {
+	m.RLock()
+	defer m.RUnlock()
+
 	handler, found := m.taggedHandlers[tag]
 	if !found {
 		return nil, newError("handler not found: ", tag)
@@ -70,7 +88,36 @@ func (m *Manager) GetHandler(ctx context.Context, tag string) (proxyman.InboundH
 	return handler, nil
 }
 
+func (m *Manager) RemoveHandler(ctx context.Context, tag string) error {
+	if len(tag) == 0 {
+		return newError("empty tag")
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	handler, found := m.taggedHandlers[tag]
+	if !found {
+		return newError("handler not found: ", tag)
+	}
+	delete(m.taggedHandlers, tag)
+
+	for idx, h := range m.handlers {
+		if h == handler {
+			m.handlers = append(m.handlers[:idx], m.handlers[idx+1:]...)
+			break
+		}
+	}
+
+	handler.Close()
+	return nil
+}
+
 func (m *Manager) Start() error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.running = true
 	for _, handler := range m.handlers {
 		if err := handler.Start(); err != nil {
 			return err
@@ -80,6 +127,10 @@ func (m *Manager) Start() error {
 }
 
 func (m *Manager) Close() {
+	m.Lock()
+	defer m.Unlock()
+
+	m.running = false
 	for _, handler := range m.handlers {
 		handler.Close()
 	}
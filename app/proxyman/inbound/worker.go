@@ -10,6 +10,7 @@ import (
 
 	"v2ray.com/core/app/dispatcher"
 	"v2ray.com/core/app/log"
+	"v2ray.com/core/app/metrics"
 	"v2ray.com/core/app/proxyman"
 	"v2ray.com/core/common/buf"
 	v2net "v2ray.com/core/common/net"
@@ -19,6 +20,8 @@ import (
 	"v2ray.com/core/transport/internet/udp"
 )
 
+var activeConnections = metrics.NewGaugeVec("v2ray_inbound_active_connections", "Number of active connections of inbound handlers.", "tag")
+
 type worker interface {
 	Start() error
 	Close()
@@ -35,6 +38,7 @@ type tcpWorker struct {
 	tag          string
 	dispatcher   dispatcher.Interface
 	sniffers     []proxyman.KnownProtocols
+	attributes   map[string]string
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -42,6 +46,10 @@ type tcpWorker struct {
 }
 
 func (w *tcpWorker) callback(conn internet.Connection) {
+	gauge := activeConnections.With(w.tag)
+	gauge.Inc()
+	defer gauge.Dec()
+
 	ctx, cancel := context.WithCancel(w.ctx)
 	if w.recvOrigDest {
 		dest, err := tcp.GetOriginalDestination(conn)
@@ -60,6 +68,9 @@ func (w *tcpWorker) callback(conn internet.Connection) {
 	if len(w.sniffers) > 0 {
 		ctx = proxyman.ContextWithProtocolSniffers(ctx, w.sniffers)
 	}
+	if len(w.attributes) > 0 {
+		ctx = proxy.ContextWithAttributes(ctx, w.attributes)
+	}
 	if err := w.proxy.Process(ctx, v2net.Network_TCP, conn, w.dispatcher); err != nil {
 		log.Trace(newError("connection ends").Base(err))
 	}
@@ -90,7 +101,6 @@ func (w *tcpWorker) handleConnections(conns <-chan internet.Connection) {
 	for {
 		select {
 		case <-w.ctx.Done():
-			w.hub.Close()
 		L:
 			for {
 				select {
@@ -109,6 +119,7 @@ func (w *tcpWorker) handleConnections(conns <-chan internet.Connection) {
 
 func (w *tcpWorker) Close() {
 	if w.hub != nil {
+		w.hub.Close()
 		w.cancel()
 	}
 }
@@ -183,6 +194,7 @@ type udpWorker struct {
 	recvOrigDest bool
 	tag          string
 	dispatcher   dispatcher.Interface
+	attributes   map[string]string
 
 	ctx        context.Context
 	cancel     context.CancelFunc
@@ -227,6 +239,10 @@ func (w *udpWorker) callback(b *buf.Buffer, source v2net.Destination, originalDe
 
 	if !existing {
 		go func() {
+			gauge := activeConnections.With(w.tag)
+			gauge.Inc()
+			defer gauge.Dec()
+
 			ctx := w.ctx
 			ctx, cancel := context.WithCancel(ctx)
 			conn.cancel = cancel
@@ -238,6 +254,9 @@ func (w *udpWorker) callback(b *buf.Buffer, source v2net.Destination, originalDe
 			}
 			ctx = proxy.ContextWithSource(ctx, source)
 			ctx = proxy.ContextWithInboundEntryPoint(ctx, v2net.UDPDestination(w.address, w.port))
+			if len(w.attributes) > 0 {
+				ctx = proxy.ContextWithAttributes(ctx, w.attributes)
+			}
 			if err := w.proxy.Process(ctx, v2net.Network_UDP, conn, w.dispatcher); err != nil {
 				log.Trace(newError("connection ends").Base(err))
 			}
@@ -59,6 +59,16 @@ func (m *ClientManager) Dispatch(ctx context.Context, outboundRay ray.OutboundRa
 	return nil
 }
 
+// Close closes all clients in this manager, along with all sessions in them.
+func (m *ClientManager) Close() {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	for _, client := range m.clients {
+		client.cancel()
+	}
+}
+
 func (m *ClientManager) onClientFinish() {
 	m.access.Lock()
 	defer m.access.Unlock()
@@ -256,12 +266,16 @@ func (m *Client) fetchOutput() {
 }
 
 type Server struct {
+	access     sync.Mutex
 	dispatcher dispatcher.Interface
+	workers    map[*ServerWorker]bool
 }
 
 // NewServer creates a new mux.Server.
 func NewServer(ctx context.Context) *Server {
-	s := &Server{}
+	s := &Server{
+		workers: make(map[*ServerWorker]bool),
+	}
 	space := app.SpaceFromContext(ctx)
 	space.OnInitialize(func() error {
 		d := dispatcher.FromSpace(space)
@@ -285,10 +299,31 @@ func (s *Server) Dispatch(ctx context.Context, dest net.Destination) (ray.Inboun
 		outboundRay:    ray,
 		sessionManager: NewSessionManager(),
 	}
-	go worker.run(ctx)
+
+	s.access.Lock()
+	s.workers[worker] = true
+	s.access.Unlock()
+
+	go func() {
+		worker.run(ctx)
+
+		s.access.Lock()
+		delete(s.workers, worker)
+		s.access.Unlock()
+	}()
 	return ray, nil
 }
 
+// Close terminates all mux connections that are being served by this Server.
+func (s *Server) Close() {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	for worker := range s.workers {
+		worker.close()
+	}
+}
+
 type ServerWorker struct {
 	dispatcher     dispatcher.Interface
 	outboundRay    ray.OutboundRay
@@ -384,6 +419,12 @@ func (w *ServerWorker) handleFrame(ctx context.Context, reader io.Reader) error
 	return nil
 }
 
+func (w *ServerWorker) close() {
+	w.sessionManager.Close()
+	w.outboundRay.OutboundInput().CloseError()
+	w.outboundRay.OutboundOutput().CloseError()
+}
+
 func (w *ServerWorker) run(ctx context.Context) {
 	input := w.outboundRay.OutboundInput()
 	reader := buf.ToBytesReader(input)
@@ -4,11 +4,14 @@ import (
 	"io"
 	"sync"
 
+	"v2ray.com/core/app/metrics"
 	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/protocol"
 	"v2ray.com/core/transport/ray"
 )
 
+var activeSessions = metrics.NewGauge("v2ray_mux_active_sessions", "Number of active sessions in all Mux connections.")
+
 type SessionManager struct {
 	sync.RWMutex
 	sessions map[uint16]*Session
@@ -51,6 +54,7 @@ func (m *SessionManager) Allocate() *Session {
 		parent: m,
 	}
 	m.sessions[s.ID] = s
+	activeSessions.Inc()
 	return s
 }
 
@@ -58,6 +62,9 @@ func (m *SessionManager) Add(s *Session) {
 	m.Lock()
 	defer m.Unlock()
 
+	if _, found := m.sessions[s.ID]; !found {
+		activeSessions.Inc()
+	}
 	m.sessions[s.ID] = s
 }
 
@@ -65,7 +72,10 @@ func (m *SessionManager) Remove(id uint16) {
 	m.Lock()
 	defer m.Unlock()
 
-	delete(m.sessions, id)
+	if _, found := m.sessions[id]; found {
+		delete(m.sessions, id)
+		activeSessions.Dec()
+	}
 }
 
 func (m *SessionManager) Get(id uint16) (*Session, bool) {
@@ -110,6 +120,7 @@ func (m *SessionManager) Close() {
 		s.input.Close()
 		s.output.Close()
 	}
+	activeSessions.Add(-int64(len(m.sessions)))
 
 	m.sessions = make(map[uint16]*Session)
 }
@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"v2ray.com/core/app"
+	"v2ray.com/core/app/conntrack"
 	"v2ray.com/core/app/log"
 	"v2ray.com/core/app/proxyman"
 	"v2ray.com/core/app/proxyman/mux"
+	"v2ray.com/core/app/stats"
 	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/errors"
 	v2net "v2ray.com/core/common/net"
@@ -24,6 +26,10 @@ type Handler struct {
 	proxy           proxy.Outbound
 	outboundManager proxyman.OutboundHandlerManager
 	mux             *mux.ClientManager
+	uplink          *stats.Counter
+	downlink        *stats.Counter
+	observer
+	health
 }
 
 func NewHandler(ctx context.Context, config *proxyman.OutboundHandlerConfig) (*Handler, error) {
@@ -40,6 +46,10 @@ func NewHandler(ctx context.Context, config *proxyman.OutboundHandlerConfig) (*H
 			return newError("no OutboundManager in space")
 		}
 		h.outboundManager = ohm
+		if sm := stats.FromSpace(space); sm != nil && len(config.Tag) > 0 {
+			h.uplink = sm.GetOrRegisterCounter(stats.OutboundTrafficCounterName(config.Tag, stats.Uplink))
+			h.downlink = sm.GetOrRegisterCounter(stats.OutboundTrafficCounterName(config.Tag, stats.Downlink))
+		}
 		return nil
 	})
 
@@ -75,10 +85,25 @@ func NewHandler(ctx context.Context, config *proxyman.OutboundHandlerConfig) (*H
 
 // Dispatch implements proxy.Outbound.Dispatch.
 func (h *Handler) Dispatch(ctx context.Context, outboundRay ray.OutboundRay) {
+	if session := conntrack.SessionFromContext(ctx); session != nil && len(session.OutboundTag()) == 0 {
+		session.SetOutboundTag(h.config.Tag)
+	}
+	outboundRay = h.observe(outboundRay)
+	if h.uplink != nil {
+		outboundRay = ray.NewStatOutboundRay(outboundRay, h.uplink, h.downlink)
+	}
+	h.dispatch(ctx, outboundRay)
+}
+
+// dispatch sends the traffic in the ray through the proxy or Mux of this handler, without counting it in the
+// statistics of this handler.
+func (h *Handler) dispatch(ctx context.Context, outboundRay ray.OutboundRay) {
 	if h.mux != nil {
 		err := h.mux.Dispatch(ctx, outboundRay)
 		if err != nil {
 			log.Trace(newError("failed to process outbound traffic").Base(err))
+			outboundRay.OutboundOutput().CloseError()
+			outboundRay.OutboundInput().CloseError()
 		}
 	} else {
 		err := h.proxy.Process(ctx, outboundRay, h)
@@ -93,6 +118,13 @@ func (h *Handler) Dispatch(ctx context.Context, outboundRay ray.OutboundRay) {
 	}
 }
 
+// Close closes all multiplexed connections of this handler.
+func (h *Handler) Close() {
+	if h.mux != nil {
+		h.mux.Close()
+	}
+}
+
 // Dial implements proxy.Dialer.Dial().
 func (h *Handler) Dial(ctx context.Context, dest v2net.Destination) (internet.Connection, error) {
 	if h.senderSettings != nil {
@@ -128,7 +160,7 @@ var (
 )
 
 type Connection struct {
-	stream     ray.Ray
+	stream     ray.InboundRay
 	closed     bool
 	localAddr  net.Addr
 	remoteAddr net.Addr
@@ -138,7 +170,7 @@ type Connection struct {
 	writer      buf.Writer
 }
 
-func NewConnection(stream ray.Ray) *Connection {
+func NewConnection(stream ray.InboundRay) *Connection {
 	return &Connection{
 		stream: stream,
 		localAddr: &net.TCPAddr{
@@ -0,0 +1,218 @@
+package outbound
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"v2ray.com/core/app/log"
+	"v2ray.com/core/app/metrics"
+	"v2ray.com/core/app/proxyman"
+	v2net "v2ray.com/core/common/net"
+	"v2ray.com/core/proxy"
+	"v2ray.com/core/transport/ray"
+)
+
+// probeWindow is the number of recent probes for calculating success rate.
+const probeWindow = 10
+
+var healthyOutbounds = metrics.NewGaugeVec("v2ray_outbound_healthy", "Whether an outbound handler passes health probing, 1 for healthy and 0 for unhealthy.", "tag")
+
+// health keeps results of health probing of an outbound handler.
+type health struct {
+	access    sync.RWMutex
+	results   []bool
+	failures  uint32
+	unhealthy bool
+	rtt       time.Duration
+	lastProbe time.Time
+	lastError error
+}
+
+// Healthy implements proxyman.HealthReporter.
+func (h *health) Healthy() bool {
+	h.access.RLock()
+	defer h.access.RUnlock()
+	return !h.unhealthy
+}
+
+// HealthStatus implements proxyman.HealthReporter.
+func (h *health) HealthStatus() proxyman.HealthStatus {
+	h.access.RLock()
+	defer h.access.RUnlock()
+
+	status := proxyman.HealthStatus{
+		Healthy:   !h.unhealthy,
+		RTT:       h.rtt,
+		LastProbe: h.lastProbe,
+		LastError: h.lastError,
+	}
+	if len(h.results) > 0 {
+		succeeded := 0
+		for _, r := range h.results {
+			if r {
+				succeeded++
+			}
+		}
+		status.SuccessRate = float64(succeeded) / float64(len(h.results))
+	}
+	return status
+}
+
+// reportProbe records the result of a probe, and returns whether the handler is healthy afterwards.
+func (h *health) reportProbe(rtt time.Duration, err error, failureThreshold uint32) bool {
+	h.access.Lock()
+	defer h.access.Unlock()
+
+	h.lastProbe = time.Now()
+	h.lastError = err
+	if len(h.results) == probeWindow {
+		copy(h.results, h.results[1:])
+		h.results = h.results[:probeWindow-1]
+	}
+	h.results = append(h.results, err == nil)
+
+	if err == nil {
+		h.rtt = rtt
+		h.failures = 0
+		h.unhealthy = false
+	} else {
+		h.failures++
+		if h.failures >= failureThreshold {
+			h.unhealthy = true
+		}
+	}
+	return !h.unhealthy
+}
+
+// probe sends a HTTP GET request to the given URL through this handler, and returns the time until response.
+// The probe is not counted in the traffic statistics or active connections of the handler.
+func (h *Handler) probe(url string, timeout time.Duration) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(_ context.Context, network, addr string) (net.Conn, error) {
+				host, port, err := net.SplitHostPort(addr)
+				if err != nil {
+					return nil, err
+				}
+				p, err := v2net.PortFromString(port)
+				if err != nil {
+					return nil, err
+				}
+				dest := v2net.TCPDestination(v2net.ParseAddress(host), p)
+				stream := ray.NewRay(ctx)
+				// Probes are not user traffic, so they are kept out of the statistics and the observer.
+				go h.dispatch(proxy.ContextWithTarget(ctx, dest), stream)
+				return NewConnection(stream), nil
+			},
+			DisableKeepAlives: true,
+		},
+		Timeout: timeout,
+	}
+
+	start := time.Now()
+	resp, err := client.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	rtt := time.Since(start)
+	resp.Body.Close()
+	return rtt, nil
+}
+
+// prober probes outbound handlers of a Manager periodically.
+type prober struct {
+	config           *proxyman.HealthCheckConfig
+	url              string
+	interval         time.Duration
+	timeout          time.Duration
+	failureThreshold uint32
+	done             chan struct{}
+}
+
+func newProber(config *proxyman.HealthCheckConfig) *prober {
+	p := &prober{
+		config:           config,
+		url:              config.ProbeUrl,
+		interval:         time.Second * time.Duration(config.Interval),
+		timeout:          time.Second * time.Duration(config.Timeout),
+		failureThreshold: config.FailureThreshold,
+	}
+	if len(p.url) == 0 {
+		p.url = "http://www.gstatic.com/generate_204"
+	}
+	if p.interval == 0 {
+		p.interval = time.Minute
+	}
+	if p.timeout == 0 {
+		p.timeout = time.Second * 10
+	}
+	if p.failureThreshold == 0 {
+		p.failureThreshold = 3
+	}
+	return p
+}
+
+func (p *prober) probeAll(m *Manager) {
+	selectors := p.config.TagSelector
+	if len(selectors) == 0 {
+		selectors = []string{""}
+	}
+
+	var wg sync.WaitGroup
+	for _, tag := range m.Select(selectors) {
+		handler, ok := m.GetHandler(tag).(*Handler)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(tag string, handler *Handler) {
+			defer wg.Done()
+			rtt, err := handler.probe(p.url, p.timeout)
+			wasHealthy := handler.Healthy()
+			healthy := handler.reportProbe(rtt, err, p.failureThreshold)
+			if healthy {
+				healthyOutbounds.With(tag).Set(1)
+			} else {
+				healthyOutbounds.With(tag).Set(0)
+			}
+			if wasHealthy && !healthy {
+				log.Trace(newError("outbound ", tag, " is unhealthy").Base(err).AtWarning())
+			} else if !wasHealthy && healthy {
+				log.Trace(newError("outbound ", tag, " is healthy again").AtInfo())
+			}
+		}(tag, handler)
+	}
+	wg.Wait()
+}
+
+func (p *prober) run(m *Manager) {
+	p.probeAll(m)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.probeAll(m)
+		}
+	}
+}
+
+func (p *prober) start(m *Manager) {
+	p.done = make(chan struct{})
+	go p.run(m)
+}
+
+func (p *prober) stop() {
+	if p.done != nil {
+		close(p.done)
+		p.done = nil
+	}
+}
@@ -0,0 +1,73 @@
+package outbound_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/proxyman"
+	. "v2ray.com/core/app/proxyman/outbound"
+	"v2ray.com/core/common/serial"
+	"v2ray.com/core/proxy/blackhole"
+	"v2ray.com/core/proxy/freedom"
+	"v2ray.com/core/testing/assert"
+	_ "v2ray.com/core/transport/internet/tcp"
+)
+
+func TestHealthProbe(t *testing.T) {
+	assert := assert.On(t)
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer httpServer.Close()
+
+	space := app.NewSpace()
+	ctx := app.ContextWithSpace(context.Background(), space)
+	assert.Error(app.AddApplicationToSpace(ctx, &proxyman.OutboundConfig{
+		HealthCheck: &proxyman.HealthCheckConfig{
+			ProbeUrl:         httpServer.URL,
+			Timeout:          1,
+			FailureThreshold: 2,
+		},
+	})).IsNil()
+	assert.Error(space.Initialize()).IsNil()
+
+	ohm := proxyman.OutboundHandlerManagerFromSpace(space)
+	assert.Error(ohm.AddHandler(ctx, &proxyman.OutboundHandlerConfig{
+		Tag:           "direct",
+		ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+	})).IsNil()
+	assert.Error(ohm.AddHandler(ctx, &proxyman.OutboundHandlerConfig{
+		Tag:           "dead",
+		ProxySettings: serial.ToTypedMessage(&blackhole.Config{}),
+	})).IsNil()
+
+	manager := ohm.(*Manager)
+	direct := ohm.GetHandler("direct").(proxyman.HealthReporter)
+	dead := ohm.GetHandler("dead").(proxyman.HealthReporter)
+	assert.Bool(direct.Healthy()).IsTrue()
+	assert.Bool(dead.Healthy()).IsTrue()
+	assert.Bool(direct.HealthStatus().LastProbe.IsZero()).IsTrue()
+
+	manager.ProbeAll()
+
+	status := direct.HealthStatus()
+	assert.Bool(status.Healthy).IsTrue()
+	assert.Error(status.LastError).IsNil()
+	assert.Bool(status.RTT > 0).IsTrue()
+	assert.Bool(status.SuccessRate == 1).IsTrue()
+	// Probes are not counted as connections of the handler.
+	assert.Int(ohm.GetHandler("direct").(*Handler).ActiveConnections()).Equals(0)
+
+	status = dead.HealthStatus()
+	assert.Bool(status.Healthy).IsTrue()
+	assert.Error(status.LastError).IsNotNil()
+	assert.Bool(status.SuccessRate == 0).IsTrue()
+
+	manager.ProbeAll()
+	assert.Bool(dead.Healthy()).IsFalse()
+	assert.Bool(direct.Healthy()).IsTrue()
+}
@@ -0,0 +1,61 @@
+package outbound
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"v2ray.com/core/transport/ray"
+)
+
+// observer keeps track of active connections of an outbound handler.
+type observer struct {
+	active int32
+}
+
+// ActiveConnections returns the number of connections being handled.
+func (o *observer) ActiveConnections() int {
+	return int(atomic.LoadInt32(&o.active))
+}
+
+// observe returns an OutboundRay that reports to this observer. The connection is active until its downlink is closed.
+func (o *observer) observe(r ray.OutboundRay) ray.OutboundRay {
+	atomic.AddInt32(&o.active, 1)
+	return &observedOutboundRay{
+		OutboundRay: r,
+		output: &observedOutputStream{
+			OutputStream: r.OutboundOutput(),
+			observer:     o,
+		},
+	}
+}
+
+type observedOutboundRay struct {
+	ray.OutboundRay
+	output ray.OutputStream
+}
+
+func (r *observedOutboundRay) OutboundOutput() ray.OutputStream {
+	return r.output
+}
+
+type observedOutputStream struct {
+	ray.OutputStream
+	observer *observer
+	done     sync.Once
+}
+
+func (s *observedOutputStream) finish() {
+	s.done.Do(func() {
+		atomic.AddInt32(&s.observer.active, -1)
+	})
+}
+
+func (s *observedOutputStream) Close() {
+	s.finish()
+	s.OutputStream.Close()
+}
+
+func (s *observedOutputStream) CloseError() {
+	s.finish()
+	s.OutputStream.CloseError()
+}
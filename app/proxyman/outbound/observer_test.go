@@ -0,0 +1,24 @@
+package outbound
+
+import (
+	"context"
+	"testing"
+
+	"v2ray.com/core/testing/assert"
+	"v2ray.com/core/transport/ray"
+)
+
+func TestObserver(t *testing.T) {
+	assert := assert.On(t)
+
+	o := new(observer)
+	r1 := o.observe(ray.NewRay(context.Background()))
+	r2 := o.observe(ray.NewRay(context.Background()))
+	assert.Int(o.ActiveConnections()).Equals(2)
+
+	r1.OutboundOutput().Close()
+	r1.OutboundOutput().CloseError()
+	assert.Int(o.ActiveConnections()).Equals(1)
+	r2.OutboundOutput().CloseError()
+	assert.Int(o.ActiveConnections()).Equals(0)
+}
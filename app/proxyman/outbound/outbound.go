@@ -4,6 +4,8 @@ package outbound
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
 
 	"v2ray.com/core/app/proxyman"
@@ -15,13 +17,18 @@ type Manager struct {
 	sync.RWMutex
 	defaultHandler *Handler
 	taggedHandler  map[string]*Handler
+	prober         *prober
 }
 
 // New creates a new Manager.
 func New(ctx context.Context, config *proxyman.OutboundConfig) (*Manager, error) {
-	return &Manager{
+	m := &Manager{
 		taggedHandler: make(map[string]*Handler),
-	}, nil
+	}
+	if config.HealthCheck != nil {
+		m.prober = newProber(config.HealthCheck)
+	}
+	return m, nil
 }
 
 // Interface implements Application.Interface.
@@ -30,10 +37,27 @@ func (*Manager) Interface() interface{} {
 }
 
 // Start implements Application.Start
-func (*Manager) Start() error { return nil }
+func (m *Manager) Start() error {
+	if m.prober != nil {
+		m.prober.start(m)
+	}
+	return nil
+}
+
+// ProbeAll probes all outbound handlers selected by health check config immediately, and waits for the results.
+// It does nothing if health check is not enabled.
+func (m *Manager) ProbeAll() {
+	if m.prober != nil {
+		m.prober.probeAll(m)
+	}
+}
 
 // Close implements Application.Close
-func (*Manager) Close() {}
+func (m *Manager) Close() {
+	if m.prober != nil {
+		m.prober.stop()
+	}
+}
 
 func (m *Manager) GetDefaultHandler() proxyman.OutboundHandler {
 	m.RLock()
@@ -53,6 +77,26 @@ func (m *Manager) GetHandler(tag string) proxyman.OutboundHandler {
 	return nil
 }
 
+// Select returns the tags of all handlers whose tag begins with any of the selectors, in alphabetical order.
+func (m *Manager) Select(selectors []string) []string {
+	m.RLock()
+	defer m.RUnlock()
+
+	tags := make([]string, 0, len(selectors))
+	for tag := range m.taggedHandler {
+		for _, selector := range selectors {
+			if strings.HasPrefix(tag, selector) {
+				tags = append(tags, tag)
+				break
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// AddHandler creates a new handler with the given config. If there is already a handler with the same tag,
+// it is replaced by the new one and closed. The new handler also becomes the default one if it replaces the default.
 func (m *Manager) AddHandler(ctx context.Context, config *proxyman.OutboundHandlerConfig) error {
 	m.Lock()
 	defer m.Unlock()
@@ -66,12 +110,39 @@ func (m *Manager) AddHandler(ctx context.Context, config *proxyman.OutboundHandl
 	}
 
 	if len(config.Tag) > 0 {
+		if existing, found := m.taggedHandler[config.Tag]; found {
+			if existing == m.defaultHandler {
+				m.defaultHandler = handler
+			}
+			existing.Close()
+		}
 		m.taggedHandler[config.Tag] = handler
 	}
 
 	return nil
 }
 
+func (m *Manager) RemoveHandler(ctx context.Context, tag string) error {
+	if len(tag) == 0 {
+		return newError("empty tag")
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	handler, found := m.taggedHandler[tag]
+	if !found {
+		return newError("handler not found: ", tag)
+	}
+	if handler == m.defaultHandler {
+		return newError("unable to remove the default handler: ", tag)
+	}
+	delete(m.taggedHandler, tag)
+
+	handler.Close()
+	return nil
+}
+
 func init() {
 	common.Must(common.RegisterConfig((*proxyman.OutboundConfig)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
 		return New(ctx, config.(*proxyman.OutboundConfig))
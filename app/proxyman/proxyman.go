@@ -5,6 +5,7 @@ package proxyman
 
 import (
 	"context"
+	"time"
 
 	"v2ray.com/core/app"
 	"v2ray.com/core/common/net"
@@ -15,6 +16,8 @@ import (
 type InboundHandlerManager interface {
 	GetHandler(ctx context.Context, tag string) (InboundHandler, error)
 	AddHandler(ctx context.Context, config *InboundHandlerConfig) error
+	// RemoveHandler closes the handler with the given tag and removes it from the manager.
+	RemoveHandler(ctx context.Context, tag string) error
 }
 
 type InboundHandler interface {
@@ -28,13 +31,39 @@ type InboundHandler interface {
 type OutboundHandlerManager interface {
 	GetHandler(tag string) OutboundHandler
 	GetDefaultHandler() OutboundHandler
+	// Select returns the tags of all handlers whose tag begins with any of the selectors.
+	Select(selectors []string) []string
+	// AddHandler creates a new handler. An existing handler with the same tag is replaced.
 	AddHandler(ctx context.Context, config *OutboundHandlerConfig) error
+	// RemoveHandler closes the handler with the given tag and removes it from the manager.
+	// The default handler can't be removed.
+	RemoveHandler(ctx context.Context, tag string) error
 }
 
 type OutboundHandler interface {
 	Dispatch(ctx context.Context, outboundRay ray.OutboundRay)
 }
 
+// HealthStatus is the result of health probing of an outbound handler.
+type HealthStatus struct {
+	// Healthy is false if the handler failed too many consecutive probes.
+	Healthy bool
+	// SuccessRate is the ratio of successful ones in recent probes.
+	SuccessRate float64
+	// RTT is the round trip time of the last successful probe.
+	RTT time.Duration
+	// LastProbe is the time of the last probe. It is zero if the handler is never probed.
+	LastProbe time.Time
+	// LastError is the error of the last probe, or nil if it succeeded.
+	LastError error
+}
+
+// HealthReporter is implemented by outbound handlers that support health probing.
+type HealthReporter interface {
+	Healthy() bool
+	HealthStatus() HealthStatus
+}
+
 func InboundHandlerManagerFromSpace(space app.Space) InboundHandlerManager {
 	app := space.GetApplication((*InboundHandlerManager)(nil))
 	if app == nil {
@@ -0,0 +1,186 @@
+package router
+
+import (
+	"sync/atomic"
+	"time"
+
+	"v2ray.com/core/app/proxyman"
+	"v2ray.com/core/common/dice"
+)
+
+// BalancingStrategy picks one outbound tag from the candidates.
+type BalancingStrategy interface {
+	PickOutbound(ohm proxyman.OutboundHandlerManager, tags []string) string
+	// PeekOutbound returns the outbound that PickOutbound would pick, without changing the state of the strategy.
+	PeekOutbound(ohm proxyman.OutboundHandlerManager, tags []string) string
+}
+
+// RandomStrategy picks a random outbound.
+type RandomStrategy struct{}
+
+func (RandomStrategy) PickOutbound(ohm proxyman.OutboundHandlerManager, tags []string) string {
+	return tags[dice.Roll(len(tags))]
+}
+
+func (s RandomStrategy) PeekOutbound(ohm proxyman.OutboundHandlerManager, tags []string) string {
+	return s.PickOutbound(ohm, tags)
+}
+
+// RoundRobinStrategy picks outbounds in turn.
+type RoundRobinStrategy struct {
+	next uint32
+}
+
+func (s *RoundRobinStrategy) PickOutbound(ohm proxyman.OutboundHandlerManager, tags []string) string {
+	n := atomic.AddUint32(&s.next, 1) - 1
+	return tags[n%uint32(len(tags))]
+}
+
+func (s *RoundRobinStrategy) PeekOutbound(ohm proxyman.OutboundHandlerManager, tags []string) string {
+	n := atomic.LoadUint32(&s.next)
+	return tags[n%uint32(len(tags))]
+}
+
+// isHealthy returns false if the outbound with the given tag fails health probing.
+func isHealthy(ohm proxyman.OutboundHandlerManager, tag string) bool {
+	reporter, ok := ohm.GetHandler(tag).(proxyman.HealthReporter)
+	return !ok || reporter.Healthy()
+}
+
+type activeConnectionCounter interface {
+	ActiveConnections() int
+}
+
+// LeastActiveStrategy picks the outbound with the least active connections. Ties are broken randomly.
+type LeastActiveStrategy struct{}
+
+func (LeastActiveStrategy) PickOutbound(ohm proxyman.OutboundHandlerManager, tags []string) string {
+	return pickMinimum(tags, func(tag string) int64 {
+		counter, ok := ohm.GetHandler(tag).(activeConnectionCounter)
+		if !ok {
+			return 0
+		}
+		return int64(counter.ActiveConnections())
+	})
+}
+
+func (s LeastActiveStrategy) PeekOutbound(ohm proxyman.OutboundHandlerManager, tags []string) string {
+	return s.PickOutbound(ohm, tags)
+}
+
+// LowestLatencyStrategy picks the outbound with the lowest round trip time in health probing. Outbounds that have
+// not been probed successfully are skipped, unless none of the outbounds has been.
+type LowestLatencyStrategy struct{}
+
+func (LowestLatencyStrategy) PickOutbound(ohm proxyman.OutboundHandlerManager, tags []string) string {
+	var probed []string
+	for _, tag := range tags {
+		if probeRTT(ohm, tag) > 0 {
+			probed = append(probed, tag)
+		}
+	}
+	if len(probed) == 0 {
+		return tags[dice.Roll(len(tags))]
+	}
+	return pickMinimum(probed, func(tag string) int64 {
+		return int64(probeRTT(ohm, tag))
+	})
+}
+
+func (s LowestLatencyStrategy) PeekOutbound(ohm proxyman.OutboundHandlerManager, tags []string) string {
+	return s.PickOutbound(ohm, tags)
+}
+
+// probeRTT returns the round trip time of the last successful health probe of the outbound, or 0 if unknown.
+func probeRTT(ohm proxyman.OutboundHandlerManager, tag string) time.Duration {
+	reporter, ok := ohm.GetHandler(tag).(proxyman.HealthReporter)
+	if !ok {
+		return 0
+	}
+	return reporter.HealthStatus().RTT
+}
+
+func pickMinimum(tags []string, value func(string) int64) string {
+	var candidates []string
+	var min int64
+	for _, tag := range tags {
+		v := value(tag)
+		if len(candidates) == 0 || v < min {
+			candidates = append(candidates[:0], tag)
+			min = v
+		} else if v == min {
+			candidates = append(candidates, tag)
+		}
+	}
+	return candidates[dice.Roll(len(candidates))]
+}
+
+// Balancer picks an outbound from a group of outbounds for each connection.
+type Balancer struct {
+	selectors []string
+	strategy  BalancingStrategy
+	ohm       proxyman.OutboundHandlerManager
+}
+
+// NewBalancer creates a Balancer for the given rule.
+func NewBalancer(rule *BalancingRule, ohm proxyman.OutboundHandlerManager) (*Balancer, error) {
+	if len(rule.OutboundSelector) == 0 {
+		return nil, newError("no outbound selector in balancer: ", rule.Tag)
+	}
+
+	var strategy BalancingStrategy
+	switch rule.Strategy {
+	case BalancingRule_Random:
+		strategy = RandomStrategy{}
+	case BalancingRule_RoundRobin:
+		strategy = new(RoundRobinStrategy)
+	case BalancingRule_LeastActive:
+		strategy = LeastActiveStrategy{}
+	case BalancingRule_LowestLatency:
+		strategy = LowestLatencyStrategy{}
+	default:
+		return nil, newError("unknown balancing strategy: ", rule.Strategy)
+	}
+
+	return &Balancer{
+		selectors: rule.OutboundSelector,
+		strategy:  strategy,
+		ohm:       ohm,
+	}, nil
+}
+
+// PickOutbound returns the tag of the picked outbound. Unhealthy outbounds are never picked.
+func (b *Balancer) PickOutbound() (string, error) {
+	tags, err := b.healthyTags()
+	if err != nil {
+		return "", err
+	}
+	return b.strategy.PickOutbound(b.ohm, tags), nil
+}
+
+// PeekOutbound returns the tag of the outbound that PickOutbound would pick, without changing the state of the
+// balancing strategy.
+func (b *Balancer) PeekOutbound() (string, error) {
+	tags, err := b.healthyTags()
+	if err != nil {
+		return "", err
+	}
+	return b.strategy.PeekOutbound(b.ohm, tags), nil
+}
+
+func (b *Balancer) healthyTags() ([]string, error) {
+	tags := b.ohm.Select(b.selectors)
+	if len(tags) == 0 {
+		return nil, newError("no outbound matches selectors ", b.selectors)
+	}
+	healthyTags := tags[:0]
+	for _, tag := range tags {
+		if isHealthy(b.ohm, tag) {
+			healthyTags = append(healthyTags, tag)
+		}
+	}
+	if len(healthyTags) == 0 {
+		return nil, newError("no healthy outbound matches selectors ", b.selectors)
+	}
+	return healthyTags, nil
+}
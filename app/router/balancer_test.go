@@ -0,0 +1,184 @@
+package router_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/dispatcher"
+	_ "v2ray.com/core/app/dispatcher/impl"
+	"v2ray.com/core/app/dns"
+	_ "v2ray.com/core/app/dns/server"
+	"v2ray.com/core/app/proxyman"
+	_ "v2ray.com/core/app/proxyman/outbound"
+	. "v2ray.com/core/app/router"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/serial"
+	"v2ray.com/core/proxy"
+	"v2ray.com/core/proxy/freedom"
+	"v2ray.com/core/testing/assert"
+	"v2ray.com/core/transport/ray"
+)
+
+type testHandler struct {
+	active    int
+	latency   time.Duration
+	unhealthy bool
+}
+
+func (*testHandler) Dispatch(ctx context.Context, outboundRay ray.OutboundRay) {}
+
+func (h *testHandler) ActiveConnections() int {
+	return h.active
+}
+
+func (h *testHandler) Healthy() bool {
+	return !h.unhealthy
+}
+
+func (h *testHandler) HealthStatus() proxyman.HealthStatus {
+	return proxyman.HealthStatus{Healthy: !h.unhealthy, RTT: h.latency}
+}
+
+type testHandlerManager map[string]*testHandler
+
+func (m testHandlerManager) GetHandler(tag string) proxyman.OutboundHandler {
+	return m[tag]
+}
+
+func (testHandlerManager) GetDefaultHandler() proxyman.OutboundHandler {
+	return nil
+}
+
+func (m testHandlerManager) Select(selectors []string) []string {
+	return []string{"a", "b", "c"}
+}
+
+func (testHandlerManager) AddHandler(ctx context.Context, config *proxyman.OutboundHandlerConfig) error {
+	return nil
+}
+
+func (testHandlerManager) RemoveHandler(ctx context.Context, tag string) error {
+	return nil
+}
+
+func TestBalancingStrategy(t *testing.T) {
+	assert := assert.On(t)
+
+	ohm := testHandlerManager{
+		"a": {active: 3, latency: time.Millisecond * 30},
+		"b": {active: 1, latency: time.Millisecond * 20},
+		"c": {active: 2, latency: time.Millisecond * 10},
+	}
+	tags := ohm.Select(nil)
+
+	roundRobin := new(RoundRobinStrategy)
+	for i := 0; i < 6; i++ {
+		assert.String(roundRobin.PeekOutbound(ohm, tags)).Equals(tags[i%3])
+		assert.String(roundRobin.PeekOutbound(ohm, tags)).Equals(tags[i%3])
+		assert.String(roundRobin.PickOutbound(ohm, tags)).Equals(tags[i%3])
+	}
+
+	assert.String(LeastActiveStrategy{}.PickOutbound(ohm, tags)).Equals("b")
+	assert.String(LowestLatencyStrategy{}.PickOutbound(ohm, tags)).Equals("c")
+
+	// Outbounds without probe results are skipped.
+	ohm["a"].latency = 0
+	ohm["c"].latency = 0
+	assert.String(LowestLatencyStrategy{}.PickOutbound(ohm, tags)).Equals("b")
+
+	picked := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		picked[RandomStrategy{}.PickOutbound(ohm, tags)] = true
+	}
+	assert.Int(len(picked)).Equals(3)
+}
+
+func TestBalancerSkipsUnhealthy(t *testing.T) {
+	assert := assert.On(t)
+
+	ohm := testHandlerManager{
+		"a": {unhealthy: true},
+		"b": {},
+		"c": {unhealthy: true},
+	}
+	balancer, err := NewBalancer(&BalancingRule{
+		Tag:              "lb",
+		OutboundSelector: []string{""},
+		Strategy:         BalancingRule_RoundRobin,
+	}, ohm)
+	assert.Error(err).IsNil()
+
+	for i := 0; i < 3; i++ {
+		tag, err := balancer.PickOutbound()
+		assert.Error(err).IsNil()
+		assert.String(tag).Equals("b")
+	}
+
+	ohm["b"].unhealthy = true
+	_, err = balancer.PickOutbound()
+	assert.Error(err).IsNotNil()
+}
+
+func TestRouterBalancer(t *testing.T) {
+	assert := assert.On(t)
+
+	config := &Config{
+		Rule: []*RoutingRule{
+			{
+				BalancingTag: "lb",
+				NetworkList: &net.NetworkList{
+					Network: []net.Network{net.Network_TCP},
+				},
+			},
+		},
+		BalancingRule: []*BalancingRule{
+			{
+				Tag:              "lb",
+				OutboundSelector: []string{"out-"},
+				Strategy:         BalancingRule_RoundRobin,
+			},
+		},
+	}
+
+	space := app.NewSpace()
+	ctx := app.ContextWithSpace(context.Background(), space)
+	assert.Error(app.AddApplicationToSpace(ctx, new(dns.Config))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, new(dispatcher.Config))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, new(proxyman.OutboundConfig))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, config)).IsNil()
+
+	ohm := proxyman.OutboundHandlerManagerFromSpace(space)
+	for _, tag := range []string{"direct", "out-1", "out-2"} {
+		assert.Error(ohm.AddHandler(ctx, &proxyman.OutboundHandlerConfig{
+			Tag:           tag,
+			ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+		})).IsNil()
+	}
+	assert.Error(space.Initialize()).IsNil()
+
+	r := FromSpace(space)
+	ctx = proxy.ContextWithTarget(ctx, net.TCPDestination(net.DomainAddress("v2ray.com"), 80))
+	for i := 0; i < 4; i++ {
+		tag, err := r.TakeDetour(ctx)
+		assert.Error(err).IsNil()
+		assert.String(tag).Equals([]string{"out-1", "out-2"}[i%2])
+	}
+
+	assert.Error(ohm.RemoveHandler(ctx, "out-1")).IsNil()
+	assert.Error(ohm.RemoveHandler(ctx, "out-2")).IsNil()
+	_, err := r.TakeDetour(ctx)
+	assert.Error(err).IsNotNil()
+
+	assert.Error(r.Reload(&Config{
+		Rule: []*RoutingRule{
+			{
+				BalancingTag: "unknown",
+				NetworkList: &net.NetworkList{
+					Network: []net.Network{net.Network_TCP},
+				},
+			},
+		},
+	})).IsNotNil()
+}
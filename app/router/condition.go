@@ -3,8 +3,10 @@ package router
 import (
 	"context"
 	"net"
+	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	v2net "v2ray.com/core/common/net"
 	"v2ray.com/core/common/protocol"
@@ -240,6 +242,44 @@ func (v *PortMatcher) Apply(ctx context.Context) bool {
 	return v.port.Contains(dest.Port)
 }
 
+// SourcePortMatcher matches the port of the source address.
+type SourcePortMatcher struct {
+	port v2net.PortRange
+}
+
+func NewSourcePortMatcher(portRange v2net.PortRange) *SourcePortMatcher {
+	return &SourcePortMatcher{
+		port: portRange,
+	}
+}
+
+func (v *SourcePortMatcher) Apply(ctx context.Context) bool {
+	source, ok := proxy.SourceFromContext(ctx)
+	if !ok {
+		return false
+	}
+	return v.port.Contains(source.Port)
+}
+
+// LocalPortMatcher matches the port that the inbound receives the connection on.
+type LocalPortMatcher struct {
+	port v2net.PortRange
+}
+
+func NewLocalPortMatcher(portRange v2net.PortRange) *LocalPortMatcher {
+	return &LocalPortMatcher{
+		port: portRange,
+	}
+}
+
+func (v *LocalPortMatcher) Apply(ctx context.Context) bool {
+	entryPoint, ok := proxy.InboundEntryPointFromContext(ctx)
+	if !ok {
+		return false
+	}
+	return v.port.Contains(entryPoint.Port)
+}
+
 type NetworkMatcher struct {
 	network *v2net.NetworkList
 }
@@ -316,3 +356,209 @@ func (v *InboundTagMatcher) Apply(ctx context.Context) bool {
 	}
 	return false
 }
+
+// AttributeMatcher matches attributes attached to the connection by the inbound.
+type AttributeMatcher struct {
+	attributes map[string]string
+}
+
+func NewAttributeMatcher(attributes map[string]string) *AttributeMatcher {
+	return &AttributeMatcher{
+		attributes: attributes,
+	}
+}
+
+func (m *AttributeMatcher) Apply(ctx context.Context) bool {
+	attributes := proxy.AttributesFromContext(ctx)
+	for key, value := range m.attributes {
+		v, found := attributes[key]
+		if !found || (len(value) > 0 && v != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// ProtocolMatcher matches the sniffed protocol of the connection. "unknown" matches connections whose protocol
+// is not recognized.
+type ProtocolMatcher struct {
+	protocols []string
+}
+
+func NewProtocolMatcher(protocols []string) *ProtocolMatcher {
+	protocolsCopy := make([]string, 0, len(protocols))
+	for _, p := range protocols {
+		if len(p) > 0 {
+			protocolsCopy = append(protocolsCopy, strings.ToLower(p))
+		}
+	}
+	return &ProtocolMatcher{
+		protocols: protocolsCopy,
+	}
+}
+
+func (m *ProtocolMatcher) Apply(ctx context.Context) bool {
+	result, ok := proxy.SniffingResultFromContext(ctx)
+	if !ok {
+		return false
+	}
+	protocol := result.Protocol
+	if len(protocol) == 0 {
+		protocol = "unknown"
+	}
+	for _, p := range m.protocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPMatcher matches attributes of a plain HTTP request.
+type HTTPMatcher struct {
+	methods      []string
+	pathPrefixes []string
+	headers      []*HTTPHeader
+}
+
+func NewHTTPMatcher(condition *HTTPCondition) *HTTPMatcher {
+	m := &HTTPMatcher{
+		pathPrefixes: condition.PathPrefix,
+	}
+	for _, method := range condition.Method {
+		m.methods = append(m.methods, strings.ToUpper(method))
+	}
+	for _, header := range condition.Header {
+		m.headers = append(m.headers, &HTTPHeader{
+			Name:  header.Name,
+			Value: strings.ToLower(header.Value),
+		})
+	}
+	return m
+}
+
+func (m *HTTPMatcher) Apply(ctx context.Context) bool {
+	result, ok := proxy.SniffingResultFromContext(ctx)
+	if !ok || result.Protocol != "http" {
+		return false
+	}
+	if len(m.methods) > 0 && !containsString(m.methods, result.HTTPMethod) {
+		return false
+	}
+	if len(m.pathPrefixes) > 0 {
+		matched := false
+		for _, prefix := range m.pathPrefixes {
+			if strings.HasPrefix(result.HTTPPath, prefix) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, header := range m.headers {
+		values, found := result.HTTPHeader[http.CanonicalHeaderKey(header.Name)]
+		if !found {
+			return false
+		}
+		matched := false
+		for _, value := range values {
+			if strings.Contains(strings.ToLower(value), header.Value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+type timeWindow struct {
+	weekdays  [7]bool
+	startHour int
+	endHour   int
+}
+
+func (w *timeWindow) contains(t time.Time) bool {
+	hour := t.Hour()
+	day := t.Weekday()
+	if w.startHour < w.endHour {
+		return w.weekdays[day] && hour >= w.startHour && hour < w.endHour
+	}
+	// The window ends on the next day.
+	previousDay := (day + 6) % 7
+	return (w.weekdays[day] && hour >= w.startHour) || (w.weekdays[previousDay] && hour < w.endHour)
+}
+
+// TimeMatcher matches the current local time against time windows.
+type TimeMatcher struct {
+	location *time.Location
+	windows  []timeWindow
+}
+
+func NewTimeMatcher(condition *TimeCondition) (*TimeMatcher, error) {
+	if len(condition.Window) == 0 {
+		return nil, newError("no time window")
+	}
+	location := time.Local
+	if len(condition.Timezone) > 0 {
+		loc, err := time.LoadLocation(condition.Timezone)
+		if err != nil {
+			return nil, newError("invalid timezone: ", condition.Timezone).Base(err)
+		}
+		location = loc
+	}
+
+	m := &TimeMatcher{
+		location: location,
+	}
+	for _, window := range condition.Window {
+		if window.StartHour >= 24 || window.EndHour > 24 {
+			return nil, newError("invalid hour range: ", window.StartHour, "-", window.EndHour)
+		}
+		w := timeWindow{
+			startHour: int(window.StartHour),
+			endHour:   int(window.EndHour),
+		}
+		if len(window.Weekday) == 0 {
+			for i := range w.weekdays {
+				w.weekdays[i] = true
+			}
+		}
+		for _, day := range window.Weekday {
+			if day < Weekday_Sunday || day > Weekday_Saturday {
+				return nil, newError("invalid weekday: ", day)
+			}
+			w.weekdays[day] = true
+		}
+		m.windows = append(m.windows, w)
+	}
+	return m, nil
+}
+
+// ApplyAt returns true if the given time is in any of the windows.
+func (m *TimeMatcher) ApplyAt(t time.Time) bool {
+	t = t.In(m.location)
+	for i := range m.windows {
+		if m.windows[i].contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *TimeMatcher) Apply(ctx context.Context) bool {
+	return m.ApplyAt(time.Now())
+}
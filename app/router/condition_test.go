@@ -2,7 +2,9 @@ package router_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	. "v2ray.com/core/app/router"
 	"v2ray.com/core/common/net"
@@ -168,6 +170,133 @@ func TestRoutingRule(t *testing.T) {
 				},
 			},
 		},
+		{
+			rule: &RoutingRule{
+				Protocol: []string{"bittorrent", "unknown"},
+			},
+			test: []ruleTest{
+				ruleTest{
+					input:  proxy.ContextWithSniffingResult(context.Background(), &proxy.SniffingResult{Protocol: "bittorrent"}),
+					output: true,
+				},
+				ruleTest{
+					input:  proxy.ContextWithSniffingResult(context.Background(), &proxy.SniffingResult{}),
+					output: true,
+				},
+				ruleTest{
+					input:  proxy.ContextWithSniffingResult(context.Background(), &proxy.SniffingResult{Protocol: "tls", Domain: "v2ray.com"}),
+					output: false,
+				},
+				ruleTest{
+					input:  context.Background(),
+					output: false,
+				},
+			},
+		},
+		{
+			rule: &RoutingRule{
+				Http: &HTTPCondition{
+					Method:     []string{"get", "head"},
+					PathPrefix: []string{"/api/"},
+					Header: []*HTTPHeader{
+						{Name: "user-agent", Value: "curl"},
+						{Name: "X-Token"},
+					},
+				},
+			},
+			test: []ruleTest{
+				ruleTest{
+					input: proxy.ContextWithSniffingResult(context.Background(), &proxy.SniffingResult{
+						Protocol:   "http",
+						HTTPMethod: "GET",
+						HTTPPath:   "/api/v1",
+						HTTPHeader: http.Header{"User-Agent": {"Curl/7.54"}, "X-Token": {""}},
+					}),
+					output: true,
+				},
+				ruleTest{
+					input: proxy.ContextWithSniffingResult(context.Background(), &proxy.SniffingResult{
+						Protocol:   "http",
+						HTTPMethod: "POST",
+						HTTPPath:   "/api/v1",
+						HTTPHeader: http.Header{"User-Agent": {"curl/7.54"}, "X-Token": {""}},
+					}),
+					output: false,
+				},
+				ruleTest{
+					input: proxy.ContextWithSniffingResult(context.Background(), &proxy.SniffingResult{
+						Protocol:   "http",
+						HTTPMethod: "GET",
+						HTTPPath:   "/static/api/",
+						HTTPHeader: http.Header{"User-Agent": {"curl/7.54"}, "X-Token": {""}},
+					}),
+					output: false,
+				},
+				ruleTest{
+					input: proxy.ContextWithSniffingResult(context.Background(), &proxy.SniffingResult{
+						Protocol:   "http",
+						HTTPMethod: "GET",
+						HTTPPath:   "/api/v1",
+						HTTPHeader: http.Header{"User-Agent": {"curl/7.54"}},
+					}),
+					output: false,
+				},
+				ruleTest{
+					input:  proxy.ContextWithSniffingResult(context.Background(), &proxy.SniffingResult{Protocol: "tls"}),
+					output: false,
+				},
+			},
+		},
+		{
+			rule: &RoutingRule{
+				SourcePortRange: &net.PortRange{From: 1000, To: 2000},
+				LocalPortRange:  &net.PortRange{From: 1080, To: 1080},
+			},
+			test: []ruleTest{
+				ruleTest{
+					input:  proxy.ContextWithInboundEntryPoint(proxy.ContextWithSource(context.Background(), net.TCPDestination(net.LocalHostIP, 1500)), net.TCPDestination(net.AnyIP, 1080)),
+					output: true,
+				},
+				ruleTest{
+					input:  proxy.ContextWithInboundEntryPoint(proxy.ContextWithSource(context.Background(), net.TCPDestination(net.LocalHostIP, 2500)), net.TCPDestination(net.AnyIP, 1080)),
+					output: false,
+				},
+				ruleTest{
+					input:  proxy.ContextWithInboundEntryPoint(proxy.ContextWithSource(context.Background(), net.TCPDestination(net.LocalHostIP, 1500)), net.TCPDestination(net.AnyIP, 1081)),
+					output: false,
+				},
+				ruleTest{
+					input:  proxy.ContextWithTarget(context.Background(), net.TCPDestination(net.LocalHostIP, 1080)),
+					output: false,
+				},
+			},
+		},
+		{
+			rule: &RoutingRule{
+				Attributes: map[string]string{
+					"class": "bulk",
+					"vip":   "",
+				},
+			},
+			test: []ruleTest{
+				ruleTest{
+					input:  proxy.ContextWithAttributes(proxy.ContextWithAttributes(context.Background(), map[string]string{"class": "bulk"}), map[string]string{"vip": "yes"}),
+					output: true,
+				},
+				ruleTest{
+					input:  proxy.ContextWithAttributes(context.Background(), map[string]string{"class": "premium", "vip": "yes"}),
+					output: false,
+				},
+				ruleTest{
+					input:  proxy.ContextWithAttributes(context.Background(), map[string]string{"class": "bulk"}),
+					output: false,
+				},
+				ruleTest{
+					input:  context.Background(),
+					output: false,
+				},
+			},
+		},
 	}
 
 	for _, test := range cases {
@@ -179,3 +308,58 @@ func TestRoutingRule(t *testing.T) {
 		}
 	}
 }
+
+func TestTimeMatcher(t *testing.T) {
+	assert := assert.On(t)
+
+	matcher, err := NewTimeMatcher(&TimeCondition{
+		Timezone: "Asia/Shanghai",
+		Window: []*TimeWindow{
+			{
+				Weekday:   []Weekday{Weekday_Monday, Weekday_Tuesday, Weekday_Wednesday, Weekday_Thursday, Weekday_Friday},
+				StartHour: 9,
+				EndHour:   18,
+			},
+			{
+				Weekday:   []Weekday{Weekday_Friday},
+				StartHour: 22,
+				EndHour:   6,
+			},
+		},
+	})
+	assert.Error(err).IsNil()
+
+	location, err := time.LoadLocation("Asia/Shanghai")
+	assert.Error(err).IsNil()
+
+	cases := []struct {
+		time   time.Time
+		output bool
+	}{
+		// 2017-10-02 is a Monday.
+		{time.Date(2017, 10, 2, 9, 0, 0, 0, location), true},
+		{time.Date(2017, 10, 2, 17, 59, 0, 0, location), true},
+		{time.Date(2017, 10, 2, 18, 0, 0, 0, location), false},
+		{time.Date(2017, 10, 2, 8, 59, 0, 0, location), false},
+		{time.Date(2017, 10, 2, 1, 0, 0, 0, time.UTC), true},
+		{time.Date(2017, 10, 6, 23, 0, 0, 0, location), true},
+		{time.Date(2017, 10, 7, 5, 0, 0, 0, location), true},
+		{time.Date(2017, 10, 7, 6, 0, 0, 0, location), false},
+		{time.Date(2017, 10, 7, 12, 0, 0, 0, location), false},
+		{time.Date(2017, 10, 5, 23, 0, 0, 0, location), false},
+	}
+	for _, test := range cases {
+		assert.Bool(matcher.ApplyAt(test.time)).Equals(test.output)
+	}
+
+	_, err = NewTimeMatcher(&TimeCondition{
+		Window: []*TimeWindow{{StartHour: 24}},
+	})
+	assert.Error(err).IsNotNil()
+
+	_, err = NewTimeMatcher(&TimeCondition{
+		Timezone: "Mars/Olympus_Mons",
+		Window:   []*TimeWindow{{StartHour: 0, EndHour: 24}},
+	})
+	assert.Error(err).IsNotNil()
+}
@@ -9,7 +9,28 @@ import (
 
 type Rule struct {
 	Tag       string
+	Balancer  *Balancer
 	Condition Condition
+	// DomainStrategy is the effective domain strategy of this rule.
+	DomainStrategy Config_DomainStrategy
+	// hasIP is true if the rule has IP conditions on the target.
+	hasIP bool
+}
+
+// PickOutbound returns the tag of the outbound for connections matching this rule.
+func (r *Rule) PickOutbound() (string, error) {
+	if r.Balancer != nil {
+		return r.Balancer.PickOutbound()
+	}
+	return r.Tag, nil
+}
+
+// PeekOutbound is like PickOutbound, but it doesn't change the state of the balancer.
+func (r *Rule) PeekOutbound() (string, error) {
+	if r.Balancer != nil {
+		return r.Balancer.PeekOutbound()
+	}
+	return r.Tag, nil
 }
 
 func (r *Rule) Apply(ctx context.Context) bool {
@@ -49,28 +70,55 @@ func cidrToCondition(cidr []*CIDR, source bool) (Condition, error) {
 	}
 }
 
+// effectiveDomainStrategy returns the domain strategy of this rule, or the given default if the rule doesn't override it.
+func (rr *RoutingRule) effectiveDomainStrategy(defaultStrategy Config_DomainStrategy) (Config_DomainStrategy, error) {
+	switch rr.DomainStrategy {
+	case RoutingRule_UseDefault:
+		return defaultStrategy, nil
+	case RoutingRule_AsIs:
+		return Config_AsIs, nil
+	case RoutingRule_UseIp:
+		return Config_UseIp, nil
+	case RoutingRule_IpIfNonMatch:
+		return Config_IpIfNonMatch, nil
+	case RoutingRule_IpOnDemand:
+		return Config_IpOnDemand, nil
+	default:
+		return Config_AsIs, newError("unknown domain strategy: ", rr.DomainStrategy)
+	}
+}
+
+// needsSniffing returns true if the rule depends on the result of protocol sniffing.
+func (rr *RoutingRule) needsSniffing() bool {
+	return len(rr.Protocol) > 0 || rr.Http != nil
+}
+
+// needsHTTPHeaders returns true if the rule matches headers of HTTP requests.
+func (rr *RoutingRule) needsHTTPHeaders() bool {
+	return rr.Http != nil && len(rr.Http.Header) > 0
+}
+
+// BuildCondition builds the Condition of this rule.
 func (rr *RoutingRule) BuildCondition() (Condition, error) {
+	return rr.buildCondition(nil, 0)
+}
+
+// buildCondition builds the Condition of this rule. Domains of the rule are added into the given DomainMatcher
+// with the given value, or into a new DomainMatcher if it is nil.
+func (rr *RoutingRule) buildCondition(matcher *DomainMatcher, value uint32) (Condition, error) {
 	conds := NewConditionChan()
 
 	if len(rr.Domain) > 0 {
-		anyCond := NewAnyCondition()
+		if matcher == nil {
+			matcher = NewDomainMatcher()
+			value = 0
+		}
 		for _, domain := range rr.Domain {
-			switch domain.Type {
-			case Domain_Plain:
-				anyCond.Add(NewPlainDomainMatcher(domain.Value))
-			case Domain_Regex:
-				matcher, err := NewRegexpDomainMatcher(domain.Value)
-				if err != nil {
-					return nil, err
-				}
-				anyCond.Add(matcher)
-			case Domain_Domain:
-				anyCond.Add(NewSubDomainMatcher(domain.Value))
-			default:
-				panic("Unknown domain type.")
+			if err := matcher.Add(domain, value); err != nil {
+				return nil, err
 			}
 		}
-		conds.Add(anyCond)
+		conds.Add(NewDomainCondition(matcher, value))
 	}
 
 	if len(rr.Cidr) > 0 {
@@ -85,6 +133,14 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 		conds.Add(NewPortMatcher(*rr.PortRange))
 	}
 
+	if rr.SourcePortRange != nil {
+		conds.Add(NewSourcePortMatcher(*rr.SourcePortRange))
+	}
+
+	if rr.LocalPortRange != nil {
+		conds.Add(NewLocalPortMatcher(*rr.LocalPortRange))
+	}
+
 	if rr.NetworkList != nil {
 		conds.Add(NewNetworkMatcher(rr.NetworkList))
 	}
@@ -105,6 +161,26 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 		conds.Add(NewInboundTagMatcher(rr.InboundTag))
 	}
 
+	if len(rr.Attributes) > 0 {
+		conds.Add(NewAttributeMatcher(rr.Attributes))
+	}
+
+	if len(rr.Protocol) > 0 {
+		conds.Add(NewProtocolMatcher(rr.Protocol))
+	}
+
+	if rr.Http != nil {
+		conds.Add(NewHTTPMatcher(rr.Http))
+	}
+
+	if rr.Time != nil {
+		cond, err := NewTimeMatcher(rr.Time)
+		if err != nil {
+			return nil, err
+		}
+		conds.Add(cond)
+	}
+
 	if conds.Len() == 0 {
 		return nil, newError("this rule has no effective fields").AtError()
 	}
@@ -3,7 +3,8 @@ package router
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
-import v2ray_core_common_net "v2ray.com/core/common/net"
+import v2ray_core_app_dns "v2ray.com/core/app/dns"
+import v2ray_core_common_net3 "v2ray.com/core/common/net"
 import v2ray_core_common_net1 "v2ray.com/core/common/net"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -17,27 +18,72 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type Weekday int32
+
+const (
+	Weekday_Sunday    Weekday = 0
+	Weekday_Monday    Weekday = 1
+	Weekday_Tuesday   Weekday = 2
+	Weekday_Wednesday Weekday = 3
+	Weekday_Thursday  Weekday = 4
+	Weekday_Friday    Weekday = 5
+	Weekday_Saturday  Weekday = 6
+)
+
+var Weekday_name = map[int32]string{
+	0: "Sunday",
+	1: "Monday",
+	2: "Tuesday",
+	3: "Wednesday",
+	4: "Thursday",
+	5: "Friday",
+	6: "Saturday",
+}
+var Weekday_value = map[string]int32{
+	"Sunday":    0,
+	"Monday":    1,
+	"Tuesday":   2,
+	"Wednesday": 3,
+	"Thursday":  4,
+	"Friday":    5,
+	"Saturday":  6,
+}
+
+func (x Weekday) String() string {
+	return proto.EnumName(Weekday_name, int32(x))
+}
+func (Weekday) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
 // Type of domain value.
 type Domain_Type int32
 
 const (
-	// The value is used as is.
+	// The value is used as is. It matches any domain containing the value as a substring.
 	Domain_Plain Domain_Type = 0
 	// The value is used as a regular expression.
 	Domain_Regex Domain_Type = 1
-	// The value is a domain.
+	// The value is a domain. It matches the domain and all its sub-domains.
 	Domain_Domain Domain_Type = 2
+	// The value is a domain. It matches the exact domain only.
+	Domain_Full Domain_Type = 3
+	// The value is one or more domain labels, such as "google" or "google.co". It matches any domain
+	// containing the labels as a whole, e.g. "google" matches "www.google.co.uk" but not "googleapis.com".
+	Domain_Keyword Domain_Type = 4
 )
 
 var Domain_Type_name = map[int32]string{
 	0: "Plain",
 	1: "Regex",
 	2: "Domain",
+	3: "Full",
+	4: "Keyword",
 }
 var Domain_Type_value = map[string]int32{
-	"Plain":  0,
-	"Regex":  1,
-	"Domain": 2,
+	"Plain":   0,
+	"Regex":   1,
+	"Domain":  2,
+	"Full":    3,
+	"Keyword": 4,
 }
 
 func (x Domain_Type) String() string {
@@ -45,32 +91,101 @@ func (x Domain_Type) String() string {
 }
 func (Domain_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0, 0} }
 
+type RoutingRule_DomainStrategy int32
+
+const (
+	// Use the domain strategy of the router.
+	RoutingRule_UseDefault   RoutingRule_DomainStrategy = 0
+	RoutingRule_AsIs         RoutingRule_DomainStrategy = 1
+	RoutingRule_UseIp        RoutingRule_DomainStrategy = 2
+	RoutingRule_IpIfNonMatch RoutingRule_DomainStrategy = 3
+	RoutingRule_IpOnDemand   RoutingRule_DomainStrategy = 4
+)
+
+var RoutingRule_DomainStrategy_name = map[int32]string{
+	0: "UseDefault",
+	1: "AsIs",
+	2: "UseIp",
+	3: "IpIfNonMatch",
+	4: "IpOnDemand",
+}
+var RoutingRule_DomainStrategy_value = map[string]int32{
+	"UseDefault":   0,
+	"AsIs":         1,
+	"UseIp":        2,
+	"IpIfNonMatch": 3,
+	"IpOnDemand":   4,
+}
+
+func (x RoutingRule_DomainStrategy) String() string {
+	return proto.EnumName(RoutingRule_DomainStrategy_name, int32(x))
+}
+func (RoutingRule_DomainStrategy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{6, 0}
+}
+
+type BalancingRule_Strategy int32
+
+const (
+	// Pick a random outbound.
+	BalancingRule_Random BalancingRule_Strategy = 0
+	// Pick outbounds in turn.
+	BalancingRule_RoundRobin BalancingRule_Strategy = 1
+	// Pick the outbound with the least active connections.
+	BalancingRule_LeastActive BalancingRule_Strategy = 2
+	// Pick the outbound with the lowest round trip time in health probing. Outbounds that are not probed yet are
+	// skipped, unless no outbound is probed.
+	BalancingRule_LowestLatency BalancingRule_Strategy = 3
+)
+
+var BalancingRule_Strategy_name = map[int32]string{
+	0: "Random",
+	1: "RoundRobin",
+	2: "LeastActive",
+	3: "LowestLatency",
+}
+var BalancingRule_Strategy_value = map[string]int32{
+	"Random":        0,
+	"RoundRobin":    1,
+	"LeastActive":   2,
+	"LowestLatency": 3,
+}
+
+func (x BalancingRule_Strategy) String() string {
+	return proto.EnumName(BalancingRule_Strategy_name, int32(x))
+}
+func (BalancingRule_Strategy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{11, 0} }
+
 type Config_DomainStrategy int32
 
 const (
 	// Use domain as is.
 	Config_AsIs Config_DomainStrategy = 0
-	// Always resolve IP for domains.
+	// Always resolve IP for domains, before matching rules.
 	Config_UseIp Config_DomainStrategy = 1
 	// Resolve to IP if the domain doesn't match any rules.
 	Config_IpIfNonMatch Config_DomainStrategy = 2
+	// Resolve IP for domains only when matching rules that have IP conditions.
+	Config_IpOnDemand Config_DomainStrategy = 3
 )
 
 var Config_DomainStrategy_name = map[int32]string{
 	0: "AsIs",
 	1: "UseIp",
 	2: "IpIfNonMatch",
+	3: "IpOnDemand",
 }
 var Config_DomainStrategy_value = map[string]int32{
 	"AsIs":         0,
 	"UseIp":        1,
 	"IpIfNonMatch": 2,
+	"IpOnDemand":   3,
 }
 
 func (x Config_DomainStrategy) String() string {
 	return proto.EnumName(Config_DomainStrategy_name, int32(x))
 }
-func (Config_DomainStrategy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3, 0} }
+func (Config_DomainStrategy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{12, 0} }
 
 // Domain for routing decision.
 type Domain struct {
@@ -126,21 +241,172 @@ func (m *CIDR) GetPrefix() uint32 {
 	return 0
 }
 
+// A named list of IP ranges, usually the ones of a country.
+type GeoIP struct {
+	// Country code or name of the list, case insensitive. For example "CN" or "private".
+	CountryCode string  `protobuf:"bytes,1,opt,name=country_code,json=countryCode" json:"country_code,omitempty"`
+	Cidr        []*CIDR `protobuf:"bytes,2,rep,name=cidr" json:"cidr,omitempty"`
+}
+
+func (m *GeoIP) Reset()                    { *m = GeoIP{} }
+func (m *GeoIP) String() string            { return proto.CompactTextString(m) }
+func (*GeoIP) ProtoMessage()               {}
+func (*GeoIP) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *GeoIP) GetCountryCode() string {
+	if m != nil {
+		return m.CountryCode
+	}
+	return ""
+}
+
+func (m *GeoIP) GetCidr() []*CIDR {
+	if m != nil {
+		return m.Cidr
+	}
+	return nil
+}
+
+// Content of an external GeoIP file. By default the file is "geoip.dat" in the asset location.
+type GeoIPList struct {
+	// Version of the file format. Must be 1.
+	Version uint32 `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	// Revision of the data, for information only.
+	Revision string   `protobuf:"bytes,2,opt,name=revision" json:"revision,omitempty"`
+	Entry    []*GeoIP `protobuf:"bytes,3,rep,name=entry" json:"entry,omitempty"`
+}
+
+func (m *GeoIPList) Reset()                    { *m = GeoIPList{} }
+func (m *GeoIPList) String() string            { return proto.CompactTextString(m) }
+func (*GeoIPList) ProtoMessage()               {}
+func (*GeoIPList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *GeoIPList) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GeoIPList) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+func (m *GeoIPList) GetEntry() []*GeoIP {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+// A named category of domains.
+type GeoSite struct {
+	// Name of the category, case insensitive. For example "CN" or "ads".
+	CountryCode string    `protobuf:"bytes,1,opt,name=country_code,json=countryCode" json:"country_code,omitempty"`
+	Domain      []*Domain `protobuf:"bytes,2,rep,name=domain" json:"domain,omitempty"`
+}
+
+func (m *GeoSite) Reset()                    { *m = GeoSite{} }
+func (m *GeoSite) String() string            { return proto.CompactTextString(m) }
+func (*GeoSite) ProtoMessage()               {}
+func (*GeoSite) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *GeoSite) GetCountryCode() string {
+	if m != nil {
+		return m.CountryCode
+	}
+	return ""
+}
+
+func (m *GeoSite) GetDomain() []*Domain {
+	if m != nil {
+		return m.Domain
+	}
+	return nil
+}
+
+// Content of an external domain list file. By default the file is "geosite.dat" in the asset location.
+type GeoSiteList struct {
+	// Version of the file format. Must be 1.
+	Version uint32 `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	// Revision of the data, for information only.
+	Revision string     `protobuf:"bytes,2,opt,name=revision" json:"revision,omitempty"`
+	Entry    []*GeoSite `protobuf:"bytes,3,rep,name=entry" json:"entry,omitempty"`
+}
+
+func (m *GeoSiteList) Reset()                    { *m = GeoSiteList{} }
+func (m *GeoSiteList) String() string            { return proto.CompactTextString(m) }
+func (*GeoSiteList) ProtoMessage()               {}
+func (*GeoSiteList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *GeoSiteList) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GeoSiteList) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+func (m *GeoSiteList) GetEntry() []*GeoSite {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
 type RoutingRule struct {
 	Tag         string                              `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
 	Domain      []*Domain                           `protobuf:"bytes,2,rep,name=domain" json:"domain,omitempty"`
 	Cidr        []*CIDR                             `protobuf:"bytes,3,rep,name=cidr" json:"cidr,omitempty"`
-	PortRange   *v2ray_core_common_net.PortRange    `protobuf:"bytes,4,opt,name=port_range,json=portRange" json:"port_range,omitempty"`
+	PortRange   *v2ray_core_common_net3.PortRange   `protobuf:"bytes,4,opt,name=port_range,json=portRange" json:"port_range,omitempty"`
 	NetworkList *v2ray_core_common_net1.NetworkList `protobuf:"bytes,5,opt,name=network_list,json=networkList" json:"network_list,omitempty"`
 	SourceCidr  []*CIDR                             `protobuf:"bytes,6,rep,name=source_cidr,json=sourceCidr" json:"source_cidr,omitempty"`
 	UserEmail   []string                            `protobuf:"bytes,7,rep,name=user_email,json=userEmail" json:"user_email,omitempty"`
 	InboundTag  []string                            `protobuf:"bytes,8,rep,name=inbound_tag,json=inboundTag" json:"inbound_tag,omitempty"`
+	// Names of GeoIP entries. The IP ranges are matched along with the ones in cidr.
+	Geoip []string `protobuf:"bytes,9,rep,name=geoip" json:"geoip,omitempty"`
+	// Names of GeoSite entries. The domains are matched along with the ones in domain.
+	Geosite []string `protobuf:"bytes,10,rep,name=geosite" json:"geosite,omitempty"`
+	// Tag of the balancer to pick outbound from. If set, tag is ignored.
+	BalancingTag string `protobuf:"bytes,11,opt,name=balancing_tag,json=balancingTag" json:"balancing_tag,omitempty"`
+	// Paths of plain-text files of domains. Each line is a domain in the form of "type:value", where type is one of
+	// "domain", "full", "keyword", "regexp" and "plain", or a domain without type, which is the same as
+	// "domain:value". Text after "#" is ignored. The domains are matched along with the ones in domain.
+	DomainFile []string `protobuf:"bytes,15,rep,name=domain_file,json=domainFile" json:"domain_file,omitempty"`
+	// Paths of plain-text files of IP ranges. Each line is an IP or CIDR. Text after "#" is ignored. The IP ranges
+	// are matched along with the ones in cidr.
+	CidrFile []string `protobuf:"bytes,16,rep,name=cidr_file,json=cidrFile" json:"cidr_file,omitempty"`
+	// Port of the source address of the connection.
+	SourcePortRange *v2ray_core_common_net3.PortRange `protobuf:"bytes,17,opt,name=source_port_range,json=sourcePortRange" json:"source_port_range,omitempty"`
+	// Port that the inbound receives the connection on.
+	LocalPortRange *v2ray_core_common_net3.PortRange `protobuf:"bytes,18,opt,name=local_port_range,json=localPortRange" json:"local_port_range,omitempty"`
+	// Attributes attached to the connection by the inbound. All of them must match. An empty value matches any
+	// connection having the attribute.
+	Attributes map[string]string `protobuf:"bytes,19,rep,name=attributes" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Domain strategy of this rule, overriding the one of the router. See Config.DomainStrategy.
+	DomainStrategy RoutingRule_DomainStrategy `protobuf:"varint,20,opt,name=domain_strategy,json=domainStrategy,enum=v2ray.core.app.router.RoutingRule_DomainStrategy" json:"domain_strategy,omitempty"`
+	// Sniffed protocols of the connection, any of "http", "tls", "bittorrent" and "unknown".
+	// Connections are sniffed for routing when any rule has this field or http set.
+	Protocol []string `protobuf:"bytes,12,rep,name=protocol" json:"protocol,omitempty"`
+	// Attributes of plain HTTP requests. Only matches connections sniffed as "http".
+	Http *HTTPCondition `protobuf:"bytes,13,opt,name=http" json:"http,omitempty"`
+	// Local time windows in which the rule is effective.
+	Time *TimeCondition `protobuf:"bytes,14,opt,name=time" json:"time,omitempty"`
 }
 
 func (m *RoutingRule) Reset()                    { *m = RoutingRule{} }
 func (m *RoutingRule) String() string            { return proto.CompactTextString(m) }
 func (*RoutingRule) ProtoMessage()               {}
-func (*RoutingRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*RoutingRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *RoutingRule) GetTag() string {
 	if m != nil {
@@ -163,7 +429,7 @@ func (m *RoutingRule) GetCidr() []*CIDR {
 	return nil
 }
 
-func (m *RoutingRule) GetPortRange() *v2ray_core_common_net.PortRange {
+func (m *RoutingRule) GetPortRange() *v2ray_core_common_net3.PortRange {
 	if m != nil {
 		return m.PortRange
 	}
@@ -198,15 +464,265 @@ func (m *RoutingRule) GetInboundTag() []string {
 	return nil
 }
 
+func (m *RoutingRule) GetGeoip() []string {
+	if m != nil {
+		return m.Geoip
+	}
+	return nil
+}
+
+func (m *RoutingRule) GetGeosite() []string {
+	if m != nil {
+		return m.Geosite
+	}
+	return nil
+}
+
+func (m *RoutingRule) GetBalancingTag() string {
+	if m != nil {
+		return m.BalancingTag
+	}
+	return ""
+}
+
+func (m *RoutingRule) GetDomainFile() []string {
+	if m != nil {
+		return m.DomainFile
+	}
+	return nil
+}
+
+func (m *RoutingRule) GetCidrFile() []string {
+	if m != nil {
+		return m.CidrFile
+	}
+	return nil
+}
+
+func (m *RoutingRule) GetSourcePortRange() *v2ray_core_common_net3.PortRange {
+	if m != nil {
+		return m.SourcePortRange
+	}
+	return nil
+}
+
+func (m *RoutingRule) GetLocalPortRange() *v2ray_core_common_net3.PortRange {
+	if m != nil {
+		return m.LocalPortRange
+	}
+	return nil
+}
+
+func (m *RoutingRule) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *RoutingRule) GetDomainStrategy() RoutingRule_DomainStrategy {
+	if m != nil {
+		return m.DomainStrategy
+	}
+	return RoutingRule_UseDefault
+}
+
+func (m *RoutingRule) GetProtocol() []string {
+	if m != nil {
+		return m.Protocol
+	}
+	return nil
+}
+
+func (m *RoutingRule) GetHttp() *HTTPCondition {
+	if m != nil {
+		return m.Http
+	}
+	return nil
+}
+
+func (m *RoutingRule) GetTime() *TimeCondition {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+type TimeWindow struct {
+	// Days of week on which the window begins. Empty means every day.
+	Weekday []Weekday `protobuf:"varint,1,rep,packed,name=weekday,enum=v2ray.core.app.router.Weekday" json:"weekday,omitempty"`
+	// The window begins at start_hour and ends before end_hour, both in [0, 24]. If end_hour is not greater than
+	// start_hour, the window ends on the next day.
+	StartHour uint32 `protobuf:"varint,2,opt,name=start_hour,json=startHour" json:"start_hour,omitempty"`
+	EndHour   uint32 `protobuf:"varint,3,opt,name=end_hour,json=endHour" json:"end_hour,omitempty"`
+}
+
+func (m *TimeWindow) Reset()                    { *m = TimeWindow{} }
+func (m *TimeWindow) String() string            { return proto.CompactTextString(m) }
+func (*TimeWindow) ProtoMessage()               {}
+func (*TimeWindow) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *TimeWindow) GetWeekday() []Weekday {
+	if m != nil {
+		return m.Weekday
+	}
+	return nil
+}
+
+func (m *TimeWindow) GetStartHour() uint32 {
+	if m != nil {
+		return m.StartHour
+	}
+	return 0
+}
+
+func (m *TimeWindow) GetEndHour() uint32 {
+	if m != nil {
+		return m.EndHour
+	}
+	return 0
+}
+
+type TimeCondition struct {
+	// IANA time zone name, such as "Asia/Shanghai". Empty means the local time zone of the system.
+	Timezone string `protobuf:"bytes,1,opt,name=timezone" json:"timezone,omitempty"`
+	// Matches if the current time is in any of the windows.
+	Window []*TimeWindow `protobuf:"bytes,2,rep,name=window" json:"window,omitempty"`
+}
+
+func (m *TimeCondition) Reset()                    { *m = TimeCondition{} }
+func (m *TimeCondition) String() string            { return proto.CompactTextString(m) }
+func (*TimeCondition) ProtoMessage()               {}
+func (*TimeCondition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *TimeCondition) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *TimeCondition) GetWindow() []*TimeWindow {
+	if m != nil {
+		return m.Window
+	}
+	return nil
+}
+
+type HTTPHeader struct {
+	// Name of the header, case-insensitive.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Case-insensitive substring of any value of the header. Empty matches any request having the header.
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *HTTPHeader) Reset()                    { *m = HTTPHeader{} }
+func (m *HTTPHeader) String() string            { return proto.CompactTextString(m) }
+func (*HTTPHeader) ProtoMessage()               {}
+func (*HTTPHeader) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *HTTPHeader) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HTTPHeader) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type HTTPCondition struct {
+	// Request methods, case-insensitive. Empty matches any method.
+	Method []string `protobuf:"bytes,1,rep,name=method" json:"method,omitempty"`
+	// Prefixes of the request path. Empty matches any path.
+	PathPrefix []string `protobuf:"bytes,2,rep,name=path_prefix,json=pathPrefix" json:"path_prefix,omitempty"`
+	// All the headers must match.
+	Header []*HTTPHeader `protobuf:"bytes,3,rep,name=header" json:"header,omitempty"`
+}
+
+func (m *HTTPCondition) Reset()                    { *m = HTTPCondition{} }
+func (m *HTTPCondition) String() string            { return proto.CompactTextString(m) }
+func (*HTTPCondition) ProtoMessage()               {}
+func (*HTTPCondition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *HTTPCondition) GetMethod() []string {
+	if m != nil {
+		return m.Method
+	}
+	return nil
+}
+
+func (m *HTTPCondition) GetPathPrefix() []string {
+	if m != nil {
+		return m.PathPrefix
+	}
+	return nil
+}
+
+func (m *HTTPCondition) GetHeader() []*HTTPHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type BalancingRule struct {
+	// Tag of this balancer, referenced by RoutingRule.balancing_tag.
+	Tag string `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
+	// Outbound handlers whose tag begins with any of the selectors are candidates.
+	OutboundSelector []string               `protobuf:"bytes,2,rep,name=outbound_selector,json=outboundSelector" json:"outbound_selector,omitempty"`
+	Strategy         BalancingRule_Strategy `protobuf:"varint,3,opt,name=strategy,enum=v2ray.core.app.router.BalancingRule_Strategy" json:"strategy,omitempty"`
+}
+
+func (m *BalancingRule) Reset()                    { *m = BalancingRule{} }
+func (m *BalancingRule) String() string            { return proto.CompactTextString(m) }
+func (*BalancingRule) ProtoMessage()               {}
+func (*BalancingRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *BalancingRule) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *BalancingRule) GetOutboundSelector() []string {
+	if m != nil {
+		return m.OutboundSelector
+	}
+	return nil
+}
+
+func (m *BalancingRule) GetStrategy() BalancingRule_Strategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return BalancingRule_Random
+}
+
 type Config struct {
 	DomainStrategy Config_DomainStrategy `protobuf:"varint,1,opt,name=domain_strategy,json=domainStrategy,enum=v2ray.core.app.router.Config_DomainStrategy" json:"domain_strategy,omitempty"`
 	Rule           []*RoutingRule        `protobuf:"bytes,2,rep,name=rule" json:"rule,omitempty"`
+	// Path of the GeoIP file. Default to "geoip.dat" in the asset location.
+	GeoipFile string `protobuf:"bytes,3,opt,name=geoip_file,json=geoipFile" json:"geoip_file,omitempty"`
+	// Path of the domain list file. Default to "geosite.dat" in the asset location.
+	GeositeFile   string           `protobuf:"bytes,4,opt,name=geosite_file,json=geositeFile" json:"geosite_file,omitempty"`
+	BalancingRule []*BalancingRule `protobuf:"bytes,5,rep,name=balancing_rule,json=balancingRule" json:"balancing_rule,omitempty"`
+	// Interval in seconds of checking changes of domain and CIDR files of rules. Rules are rebuilt when any of the
+	// files is changed. Default to 10.
+	ListCheckInterval uint32 `protobuf:"varint,6,opt,name=list_check_interval,json=listCheckInterval" json:"list_check_interval,omitempty"`
+	// Address families of IPs resolved for matching IP rules.
+	IpPreference v2ray_core_app_dns.IPPreference `protobuf:"varint,7,opt,name=ip_preference,json=ipPreference,enum=v2ray.core.app.dns.IPPreference" json:"ip_preference,omitempty"`
 }
 
 func (m *Config) Reset()                    { *m = Config{} }
 func (m *Config) String() string            { return proto.CompactTextString(m) }
 func (*Config) ProtoMessage()               {}
-func (*Config) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*Config) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *Config) GetDomainStrategy() Config_DomainStrategy {
 	if m != nil {
@@ -222,51 +738,150 @@ func (m *Config) GetRule() []*RoutingRule {
 	return nil
 }
 
+func (m *Config) GetGeoipFile() string {
+	if m != nil {
+		return m.GeoipFile
+	}
+	return ""
+}
+
+func (m *Config) GetGeositeFile() string {
+	if m != nil {
+		return m.GeositeFile
+	}
+	return ""
+}
+
+func (m *Config) GetBalancingRule() []*BalancingRule {
+	if m != nil {
+		return m.BalancingRule
+	}
+	return nil
+}
+
+func (m *Config) GetListCheckInterval() uint32 {
+	if m != nil {
+		return m.ListCheckInterval
+	}
+	return 0
+}
+
+func (m *Config) GetIpPreference() v2ray_core_app_dns.IPPreference {
+	if m != nil {
+		return m.IpPreference
+	}
+	return v2ray_core_app_dns.IPPreference_PreferIPv4
+}
+
 func init() {
 	proto.RegisterType((*Domain)(nil), "v2ray.core.app.router.Domain")
 	proto.RegisterType((*CIDR)(nil), "v2ray.core.app.router.CIDR")
+	proto.RegisterType((*GeoIP)(nil), "v2ray.core.app.router.GeoIP")
+	proto.RegisterType((*GeoIPList)(nil), "v2ray.core.app.router.GeoIPList")
+	proto.RegisterType((*GeoSite)(nil), "v2ray.core.app.router.GeoSite")
+	proto.RegisterType((*GeoSiteList)(nil), "v2ray.core.app.router.GeoSiteList")
 	proto.RegisterType((*RoutingRule)(nil), "v2ray.core.app.router.RoutingRule")
+	proto.RegisterType((*TimeWindow)(nil), "v2ray.core.app.router.TimeWindow")
+	proto.RegisterType((*TimeCondition)(nil), "v2ray.core.app.router.TimeCondition")
+	proto.RegisterType((*HTTPHeader)(nil), "v2ray.core.app.router.HTTPHeader")
+	proto.RegisterType((*HTTPCondition)(nil), "v2ray.core.app.router.HTTPCondition")
+	proto.RegisterType((*BalancingRule)(nil), "v2ray.core.app.router.BalancingRule")
 	proto.RegisterType((*Config)(nil), "v2ray.core.app.router.Config")
+	proto.RegisterEnum("v2ray.core.app.router.Weekday", Weekday_name, Weekday_value)
 	proto.RegisterEnum("v2ray.core.app.router.Domain_Type", Domain_Type_name, Domain_Type_value)
+	proto.RegisterEnum("v2ray.core.app.router.RoutingRule_DomainStrategy", RoutingRule_DomainStrategy_name, RoutingRule_DomainStrategy_value)
+	proto.RegisterEnum("v2ray.core.app.router.BalancingRule_Strategy", BalancingRule_Strategy_name, BalancingRule_Strategy_value)
 	proto.RegisterEnum("v2ray.core.app.router.Config_DomainStrategy", Config_DomainStrategy_name, Config_DomainStrategy_value)
 }
 
 func init() { proto.RegisterFile("v2ray.com/core/app/router/config.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 538 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xc1, 0x6e, 0xd4, 0x30,
-	0x10, 0x86, 0x49, 0x76, 0x1b, 0xba, 0x93, 0xb2, 0x44, 0x16, 0x45, 0xa1, 0xa8, 0x22, 0x8a, 0x10,
-	0xe4, 0x80, 0x12, 0x69, 0x11, 0x70, 0x01, 0xa1, 0xb2, 0xed, 0x61, 0x25, 0xa8, 0x2a, 0xd3, 0x72,
-	0xe0, 0x12, 0xb9, 0x59, 0x37, 0x58, 0x24, 0xb6, 0xe5, 0x38, 0xa5, 0x7b, 0xe3, 0x05, 0x78, 0x11,
-	0x9e, 0x86, 0x47, 0x42, 0xb6, 0x53, 0xd1, 0xa2, 0x2e, 0xdc, 0x66, 0x9c, 0xef, 0x9f, 0x19, 0x8f,
-	0xff, 0xc0, 0x93, 0xf3, 0x99, 0x22, 0xab, 0xbc, 0x12, 0x6d, 0x51, 0x09, 0x45, 0x0b, 0x22, 0x65,
-	0xa1, 0x44, 0xaf, 0xa9, 0x2a, 0x2a, 0xc1, 0xcf, 0x58, 0x9d, 0x4b, 0x25, 0xb4, 0x40, 0xdb, 0x97,
-	0x9c, 0xa2, 0x39, 0x91, 0x32, 0x77, 0xcc, 0xce, 0xe3, 0xbf, 0xe4, 0x95, 0x68, 0x5b, 0xc1, 0x0b,
-	0x4e, 0x75, 0x21, 0x85, 0xd2, 0x4e, 0xbc, 0xf3, 0x74, 0x3d, 0xc5, 0xa9, 0xfe, 0x26, 0xd4, 0x57,
-	0x07, 0xa6, 0xdf, 0x3d, 0x08, 0xf6, 0x45, 0x4b, 0x18, 0x47, 0x2f, 0x61, 0xac, 0x57, 0x92, 0xc6,
-	0x5e, 0xe2, 0x65, 0xd3, 0x59, 0x9a, 0xdf, 0xd8, 0x3f, 0x77, 0x70, 0x7e, 0xbc, 0x92, 0x14, 0x5b,
-	0x1e, 0xdd, 0x83, 0x8d, 0x73, 0xd2, 0xf4, 0x34, 0xf6, 0x13, 0x2f, 0x9b, 0x60, 0x97, 0xa4, 0x19,
-	0x8c, 0x0d, 0x83, 0x26, 0xb0, 0x71, 0xd4, 0x10, 0xc6, 0xa3, 0x5b, 0x26, 0xc4, 0xb4, 0xa6, 0x17,
-	0x91, 0x87, 0xe0, 0xb2, 0x6b, 0xe4, 0xa7, 0x39, 0x8c, 0xe7, 0x8b, 0x7d, 0x8c, 0xa6, 0xe0, 0x33,
-	0x69, 0xbb, 0x6f, 0x61, 0x9f, 0x49, 0x74, 0x1f, 0x02, 0xa9, 0xe8, 0x19, 0xbb, 0xb0, 0x85, 0xef,
-	0xe0, 0x21, 0x4b, 0x7f, 0x8c, 0x20, 0xc4, 0xa2, 0xd7, 0x8c, 0xd7, 0xb8, 0x6f, 0x28, 0x8a, 0x60,
-	0xa4, 0x49, 0x6d, 0x85, 0x13, 0x6c, 0x42, 0xf4, 0x02, 0x82, 0xa5, 0xad, 0x1e, 0xfb, 0xc9, 0x28,
-	0x0b, 0x67, 0xbb, 0xff, 0xbc, 0x0b, 0x1e, 0x60, 0x54, 0xc0, 0xb8, 0x62, 0x4b, 0x15, 0x8f, 0xac,
-	0xe8, 0xe1, 0x1a, 0x91, 0x99, 0x15, 0x5b, 0x10, 0xbd, 0x05, 0x30, 0x3b, 0x2f, 0x15, 0xe1, 0x35,
-	0x8d, 0xc7, 0x89, 0x97, 0x85, 0xb3, 0xe4, 0xaa, 0xcc, 0xad, 0x3d, 0xe7, 0x54, 0xe7, 0x47, 0x42,
-	0x69, 0x6c, 0x38, 0x3c, 0x91, 0x97, 0x21, 0x3a, 0x80, 0xad, 0xe1, 0x39, 0xca, 0x86, 0x75, 0x3a,
-	0xde, 0xb0, 0x25, 0xd2, 0x35, 0x25, 0x0e, 0x1d, 0xfa, 0x9e, 0x75, 0x1a, 0x87, 0xfc, 0x4f, 0x82,
-	0x5e, 0x43, 0xd8, 0x89, 0x5e, 0x55, 0xb4, 0xb4, 0xf3, 0x07, 0xff, 0x9f, 0x1f, 0x1c, 0x3f, 0x37,
-	0xb7, 0xd8, 0x05, 0xe8, 0x3b, 0xaa, 0x4a, 0xda, 0x12, 0xd6, 0xc4, 0xb7, 0x93, 0x51, 0x36, 0xc1,
-	0x13, 0x73, 0x72, 0x60, 0x0e, 0xd0, 0x23, 0x08, 0x19, 0x3f, 0x15, 0x3d, 0x5f, 0x96, 0x66, 0xcd,
-	0x9b, 0xf6, 0x3b, 0x0c, 0x47, 0xc7, 0xa4, 0x4e, 0x7f, 0x79, 0x10, 0xcc, 0xad, 0x73, 0xd1, 0x09,
-	0xdc, 0x75, 0xbb, 0x2c, 0x3b, 0xad, 0x88, 0xa6, 0xf5, 0x6a, 0x70, 0xd3, 0xb3, 0x75, 0xc3, 0x38,
-	0xc7, 0xbb, 0x87, 0xf8, 0x38, 0x68, 0xf0, 0x74, 0x79, 0x2d, 0x37, 0xce, 0x54, 0x7d, 0x43, 0x87,
-	0xd7, 0x5c, 0xe7, 0xcc, 0x2b, 0x9e, 0xc0, 0x96, 0x4f, 0x5f, 0xc1, 0xf4, 0x7a, 0x65, 0xb4, 0x09,
-	0xe3, 0xbd, 0x6e, 0xd1, 0x39, 0x33, 0x9e, 0x74, 0x74, 0x21, 0x23, 0x0f, 0x45, 0xb0, 0xb5, 0x90,
-	0x8b, 0xb3, 0x43, 0xc1, 0x3f, 0x10, 0x5d, 0x7d, 0x89, 0xfc, 0x77, 0x6f, 0xe0, 0x41, 0x25, 0xda,
-	0x9b, 0xfb, 0x1c, 0x79, 0x9f, 0x03, 0x17, 0xfd, 0xf4, 0xb7, 0x3f, 0xcd, 0x30, 0x59, 0xe5, 0x73,
-	0x43, 0xec, 0x49, 0x69, 0x47, 0xa0, 0xea, 0x34, 0xb0, 0xff, 0xd6, 0xf3, 0xdf, 0x01, 0x00, 0x00,
-	0xff, 0xff, 0xa7, 0x6a, 0x97, 0x93, 0xeb, 0x03, 0x00, 0x00,
+	// 1376 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xef, 0x6e, 0x1b, 0x37,
+	0x12, 0xb7, 0xb4, 0xb2, 0x2c, 0x8d, 0xfe, 0x78, 0xcd, 0x24, 0x87, 0x8d, 0x73, 0xb9, 0x28, 0x9b,
+	0xe0, 0xce, 0xb8, 0xbb, 0xca, 0xa8, 0xdb, 0x06, 0x69, 0xd1, 0x20, 0x70, 0x6c, 0x27, 0x51, 0xe2,
+	0xa4, 0x02, 0xed, 0xd4, 0x40, 0xfa, 0x41, 0xa0, 0x77, 0xc7, 0x12, 0xe1, 0x15, 0xb9, 0xe0, 0x72,
+	0xed, 0xa8, 0x9f, 0x0a, 0xf4, 0x0d, 0xfa, 0xa5, 0xef, 0xd0, 0xf7, 0x6a, 0x9f, 0xa3, 0x20, 0x77,
+	0x57, 0xb6, 0x1c, 0xcb, 0x31, 0xda, 0x6f, 0x9c, 0x99, 0xdf, 0x70, 0xfe, 0x71, 0x66, 0x08, 0xff,
+	0x3e, 0xd9, 0x50, 0x6c, 0xd2, 0x0d, 0xe4, 0x78, 0x3d, 0x90, 0x0a, 0xd7, 0x59, 0x1c, 0xaf, 0x2b,
+	0x99, 0x6a, 0x54, 0xeb, 0x81, 0x14, 0x47, 0x7c, 0xd8, 0x8d, 0x95, 0xd4, 0x92, 0xdc, 0x2a, 0x70,
+	0x0a, 0xbb, 0x2c, 0x8e, 0xbb, 0x19, 0x66, 0xf5, 0xc1, 0x25, 0xea, 0xa1, 0x48, 0x66, 0x74, 0x57,
+	0x1f, 0x5e, 0x00, 0x05, 0x72, 0x3c, 0x96, 0x62, 0x5d, 0xa0, 0x5e, 0x8f, 0xa5, 0xd2, 0x39, 0xea,
+	0x3f, 0xf3, 0x51, 0x02, 0xf5, 0xa9, 0x54, 0xc7, 0x19, 0xd0, 0xff, 0xb5, 0x04, 0xd5, 0x6d, 0x39,
+	0x66, 0x5c, 0x90, 0x47, 0x50, 0xd1, 0x93, 0x18, 0xbd, 0x52, 0xa7, 0xb4, 0xd6, 0xde, 0xf0, 0xbb,
+	0x97, 0x3a, 0xd9, 0xcd, 0xc0, 0xdd, 0xfd, 0x49, 0x8c, 0xd4, 0xe2, 0xc9, 0x4d, 0x58, 0x3c, 0x61,
+	0x51, 0x8a, 0x5e, 0xb9, 0x53, 0x5a, 0xab, 0xd3, 0x8c, 0xf0, 0x9f, 0x42, 0xc5, 0x60, 0x48, 0x1d,
+	0x16, 0xfb, 0x11, 0xe3, 0xc2, 0x5d, 0x30, 0x47, 0x8a, 0x43, 0xfc, 0xe0, 0x96, 0x08, 0x14, 0x56,
+	0xdd, 0x32, 0xa9, 0x41, 0xe5, 0x79, 0x1a, 0x45, 0xae, 0x43, 0x1a, 0xb0, 0xf4, 0x1a, 0x27, 0xa7,
+	0x52, 0x85, 0x6e, 0xc5, 0xef, 0x42, 0x65, 0xab, 0xb7, 0x4d, 0x49, 0x1b, 0xca, 0x3c, 0xb6, 0x4e,
+	0x35, 0x69, 0x99, 0xc7, 0xe4, 0x1f, 0x50, 0x8d, 0x15, 0x1e, 0xf1, 0x0f, 0xd6, 0x5e, 0x8b, 0xe6,
+	0x94, 0xff, 0x03, 0x2c, 0xbe, 0x40, 0xd9, 0xeb, 0x93, 0xfb, 0xd0, 0x0c, 0x64, 0x2a, 0xb4, 0x9a,
+	0x0c, 0x02, 0x19, 0x66, 0xf1, 0xd4, 0x69, 0x23, 0xe7, 0x6d, 0xc9, 0x10, 0xc9, 0x3a, 0x54, 0x02,
+	0x1e, 0x2a, 0xaf, 0xdc, 0x71, 0xd6, 0x1a, 0x1b, 0x77, 0xe6, 0x84, 0x6a, 0xcc, 0x53, 0x0b, 0xf4,
+	0x53, 0xa8, 0xdb, 0xcb, 0x77, 0x79, 0xa2, 0x89, 0x07, 0x4b, 0x27, 0xa8, 0x12, 0x2e, 0x85, 0xbd,
+	0xbb, 0x45, 0x0b, 0x92, 0xac, 0x42, 0x4d, 0xe1, 0x09, 0xb7, 0xa2, 0x2c, 0x1b, 0x53, 0x9a, 0x6c,
+	0xc0, 0x22, 0x1a, 0x07, 0x3c, 0xc7, 0x1a, 0xfd, 0xe7, 0x1c, 0xa3, 0xd6, 0x0c, 0xcd, 0xa0, 0x7e,
+	0x00, 0x4b, 0x2f, 0x50, 0xee, 0x71, 0x8d, 0xd7, 0x89, 0xea, 0x2b, 0xa8, 0x86, 0x36, 0xa9, 0x79,
+	0x5c, 0x77, 0xaf, 0x2c, 0x21, 0xcd, 0xc1, 0xfe, 0x04, 0x1a, 0xb9, 0x91, 0xbf, 0x11, 0xdd, 0x97,
+	0xb3, 0xd1, 0xfd, 0x6b, 0x7e, 0x74, 0xc6, 0x50, 0x11, 0xdf, 0x2f, 0x75, 0x68, 0x50, 0x99, 0x6a,
+	0x2e, 0x86, 0x34, 0x8d, 0x90, 0xb8, 0xe0, 0x68, 0x36, 0xcc, 0x63, 0x33, 0xc7, 0xbf, 0x18, 0xd3,
+	0xb4, 0xc0, 0xce, 0x35, 0x0b, 0x4c, 0x9e, 0x02, 0x98, 0xf6, 0x19, 0x28, 0x26, 0x86, 0xe8, 0x55,
+	0x3a, 0xa5, 0xb5, 0xc6, 0x46, 0xe7, 0xbc, 0x5a, 0xd6, 0x41, 0x5d, 0x81, 0xba, 0xdb, 0x97, 0x4a,
+	0x53, 0x83, 0xa3, 0xf5, 0xb8, 0x38, 0x92, 0x1d, 0x68, 0xe6, 0x9d, 0x35, 0x88, 0x78, 0xa2, 0xbd,
+	0x45, 0x7b, 0x85, 0x3f, 0xe7, 0x8a, 0xb7, 0x19, 0xd4, 0x24, 0x9c, 0x36, 0xc4, 0x19, 0x41, 0xbe,
+	0x85, 0x46, 0x22, 0x53, 0x15, 0xe0, 0xc0, 0xfa, 0x5f, 0xfd, 0xb4, 0xff, 0x90, 0xe1, 0xb7, 0x4c,
+	0x14, 0x77, 0x01, 0xd2, 0x04, 0xd5, 0x00, 0xc7, 0x8c, 0x47, 0xde, 0x52, 0xc7, 0x59, 0xab, 0xd3,
+	0xba, 0xe1, 0xec, 0x18, 0x06, 0xb9, 0x07, 0x0d, 0x2e, 0x0e, 0x65, 0x2a, 0xc2, 0x81, 0x49, 0x73,
+	0xcd, 0xca, 0x21, 0x67, 0xed, 0xb3, 0xa1, 0x69, 0xe5, 0x21, 0x4a, 0x1e, 0x7b, 0x75, 0x2b, 0xca,
+	0x08, 0xf3, 0x22, 0x86, 0x28, 0x13, 0xae, 0xd1, 0x03, 0xcb, 0x2f, 0x48, 0xf2, 0x00, 0x5a, 0x87,
+	0x2c, 0x62, 0x22, 0xe0, 0x62, 0x68, 0xaf, 0x6c, 0xd8, 0xca, 0x35, 0xa7, 0x4c, 0x73, 0xe9, 0x3d,
+	0x68, 0x64, 0x55, 0x19, 0x1c, 0xf1, 0x08, 0xbd, 0xe5, 0xcc, 0x6a, 0xc6, 0x7a, 0xce, 0x23, 0x24,
+	0x77, 0xa0, 0x6e, 0x82, 0xcd, 0xc4, 0xae, 0x15, 0xd7, 0x0c, 0xc3, 0x0a, 0x77, 0x61, 0x25, 0x4f,
+	0xc8, 0xb9, 0xfa, 0xac, 0x5c, 0xb3, 0x3e, 0xcb, 0x99, 0xea, 0x94, 0x41, 0x5e, 0x81, 0x1b, 0xc9,
+	0x80, 0x45, 0xe7, 0x2f, 0x23, 0xd7, 0xbc, 0xac, 0x6d, 0x35, 0xcf, 0xee, 0xa2, 0x00, 0x4c, 0x6b,
+	0xc5, 0x0f, 0x53, 0x8d, 0x89, 0x77, 0xc3, 0x56, 0x6a, 0x63, 0x4e, 0xa5, 0xce, 0x3d, 0xf2, 0xee,
+	0xe6, 0x54, 0x69, 0xc7, 0x34, 0x01, 0x3d, 0x77, 0x0b, 0x79, 0x0f, 0xcb, 0x79, 0xae, 0x12, 0xad,
+	0x98, 0xc6, 0xe1, 0xc4, 0xbb, 0x69, 0xc7, 0xf1, 0xe7, 0xd7, 0xb8, 0x38, 0xeb, 0x81, 0xbd, 0x5c,
+	0x91, 0xb6, 0xc3, 0x19, 0xda, 0xb4, 0xaf, 0x9d, 0xf9, 0x81, 0x8c, 0xbc, 0x66, 0x96, 0xe5, 0x82,
+	0x26, 0x8f, 0xa1, 0x32, 0xd2, 0x3a, 0xf6, 0x5a, 0x36, 0x17, 0x0f, 0xe7, 0x18, 0x7b, 0xb9, 0xbf,
+	0xdf, 0xdf, 0x92, 0x22, 0xe4, 0x9a, 0x4b, 0x41, 0xad, 0x86, 0xd1, 0xd4, 0x7c, 0x8c, 0x5e, 0xfb,
+	0x4a, 0xcd, 0x7d, 0x3e, 0xc6, 0x73, 0x9a, 0x46, 0x63, 0xf5, 0x09, 0x2c, 0x5f, 0x48, 0x85, 0xe9,
+	0xff, 0x63, 0x9c, 0x14, 0xfd, 0x7f, 0x8c, 0x93, 0xcb, 0x97, 0xcb, 0x37, 0xe5, 0xc7, 0x25, 0xff,
+	0x00, 0xda, 0xb3, 0x01, 0x93, 0x36, 0xc0, 0xbb, 0x04, 0xb7, 0xf1, 0x88, 0xa5, 0x91, 0x76, 0x17,
+	0xcc, 0x62, 0xd9, 0x4c, 0x7a, 0x89, 0x5b, 0x32, 0x9b, 0xe7, 0x5d, 0x82, 0xbd, 0xd8, 0x2d, 0x13,
+	0x17, 0x9a, 0xbd, 0xb8, 0x77, 0xf4, 0x56, 0x8a, 0x37, 0x4c, 0x07, 0x23, 0xd7, 0x31, 0x6a, 0xbd,
+	0xf8, 0x3b, 0xb1, 0x8d, 0x63, 0x26, 0xcc, 0xe2, 0xf9, 0xa9, 0x04, 0x60, 0xfc, 0x3d, 0xe0, 0x22,
+	0x94, 0xa7, 0xe4, 0x31, 0x2c, 0x9d, 0x22, 0x1e, 0x87, 0xcc, 0xf8, 0xe5, 0xac, 0xb5, 0xe7, 0xce,
+	0xb6, 0x83, 0x0c, 0x45, 0x0b, 0xb8, 0xe9, 0xc6, 0x44, 0x33, 0xa5, 0x07, 0x23, 0x99, 0xaa, 0x7c,
+	0x5b, 0xd5, 0x2d, 0xe7, 0xa5, 0x4c, 0x15, 0xb9, 0x0d, 0x35, 0x14, 0x61, 0x26, 0x74, 0xb2, 0x49,
+	0x8b, 0x22, 0x34, 0x22, 0xff, 0x08, 0x5a, 0x33, 0x19, 0x33, 0xb5, 0x33, 0x39, 0xfb, 0x51, 0x8a,
+	0x62, 0xf2, 0x4f, 0x69, 0xf2, 0x35, 0x54, 0x4f, 0xad, 0xab, 0xf9, 0x88, 0xbc, 0x7f, 0x45, 0x0d,
+	0xb2, 0x98, 0x68, 0xae, 0xe0, 0x3f, 0x02, 0x30, 0x35, 0x7d, 0x89, 0x2c, 0x44, 0x45, 0x08, 0x54,
+	0x04, 0x1b, 0x17, 0x06, 0xec, 0x79, 0xce, 0x72, 0xff, 0xb9, 0x04, 0xad, 0x99, 0xc7, 0x60, 0xb6,
+	0xf2, 0x18, 0xf5, 0x48, 0x86, 0x36, 0x49, 0x75, 0x9a, 0x53, 0xa6, 0xf9, 0x63, 0xa6, 0x47, 0x83,
+	0xe9, 0xca, 0x36, 0x42, 0x30, 0xac, 0xbe, 0xe5, 0x18, 0xef, 0x47, 0xd6, 0xbc, 0xe7, 0x5c, 0xe9,
+	0xfd, 0x99, 0x9f, 0x34, 0x57, 0xf0, 0x7f, 0x2f, 0x41, 0xeb, 0x59, 0x31, 0x69, 0xe6, 0xec, 0x8f,
+	0xff, 0xc1, 0x8a, 0x4c, 0x75, 0x36, 0xf3, 0x12, 0x8c, 0x30, 0xd0, 0x52, 0xe5, 0x5e, 0xb8, 0x85,
+	0x60, 0x2f, 0xe7, 0x93, 0x1e, 0xd4, 0xa6, 0x6d, 0xe7, 0xd8, 0xb6, 0xfb, 0x6c, 0x8e, 0x37, 0x33,
+	0x66, 0xbb, 0xd3, 0x96, 0x9b, 0xaa, 0xfb, 0xaf, 0xa0, 0x56, 0x70, 0xcd, 0x67, 0x87, 0x32, 0x11,
+	0xca, 0xb1, 0xbb, 0x60, 0x1e, 0x1b, 0x35, 0x36, 0xa9, 0x3c, 0xe4, 0xc2, 0x2d, 0x91, 0x65, 0x68,
+	0xec, 0x22, 0x4b, 0xf4, 0x66, 0xa0, 0xf9, 0x09, 0xba, 0x65, 0xb2, 0x02, 0xad, 0x5d, 0x79, 0x8a,
+	0x89, 0xde, 0x65, 0x1a, 0x45, 0x30, 0x71, 0x1d, 0xff, 0x0f, 0x07, 0xaa, 0x5b, 0xf6, 0x0f, 0x48,
+	0xde, 0x7d, 0x3c, 0x1f, 0xb2, 0xef, 0xda, 0xff, 0xe7, 0xad, 0x08, 0xab, 0xf7, 0xa9, 0xd1, 0xf0,
+	0x08, 0x2a, 0x2a, 0x8d, 0x30, 0x7f, 0x40, 0xfe, 0xa7, 0x67, 0x0d, 0xb5, 0x78, 0xf3, 0xc2, 0xed,
+	0x8a, 0xc8, 0x46, 0xb7, 0x63, 0xd3, 0x5e, 0xb7, 0x1c, 0x3b, 0xbb, 0xef, 0x43, 0x33, 0xdf, 0x14,
+	0x19, 0xa0, 0x92, 0xfd, 0x59, 0x72, 0x9e, 0x85, 0xbc, 0x86, 0xf6, 0xd9, 0x06, 0xb1, 0x3e, 0x2c,
+	0x76, 0x9c, 0x2b, 0x06, 0xc9, 0x4c, 0xe2, 0x69, 0xeb, 0xf0, 0x3c, 0x49, 0xba, 0x70, 0xc3, 0xec,
+	0xde, 0x41, 0x30, 0xc2, 0xe0, 0x78, 0xc0, 0x85, 0x46, 0x75, 0xc2, 0x22, 0xaf, 0x6a, 0x9b, 0x6b,
+	0xc5, 0x88, 0xb6, 0x8c, 0xa4, 0x97, 0x0b, 0xc8, 0x0e, 0xb4, 0x78, 0x6c, 0x9f, 0x26, 0x2a, 0x14,
+	0x01, 0x7a, 0x4b, 0x36, 0x97, 0x9d, 0x8b, 0xb6, 0x43, 0x91, 0x74, 0x7b, 0xfd, 0xfe, 0x14, 0x47,
+	0x9b, 0x3c, 0x3e, 0xa3, 0xfc, 0x17, 0x1f, 0x4d, 0xa2, 0x62, 0xf2, 0x2c, 0x9c, 0x4d, 0x9e, 0xd2,
+	0x47, 0x93, 0xa7, 0x7c, 0x61, 0xf2, 0x38, 0xff, 0x45, 0x58, 0xca, 0x87, 0x88, 0x79, 0x33, 0x7b,
+	0xa9, 0x08, 0xd9, 0xc4, 0x5d, 0x30, 0xe7, 0x37, 0xd2, 0x9e, 0x4b, 0xe6, 0x8b, 0xbc, 0x9f, 0x62,
+	0x62, 0x88, 0x32, 0x69, 0x41, 0xfd, 0x00, 0x43, 0x91, 0x91, 0x0e, 0x69, 0x42, 0x6d, 0x7f, 0x94,
+	0x2a, 0x4b, 0x55, 0x8c, 0xd6, 0x73, 0xc5, 0xcd, 0x79, 0xd1, 0x48, 0xf6, 0x98, 0x4e, 0x95, 0xa1,
+	0xaa, 0xcf, 0x9e, 0xc0, 0xed, 0x40, 0x8e, 0x2f, 0x4f, 0x70, 0xbf, 0xf4, 0xbe, 0x9a, 0x9d, 0x7e,
+	0x2b, 0xdf, 0xfa, 0x7e, 0x83, 0xb2, 0x49, 0x77, 0xcb, 0x20, 0x36, 0xe3, 0xd8, 0xd6, 0x1f, 0xd5,
+	0x61, 0xd5, 0x6e, 0x8d, 0x2f, 0xfe, 0x1c, 0x00, 0x69, 0xb0, 0xce, 0x05, 0xee, 0x0c, 0x00, 0x00,
 }
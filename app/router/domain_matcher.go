@@ -0,0 +1,299 @@
+package router
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+
+	"v2ray.com/core/proxy"
+)
+
+// domainNode is a node in DomainMatcherGroup. Each node represents a domain label.
+type domainNode struct {
+	children   map[string]*domainNode
+	values     []uint32
+	fullValues []uint32
+}
+
+func (n *domainNode) child(label string) *domainNode {
+	if n.children == nil {
+		n.children = make(map[string]*domainNode)
+	}
+	c, found := n.children[label]
+	if !found {
+		c = new(domainNode)
+		n.children[label] = c
+	}
+	return c
+}
+
+// DomainMatcherGroup is a suffix trie of domain labels. It matches a domain against all added patterns
+// in time proportional to the length of the domain.
+type DomainMatcherGroup struct {
+	root domainNode
+}
+
+func (g *DomainMatcherGroup) node(domain string) *domainNode {
+	n := &g.root
+	for end := len(domain); end >= 0; {
+		idx := strings.LastIndexByte(domain[:end], '.')
+		n = n.child(domain[idx+1 : end])
+		end = idx
+	}
+	return n
+}
+
+// Add adds a pattern that matches the domain itself and all its sub-domains.
+func (g *DomainMatcherGroup) Add(domain string, value uint32) {
+	n := g.node(domain)
+	n.values = append(n.values, value)
+}
+
+// AddFull adds a pattern that matches the domain only.
+func (g *DomainMatcherGroup) AddFull(domain string, value uint32) {
+	n := g.node(domain)
+	n.fullValues = append(n.fullValues, value)
+}
+
+// Match calls f with values of all patterns matching the domain.
+func (g *DomainMatcherGroup) Match(domain string, f func(uint32)) {
+	n := &g.root
+	for end := len(domain); end >= 0; {
+		idx := strings.LastIndexByte(domain[:end], '.')
+		next, found := n.children[domain[idx+1:end]]
+		if !found {
+			return
+		}
+		n = next
+		for _, v := range n.values {
+			f(v)
+		}
+		end = idx
+	}
+	for _, v := range n.fullValues {
+		f(v)
+	}
+}
+
+type acState struct {
+	next    map[byte]int
+	fail    int
+	outputs []uint32
+}
+
+// KeywordMatcherGroup is an Aho-Corasick automaton. It finds all added keywords in a string
+// in time proportional to the length of the string.
+type KeywordMatcherGroup struct {
+	states []acState
+	once   sync.Once
+}
+
+// Add adds a keyword. Keywords can't be added after Match is called.
+func (g *KeywordMatcherGroup) Add(keyword string, value uint32) {
+	if len(g.states) == 0 {
+		g.states = append(g.states, acState{})
+	}
+	s := 0
+	for i := 0; i < len(keyword); i++ {
+		c := keyword[i]
+		next, found := g.states[s].next[c]
+		if !found {
+			if g.states[s].next == nil {
+				g.states[s].next = make(map[byte]int)
+			}
+			next = len(g.states)
+			g.states[s].next[c] = next
+			g.states = append(g.states, acState{})
+		}
+		s = next
+	}
+	g.states[s].outputs = append(g.states[s].outputs, value)
+}
+
+// IsEmpty returns true if no keyword is added.
+func (g *KeywordMatcherGroup) IsEmpty() bool {
+	return len(g.states) == 0
+}
+
+// build computes failure links of all states in breadth-first order.
+func (g *KeywordMatcherGroup) build() {
+	if len(g.states) == 0 {
+		return
+	}
+	queue := make([]int, 0, len(g.states))
+	for _, s := range g.states[0].next {
+		queue = append(queue, s)
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		for c, next := range g.states[s].next {
+			queue = append(queue, next)
+			f := g.states[s].fail
+			for {
+				if target, found := g.states[f].next[c]; found {
+					g.states[next].fail = target
+					break
+				}
+				if f == 0 {
+					g.states[next].fail = 0
+					break
+				}
+				f = g.states[f].fail
+			}
+			g.states[next].outputs = append(g.states[next].outputs, g.states[g.states[next].fail].outputs...)
+		}
+	}
+}
+
+// Match calls f with values of all keywords in the string. A value may be reported more than once.
+func (g *KeywordMatcherGroup) Match(str string, f func(uint32)) {
+	g.once.Do(g.build)
+	if len(g.states) == 0 {
+		return
+	}
+	for _, v := range g.states[0].outputs {
+		f(v)
+	}
+	s := 0
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		for {
+			if next, found := g.states[s].next[c]; found {
+				s = next
+				break
+			}
+			if s == 0 {
+				break
+			}
+			s = g.states[s].fail
+		}
+		for _, v := range g.states[s].outputs {
+			f(v)
+		}
+	}
+}
+
+type regexpMatcher struct {
+	pattern *regexp.Regexp
+	value   uint32
+}
+
+// DomainMatcher matches a domain against a group of Domain patterns of all types at once.
+// Each pattern carries a value, such as the index of the rule it belongs to.
+type DomainMatcher struct {
+	domains  DomainMatcherGroup
+	keywords KeywordMatcherGroup
+	// labels matches Keyword patterns, which are added with dots around, against the domain with dots around.
+	labels  KeywordMatcherGroup
+	regexps []regexpMatcher
+}
+
+// NewDomainMatcher creates an empty DomainMatcher.
+func NewDomainMatcher() *DomainMatcher {
+	return new(DomainMatcher)
+}
+
+// Add adds a pattern with the given value.
+func (m *DomainMatcher) Add(domain *Domain, value uint32) error {
+	switch domain.Type {
+	case Domain_Plain:
+		m.keywords.Add(domain.Value, value)
+	case Domain_Regex:
+		r, err := regexp.Compile(domain.Value)
+		if err != nil {
+			return err
+		}
+		m.regexps = append(m.regexps, regexpMatcher{pattern: r, value: value})
+	case Domain_Domain:
+		m.domains.Add(domain.Value, value)
+	case Domain_Full:
+		m.domains.AddFull(domain.Value, value)
+	case Domain_Keyword:
+		keyword := strings.Trim(domain.Value, ".")
+		if len(keyword) == 0 {
+			return newError("empty domain keyword")
+		}
+		m.labels.Add("."+keyword+".", value)
+	default:
+		return newError("unknown domain type: ", domain.Type)
+	}
+	return nil
+}
+
+// Match calls f with values of all patterns matching the domain. A value may be reported more than once.
+func (m *DomainMatcher) Match(domain string, f func(uint32)) {
+	m.domains.Match(domain, f)
+	m.keywords.Match(domain, f)
+	if !m.labels.IsEmpty() {
+		m.labels.Match("."+domain+".", f)
+	}
+	if len(m.regexps) > 0 {
+		lowerDomain := strings.ToLower(domain)
+		for _, r := range m.regexps {
+			if r.pattern.MatchString(lowerDomain) {
+				f(r.value)
+			}
+		}
+	}
+}
+
+// MatchSet returns a set of values of all patterns matching the domain. size is one more than the largest value.
+func (m *DomainMatcher) MatchSet(domain string, size int) []bool {
+	set := make([]bool, size)
+	m.Match(domain, func(v uint32) {
+		set[v] = true
+	})
+	return set
+}
+
+type domainMatchesKey int
+
+const matchedRulesKey domainMatchesKey = 0
+
+type matchedDomains struct {
+	matcher *DomainMatcher
+	set     []bool
+}
+
+// contextWithDomainMatches caches the result of matching the target domain in context,
+// so that conditions sharing the matcher don't match again.
+func contextWithDomainMatches(ctx context.Context, matcher *DomainMatcher, set []bool) context.Context {
+	return context.WithValue(ctx, matchedRulesKey, &matchedDomains{
+		matcher: matcher,
+		set:     set,
+	})
+}
+
+// DomainCondition is a Condition that the target domain matches any pattern with the given value in the DomainMatcher.
+type DomainCondition struct {
+	matcher *DomainMatcher
+	value   uint32
+}
+
+// NewDomainCondition creates a DomainCondition.
+func NewDomainCondition(matcher *DomainMatcher, value uint32) *DomainCondition {
+	return &DomainCondition{
+		matcher: matcher,
+		value:   value,
+	}
+}
+
+// Apply implements Condition.
+func (c *DomainCondition) Apply(ctx context.Context) bool {
+	dest, ok := proxy.TargetFromContext(ctx)
+	if !ok || !dest.Address.Family().IsDomain() {
+		return false
+	}
+	if cache, ok := ctx.Value(matchedRulesKey).(*matchedDomains); ok && cache.matcher == c.matcher {
+		return int(c.value) < len(cache.set) && cache.set[c.value]
+	}
+	matched := false
+	c.matcher.Match(dest.Address.Domain(), func(v uint32) {
+		if v == c.value {
+			matched = true
+		}
+	})
+	return matched
+}
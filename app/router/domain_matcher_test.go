@@ -0,0 +1,203 @@
+package router_test
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+
+	. "v2ray.com/core/app/router"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/dice"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/proxy"
+	"v2ray.com/core/testing/assert"
+)
+
+func collect(match func(string, func(uint32)), s string) []uint32 {
+	set := make(map[uint32]bool)
+	match(s, func(v uint32) {
+		set[v] = true
+	})
+	values := make([]uint32, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+func TestDomainMatcherGroup(t *testing.T) {
+	assert := assert.On(t)
+
+	g := new(DomainMatcherGroup)
+	g.Add("v2ray.com", 1)
+	g.Add("google.com", 2)
+	g.Add("x.a.com", 3)
+	g.AddFull("www.v2ray.com", 4)
+	g.Add("com", 5)
+
+	cases := []struct {
+		domain string
+		values []uint32
+	}{
+		{"v2ray.com", []uint32{1, 5}},
+		{"www.v2ray.com", []uint32{1, 4, 5}},
+		{"a.www.v2ray.com", []uint32{1, 5}},
+		{"xv2ray.com", []uint32{5}},
+		{"a.com", []uint32{5}},
+		{"y.x.a.com", []uint32{3, 5}},
+		{"v2ray.org", []uint32{}},
+	}
+	for _, test := range cases {
+		assert.String(formatValues(collect(g.Match, test.domain))).Equals(formatValues(test.values))
+	}
+}
+
+func TestKeywordMatcherGroup(t *testing.T) {
+	assert := assert.On(t)
+
+	g := new(KeywordMatcherGroup)
+	g.Add("he", 1)
+	g.Add("she", 2)
+	g.Add("his", 3)
+	g.Add("hers", 4)
+
+	assert.String(formatValues(collect(g.Match, "ushers"))).Equals(formatValues([]uint32{1, 2, 4}))
+	assert.String(formatValues(collect(g.Match, "this"))).Equals(formatValues([]uint32{3}))
+	assert.String(formatValues(collect(g.Match, "abc"))).Equals(formatValues([]uint32{}))
+}
+
+func formatValues(values []uint32) string {
+	s := "["
+	for i, v := range values {
+		if i > 0 {
+			s += " "
+		}
+		s += strconv.Itoa(int(v))
+	}
+	return s + "]"
+}
+
+func TestDomainMatcherTypes(t *testing.T) {
+	assert := assert.On(t)
+
+	m := NewDomainMatcher()
+	common.Must(m.Add(&Domain{Type: Domain_Full, Value: "v2ray.com"}, 1))
+	common.Must(m.Add(&Domain{Type: Domain_Keyword, Value: "google"}, 2))
+	common.Must(m.Add(&Domain{Type: Domain_Keyword, Value: "co.uk"}, 3))
+	common.Must(m.Add(&Domain{Type: Domain_Plain, Value: "google"}, 4))
+	assert.Error(m.Add(&Domain{Type: Domain_Keyword, Value: "."}, 5)).IsNotNil()
+
+	cases := []struct {
+		domain string
+		values []uint32
+	}{
+		{"v2ray.com", []uint32{1}},
+		{"www.v2ray.com", []uint32{}},
+		{"google.com", []uint32{2, 4}},
+		{"www.google.co.uk", []uint32{2, 3, 4}},
+		{"googleapis.com", []uint32{4}},
+		{"notgoogle.com", []uint32{4}},
+		{"a.co.ukraine.com", []uint32{}},
+		{"bbc.co.uk", []uint32{3}},
+	}
+	for _, test := range cases {
+		assert.String(test.domain + " " + formatValues(collect(m.Match, test.domain))).Equals(test.domain + " " + formatValues(test.values))
+	}
+}
+
+func randomLabel() string {
+	const letters = "abcdefghij"
+	n := dice.Roll(3) + 1
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = letters[dice.Roll(len(letters))]
+	}
+	return string(b)
+}
+
+func randomDomain() string {
+	n := dice.Roll(3) + 1
+	domain := randomLabel()
+	for i := 0; i < n; i++ {
+		domain += "." + randomLabel()
+	}
+	return domain
+}
+
+func legacyCondition(domains []*Domain) Condition {
+	cond := NewAnyCondition()
+	for _, domain := range domains {
+		switch domain.Type {
+		case Domain_Plain:
+			cond.Add(NewPlainDomainMatcher(domain.Value))
+		case Domain_Regex:
+			matcher, err := NewRegexpDomainMatcher(domain.Value)
+			common.Must(err)
+			cond.Add(matcher)
+		case Domain_Domain:
+			cond.Add(NewSubDomainMatcher(domain.Value))
+		}
+	}
+	return cond
+}
+
+func compiledCondition(domains []*Domain) Condition {
+	matcher := NewDomainMatcher()
+	for _, domain := range domains {
+		common.Must(matcher.Add(domain, 0))
+	}
+	return NewDomainCondition(matcher, 0)
+}
+
+func randomDomainList(n int) []*Domain {
+	domains := make([]*Domain, 0, n)
+	for i := 0; i < n; i++ {
+		switch i % 10 {
+		case 0:
+			domains = append(domains, &Domain{Type: Domain_Plain, Value: randomLabel() + randomLabel()})
+		default:
+			domains = append(domains, &Domain{Type: Domain_Domain, Value: randomDomain()})
+		}
+	}
+	domains = append(domains, &Domain{Type: Domain_Regex, Value: "^a+\\.b+$"})
+	return domains
+}
+
+func TestDomainMatcherEquivalence(t *testing.T) {
+	assert := assert.On(t)
+
+	for round := 0; round < 10; round++ {
+		domains := randomDomainList(200)
+		legacy := legacyCondition(domains)
+		compiled := compiledCondition(domains)
+		for i := 0; i < 1000; i++ {
+			domain := randomDomain()
+			ctx := proxy.ContextWithTarget(context.Background(), net.TCPDestination(net.DomainAddress(domain), 80))
+			assert.String(domain + " " + strconv.FormatBool(compiled.Apply(ctx))).Equals(domain + " " + strconv.FormatBool(legacy.Apply(ctx)))
+		}
+	}
+}
+
+func benchmarkDomainCondition(b *testing.B, build func([]*Domain) Condition) {
+	domains := randomDomainList(100000)
+	cond := build(domains)
+	ctxs := make([]context.Context, 1024)
+	for i := range ctxs {
+		ctxs[i] = proxy.ContextWithTarget(context.Background(), net.TCPDestination(net.DomainAddress(randomDomain()), 80))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cond.Apply(ctxs[i%len(ctxs)])
+	}
+}
+
+func BenchmarkAnyConditionDomain(b *testing.B) {
+	benchmarkDomainCondition(b, legacyCondition)
+}
+
+func BenchmarkDomainMatcher(b *testing.B) {
+	benchmarkDomainCondition(b, compiledCondition)
+}
@@ -0,0 +1,131 @@
+package router
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"v2ray.com/core/app/log"
+	"v2ray.com/core/common/platform"
+)
+
+// GeoDataVersion is the version of GeoIPList and GeoSiteList format supported by this package.
+const GeoDataVersion = 1
+
+// geoLoader loads GeoIP and GeoSite entries from external files on demand. Each file is read at most once.
+type geoLoader struct {
+	config *Config
+	ips    map[string]*GeoIP
+	sites  map[string]*GeoSite
+}
+
+func newGeoLoader(config *Config) *geoLoader {
+	return &geoLoader{
+		config: config,
+	}
+}
+
+func readGeoFile(file string, list proto.Message) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return newError("failed to read file: ", file).Base(err)
+	}
+	if err := proto.Unmarshal(data, list); err != nil {
+		return newError("failed to parse file: ", file).Base(err)
+	}
+	return nil
+}
+
+func checkGeoDataVersion(file string, version uint32, revision string) error {
+	if version != GeoDataVersion {
+		return newError("unsupported version ", version, " of file: ", file)
+	}
+	log.Trace(newError("loaded ", file, " revision ", revision).AtInfo())
+	return nil
+}
+
+func (l *geoLoader) loadGeoIP(code string) (*GeoIP, error) {
+	if l.ips == nil {
+		file := l.config.GeoipFile
+		if len(file) == 0 {
+			file = platform.GetAssetLocation("geoip.dat")
+		}
+		list := new(GeoIPList)
+		if err := readGeoFile(file, list); err != nil {
+			return nil, err
+		}
+		if err := checkGeoDataVersion(file, list.Version, list.Revision); err != nil {
+			return nil, err
+		}
+		l.ips = make(map[string]*GeoIP, len(list.Entry))
+		for _, entry := range list.Entry {
+			l.ips[strings.ToUpper(entry.CountryCode)] = entry
+		}
+	}
+	entry, found := l.ips[strings.ToUpper(code)]
+	if !found {
+		return nil, newError("GeoIP entry not found: ", code)
+	}
+	return entry, nil
+}
+
+func (l *geoLoader) loadGeoSite(code string) (*GeoSite, error) {
+	if l.sites == nil {
+		file := l.config.GeositeFile
+		if len(file) == 0 {
+			file = platform.GetAssetLocation("geosite.dat")
+		}
+		list := new(GeoSiteList)
+		if err := readGeoFile(file, list); err != nil {
+			return nil, err
+		}
+		if err := checkGeoDataVersion(file, list.Version, list.Revision); err != nil {
+			return nil, err
+		}
+		l.sites = make(map[string]*GeoSite, len(list.Entry))
+		for _, entry := range list.Entry {
+			l.sites[strings.ToUpper(entry.CountryCode)] = entry
+		}
+	}
+	entry, found := l.sites[strings.ToUpper(code)]
+	if !found {
+		return nil, newError("GeoSite entry not found: ", code)
+	}
+	return entry, nil
+}
+
+// expandRule returns a copy of the rule, with its GeoIP and GeoSite references replaced by the actual IP ranges and domains.
+func (l *geoLoader) expandRule(rule *RoutingRule) (*RoutingRule, error) {
+	if len(rule.Geoip) == 0 && len(rule.Geosite) == 0 {
+		return rule, nil
+	}
+	expanded := *rule
+	expanded.Geoip = nil
+	expanded.Geosite = nil
+
+	if len(rule.Geoip) > 0 {
+		cidr := append([]*CIDR(nil), rule.Cidr...)
+		for _, code := range rule.Geoip {
+			entry, err := l.loadGeoIP(code)
+			if err != nil {
+				return nil, err
+			}
+			cidr = append(cidr, entry.Cidr...)
+		}
+		expanded.Cidr = cidr
+	}
+
+	if len(rule.Geosite) > 0 {
+		domain := append([]*Domain(nil), rule.Domain...)
+		for _, code := range rule.Geosite {
+			entry, err := l.loadGeoSite(code)
+			if err != nil {
+				return nil, err
+			}
+			domain = append(domain, entry.Domain...)
+		}
+		expanded.Domain = domain
+	}
+
+	return &expanded, nil
+}
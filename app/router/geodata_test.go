@@ -0,0 +1,119 @@
+package router_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/dispatcher"
+	_ "v2ray.com/core/app/dispatcher/impl"
+	"v2ray.com/core/app/dns"
+	_ "v2ray.com/core/app/dns/server"
+	"v2ray.com/core/app/proxyman"
+	_ "v2ray.com/core/app/proxyman/outbound"
+	. "v2ray.com/core/app/router"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/proxy"
+	"v2ray.com/core/testing/assert"
+)
+
+func writeGeoFile(dir string, name string, message proto.Message) string {
+	data, err := proto.Marshal(message)
+	common.Must(err)
+	file := filepath.Join(dir, name)
+	common.Must(ioutil.WriteFile(file, data, 0644))
+	return file
+}
+
+func TestGeoData(t *testing.T) {
+	assert := assert.On(t)
+
+	dir, err := ioutil.TempDir("", "v2ray-geodata")
+	assert.Error(err).IsNil()
+	defer os.RemoveAll(dir)
+
+	ipFile := writeGeoFile(dir, "geoip.dat", &GeoIPList{
+		Version: GeoDataVersion,
+		Entry: []*GeoIP{
+			{
+				CountryCode: "PRIVATE",
+				Cidr: []*CIDR{
+					{Ip: []byte{10, 0, 0, 0}, Prefix: 8},
+				},
+			},
+		},
+	})
+	siteFile := writeGeoFile(dir, "geosite.dat", &GeoSiteList{
+		Version: GeoDataVersion,
+		Entry: []*GeoSite{
+			{
+				CountryCode: "ADS",
+				Domain: []*Domain{
+					{Type: Domain_Domain, Value: "ads.example.com"},
+				},
+			},
+		},
+	})
+
+	config := &Config{
+		GeoipFile:   ipFile,
+		GeositeFile: siteFile,
+		Rule: []*RoutingRule{
+			{
+				Tag:     "block",
+				Geosite: []string{"ads"},
+			},
+			{
+				Tag:   "direct",
+				Geoip: []string{"private"},
+			},
+		},
+	}
+
+	space := app.NewSpace()
+	ctx := app.ContextWithSpace(context.Background(), space)
+	assert.Error(app.AddApplicationToSpace(ctx, new(dns.Config))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, new(dispatcher.Config))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, new(proxyman.OutboundConfig))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, config)).IsNil()
+	assert.Error(space.Initialize()).IsNil()
+
+	r := FromSpace(space)
+
+	tag, err := r.TakeDetour(proxy.ContextWithTarget(ctx, net.TCPDestination(net.DomainAddress("www.ads.example.com"), 80)))
+	assert.Error(err).IsNil()
+	assert.String(tag).Equals("block")
+
+	tag, err = r.TakeDetour(proxy.ContextWithTarget(ctx, net.TCPDestination(net.ParseAddress("10.1.2.3"), 80)))
+	assert.Error(err).IsNil()
+	assert.String(tag).Equals("direct")
+
+	_, err = r.TakeDetour(proxy.ContextWithTarget(ctx, net.TCPDestination(net.DomainAddress("example.com"), 80)))
+	assert.Error(err).IsNotNil()
+
+	assert.Error(r.Reload(&Config{
+		GeoipFile: ipFile,
+		Rule: []*RoutingRule{
+			{
+				Tag:   "direct",
+				Geoip: []string{"cn"},
+			},
+		},
+	})).IsNotNil()
+
+	newerFile := writeGeoFile(dir, "newer.dat", &GeoIPList{Version: GeoDataVersion + 1})
+	assert.Error(r.Reload(&Config{
+		GeoipFile: newerFile,
+		Rule: []*RoutingRule{
+			{
+				Tag:   "direct",
+				Geoip: []string{"private"},
+			},
+		},
+	})).IsNotNil()
+}
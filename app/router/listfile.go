@@ -0,0 +1,191 @@
+package router
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// ParseDomain parses a line of domain list in the form of "type:value", where type is one of "domain", "full",
+// "keyword", "regexp" and "plain". A line without type is a domain.
+func ParseDomain(s string) (*Domain, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) == 1 {
+		return &Domain{Type: Domain_Domain, Value: strings.ToLower(s)}, nil
+	}
+	switch strings.ToLower(parts[0]) {
+	case "domain":
+		return &Domain{Type: Domain_Domain, Value: strings.ToLower(parts[1])}, nil
+	case "full":
+		return &Domain{Type: Domain_Full, Value: strings.ToLower(parts[1])}, nil
+	case "keyword":
+		return &Domain{Type: Domain_Keyword, Value: strings.ToLower(parts[1])}, nil
+	case "regexp":
+		return &Domain{Type: Domain_Regex, Value: parts[1]}, nil
+	case "plain":
+		return &Domain{Type: Domain_Plain, Value: strings.ToLower(parts[1])}, nil
+	default:
+		return nil, newError("unknown domain type: ", parts[0])
+	}
+}
+
+// ParseCIDR parses an IP or CIDR.
+func ParseCIDR(s string) (*CIDR, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, newError("invalid IP: ", s)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return &CIDR{Ip: []byte(ip4), Prefix: 32}, nil
+		}
+		return &CIDR{Ip: []byte(ip), Prefix: 128}, nil
+	}
+	ip, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, err
+	}
+	prefix, _ := ipNet.Mask.Size()
+	if ip.To4() != nil {
+		return &CIDR{Ip: []byte(ipNet.IP.To4()), Prefix: uint32(prefix)}, nil
+	}
+	return &CIDR{Ip: []byte(ipNet.IP.To16()), Prefix: uint32(prefix)}, nil
+}
+
+// readListFile calls f with each non-empty line of the file. Text after "#" is ignored.
+func readListFile(path string, f func(line string) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return newError("failed to open list file: ", path).Base(err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if err := f(line); err != nil {
+			return newError("invalid line ", lineNum, " in list file: ", path).Base(err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return newError("failed to read list file: ", path).Base(err)
+	}
+	return nil
+}
+
+// expandListFiles returns a copy of the rule, with its domain and CIDR files replaced by the entries in them.
+func expandListFiles(rule *RoutingRule) (*RoutingRule, error) {
+	if len(rule.DomainFile) == 0 && len(rule.CidrFile) == 0 {
+		return rule, nil
+	}
+	expanded := *rule
+	expanded.DomainFile = nil
+	expanded.CidrFile = nil
+
+	if len(rule.DomainFile) > 0 {
+		domains := append([]*Domain(nil), rule.Domain...)
+		for _, path := range rule.DomainFile {
+			err := readListFile(path, func(line string) error {
+				domain, err := ParseDomain(line)
+				if err != nil {
+					return err
+				}
+				domains = append(domains, domain)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		expanded.Domain = domains
+	}
+
+	if len(rule.CidrFile) > 0 {
+		cidrs := append([]*CIDR(nil), rule.Cidr...)
+		for _, path := range rule.CidrFile {
+			err := readListFile(path, func(line string) error {
+				cidr, err := ParseCIDR(line)
+				if err != nil {
+					return err
+				}
+				cidrs = append(cidrs, cidr)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		expanded.Cidr = cidrs
+	}
+
+	return &expanded, nil
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}
+}
+
+// listWatcher detects changes of the list files in a config by their modification time and size.
+type listWatcher struct {
+	files map[string]fileStamp
+}
+
+func newListWatcher(config *Config) *listWatcher {
+	w := &listWatcher{
+		files: make(map[string]fileStamp),
+	}
+	for _, rule := range config.Rule {
+		for _, path := range rule.DomainFile {
+			w.files[path] = statFile(path)
+		}
+		for _, path := range rule.CidrFile {
+			w.files[path] = statFile(path)
+		}
+	}
+	return w
+}
+
+// changes returns the current state of the files that are changed since the last commit, or nil if none is changed.
+func (w *listWatcher) changes() map[string]fileStamp {
+	var changes map[string]fileStamp
+	for path, stamp := range w.files {
+		newStamp := statFile(path)
+		if !newStamp.modTime.Equal(stamp.modTime) || newStamp.size != stamp.size {
+			if changes == nil {
+				changes = make(map[string]fileStamp)
+			}
+			changes[path] = newStamp
+		}
+	}
+	return changes
+}
+
+// commit records the given state of the files, so that they are no longer reported as changed.
+func (w *listWatcher) commit(changes map[string]fileStamp) {
+	for path, stamp := range changes {
+		w.files[path] = stamp
+	}
+}
@@ -0,0 +1,119 @@
+package router_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/dispatcher"
+	"v2ray.com/core/app/dns"
+	"v2ray.com/core/app/proxyman"
+	. "v2ray.com/core/app/router"
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/proxy"
+	"v2ray.com/core/testing/assert"
+)
+
+func TestParseDomain(t *testing.T) {
+	assert := assert.On(t)
+
+	cases := []struct {
+		input  string
+		domain *Domain
+	}{
+		{"V2Ray.com", &Domain{Type: Domain_Domain, Value: "v2ray.com"}},
+		{"domain:v2ray.com", &Domain{Type: Domain_Domain, Value: "v2ray.com"}},
+		{"full:www.v2ray.com", &Domain{Type: Domain_Full, Value: "www.v2ray.com"}},
+		{"keyword:google", &Domain{Type: Domain_Keyword, Value: "google"}},
+		{"plain:ads", &Domain{Type: Domain_Plain, Value: "ads"}},
+		{"regexp:^A+$", &Domain{Type: Domain_Regex, Value: "^A+$"}},
+	}
+	for _, test := range cases {
+		domain, err := ParseDomain(test.input)
+		assert.Error(err).IsNil()
+		assert.String(domain.Type.String()).Equals(test.domain.Type.String())
+		assert.String(domain.Value).Equals(test.domain.Value)
+	}
+
+	_, err := ParseDomain("unknown:v2ray.com")
+	assert.Error(err).IsNotNil()
+}
+
+func TestRouterListFiles(t *testing.T) {
+	assert := assert.On(t)
+
+	dir, err := ioutil.TempDir("", "v2ray-router")
+	assert.Error(err).IsNil()
+	defer os.RemoveAll(dir)
+
+	domainFile := filepath.Join(dir, "domains.txt")
+	cidrFile := filepath.Join(dir, "cidrs.txt")
+	assert.Error(ioutil.WriteFile(domainFile, []byte("# blocked domains\nfull:ads.v2ray.com\nkeyword:tracker # trackers\n"), 0644)).IsNil()
+	assert.Error(ioutil.WriteFile(cidrFile, []byte("10.0.0.0/8\n192.168.1.1\n"), 0644)).IsNil()
+
+	config := &Config{
+		ListCheckInterval: 1,
+		Rule: []*RoutingRule{
+			{
+				Tag:        "blocked",
+				DomainFile: []string{domainFile},
+			},
+			{
+				Tag:      "blocked",
+				CidrFile: []string{cidrFile},
+			},
+		},
+	}
+
+	space := app.NewSpace()
+	ctx := app.ContextWithSpace(context.Background(), space)
+	assert.Error(app.AddApplicationToSpace(ctx, new(dns.Config))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, new(dispatcher.Config))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, new(proxyman.OutboundConfig))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, config)).IsNil()
+	assert.Error(space.Initialize()).IsNil()
+
+	r := FromSpace(space)
+	assert.Error(r.Start()).IsNil()
+	defer r.Close()
+
+	route := func(address net.Address) bool {
+		_, err := r.TakeDetour(proxy.ContextWithTarget(ctx, net.TCPDestination(address, 80)))
+		return err == nil
+	}
+
+	assert.Bool(route(net.DomainAddress("ads.v2ray.com"))).IsTrue()
+	assert.Bool(route(net.DomainAddress("www.ads.v2ray.com"))).IsFalse()
+	assert.Bool(route(net.DomainAddress("tracker.example.com"))).IsTrue()
+	assert.Bool(route(net.DomainAddress("v2ray.com"))).IsFalse()
+	assert.Bool(route(net.ParseAddress("10.1.2.3"))).IsTrue()
+	assert.Bool(route(net.ParseAddress("192.168.1.1"))).IsTrue()
+	assert.Bool(route(net.ParseAddress("192.168.1.2"))).IsFalse()
+
+	assert.Error(ioutil.WriteFile(domainFile, []byte("v2ray.com\n"), 0644)).IsNil()
+	time.Sleep(time.Second * 3)
+
+	assert.Bool(route(net.DomainAddress("ads.v2ray.com"))).IsTrue()
+	assert.Bool(route(net.DomainAddress("tracker.example.com"))).IsFalse()
+	assert.Bool(route(net.DomainAddress("v2ray.com"))).IsTrue()
+
+	// Invalid lists don't replace the current rules.
+	assert.Error(ioutil.WriteFile(domainFile, []byte("unknown:v2ray.com\n"), 0644)).IsNil()
+	time.Sleep(time.Second * 3)
+
+	assert.Bool(route(net.DomainAddress("v2ray.com"))).IsTrue()
+
+	// A failed reload is retried, even if the file is then fixed without changing its size or modification time.
+	info, err := os.Stat(domainFile)
+	assert.Error(err).IsNil()
+	assert.Error(ioutil.WriteFile(domainFile, []byte("full:abcdefgh.com\n"), 0644)).IsNil()
+	assert.Error(os.Chtimes(domainFile, info.ModTime(), info.ModTime())).IsNil()
+	time.Sleep(time.Second * 3)
+
+	assert.Bool(route(net.DomainAddress("abcdefgh.com"))).IsTrue()
+	assert.Bool(route(net.DomainAddress("v2ray.com"))).IsFalse()
+}
@@ -4,10 +4,14 @@ package router
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"v2ray.com/core/app"
 	"v2ray.com/core/app/dns"
 	"v2ray.com/core/app/log"
+	"v2ray.com/core/app/metrics"
+	"v2ray.com/core/app/proxyman"
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/net"
 	"v2ray.com/core/proxy"
@@ -17,10 +21,21 @@ var (
 	ErrNoRuleApplicable = newError("No rule applicable")
 )
 
+var ruleHits = metrics.NewCounterVec("v2ray_router_rule_hits_total", "Number of times a routing rule matches, by outbound tag of the rule.", "tag")
+
 type Router struct {
+	access         sync.RWMutex
 	domainStrategy Config_DomainStrategy
+	ipPreference   dns.IPPreference
 	rules          []Rule
+	domainMatcher  *DomainMatcher
+	sniffing       bool
+	httpHeaders    bool
+	config         *Config
+	lists          *listWatcher
 	dnsServer      dns.Server
+	ohm            proxyman.OutboundHandlerManager
+	done           chan struct{}
 }
 
 func NewRouter(ctx context.Context, config *Config) (*Router, error) {
@@ -30,18 +45,25 @@ func NewRouter(ctx context.Context, config *Config) (*Router, error) {
 	}
 	r := &Router{
 		domainStrategy: config.DomainStrategy,
-		rules:          make([]Rule, len(config.Rule)),
+		ipPreference:   config.IpPreference,
 	}
 
 	space.OnInitialize(func() error {
-		for idx, rule := range config.Rule {
-			r.rules[idx].Tag = rule.Tag
-			cond, err := rule.BuildCondition()
-			if err != nil {
-				return err
-			}
-			r.rules[idx].Condition = cond
+		r.ohm = proxyman.OutboundHandlerManagerFromSpace(space)
+		if r.ohm == nil {
+			return newError("OutboundHandlerManager is not found in the space")
+		}
+
+		r.lists = newListWatcher(config)
+		rules, matcher, err := buildRules(config, r.ohm)
+		if err != nil {
+			return err
 		}
+		r.rules = rules
+		r.domainMatcher = matcher
+		r.sniffing = needsSniffing(config)
+		r.httpHeaders = needsHTTPHeaders(config)
+		r.config = config
 
 		r.dnsServer = dns.FromSpace(space)
 		if r.dnsServer == nil {
@@ -52,8 +74,162 @@ func NewRouter(ctx context.Context, config *Config) (*Router, error) {
 	return r, nil
 }
 
-func (r *Router) resolveIP(dest net.Destination) []net.Address {
-	ips := r.dnsServer.Get(dest.Address.Domain())
+// buildRules builds all routing rules in the config. Domains of all rules are compiled into one DomainMatcher,
+// with the index of the rule as value.
+func buildRules(config *Config, ohm proxyman.OutboundHandlerManager) ([]Rule, *DomainMatcher, error) {
+	balancers := make(map[string]*Balancer, len(config.BalancingRule))
+	for _, rule := range config.BalancingRule {
+		if _, found := balancers[rule.Tag]; found {
+			return nil, nil, newError("duplicate balancer tag: ", rule.Tag)
+		}
+		balancer, err := NewBalancer(rule, ohm)
+		if err != nil {
+			return nil, nil, err
+		}
+		balancers[rule.Tag] = balancer
+	}
+
+	geo := newGeoLoader(config)
+	matcher := NewDomainMatcher()
+	rules := make([]Rule, len(config.Rule))
+	for idx, rule := range config.Rule {
+		rules[idx].Tag = rule.Tag
+		if len(rule.BalancingTag) > 0 {
+			balancer, found := balancers[rule.BalancingTag]
+			if !found {
+				return nil, nil, newError("balancer not found: ", rule.BalancingTag)
+			}
+			rules[idx].Balancer = balancer
+		}
+		rule, err := geo.expandRule(rule)
+		if err != nil {
+			return nil, nil, err
+		}
+		rule, err = expandListFiles(rule)
+		if err != nil {
+			return nil, nil, err
+		}
+		rules[idx].DomainStrategy, err = rule.effectiveDomainStrategy(config.DomainStrategy)
+		if err != nil {
+			return nil, nil, err
+		}
+		rules[idx].hasIP = len(rule.Cidr) > 0
+		cond, err := rule.buildCondition(matcher, uint32(idx))
+		if err != nil {
+			return nil, nil, err
+		}
+		rules[idx].Condition = cond
+	}
+	return rules, matcher, nil
+}
+
+// Reload replaces the domain strategy and rules of this Router with the ones in the given config.
+// The current rules are kept if any of the new rules is invalid.
+func (r *Router) Reload(config *Config) error {
+	lists := newListWatcher(config)
+	rules, matcher, err := buildRules(config, r.ohm)
+	if err != nil {
+		return newError("failed to build routing rules").Base(err)
+	}
+
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	r.domainStrategy = config.DomainStrategy
+	r.ipPreference = config.IpPreference
+	r.rules = rules
+	r.domainMatcher = matcher
+	r.sniffing = needsSniffing(config)
+	r.httpHeaders = needsHTTPHeaders(config)
+	r.config = config
+	r.lists = lists
+	return nil
+}
+
+// reloadLists rebuilds the rules if any of their list files is changed. The changes are only committed after the
+// rules are rebuilt, so that a failed reload is retried until it succeeds.
+func (r *Router) reloadLists() {
+	r.access.RLock()
+	config := r.config
+	lists := r.lists
+	r.access.RUnlock()
+
+	changes := lists.changes()
+	if changes == nil {
+		return
+	}
+	rules, matcher, err := buildRules(config, r.ohm)
+	if err != nil {
+		log.Trace(newError("failed to reload list files of routing rules").Base(err).AtWarning())
+		return
+	}
+
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	if r.config != config {
+		// The config is reloaded in the meantime.
+		return
+	}
+	lists.commit(changes)
+	r.rules = rules
+	r.domainMatcher = matcher
+	log.Trace(newError("list files of routing rules reloaded").AtInfo())
+}
+
+func (r *Router) watchLists(done <-chan struct{}) {
+	for {
+		r.access.RLock()
+		interval := time.Second * time.Duration(r.config.ListCheckInterval)
+		r.access.RUnlock()
+		if interval == 0 {
+			interval = time.Second * 10
+		}
+
+		select {
+		case <-done:
+			return
+		case <-time.After(interval):
+			r.reloadLists()
+		}
+	}
+}
+
+func needsSniffing(config *Config) bool {
+	for _, rule := range config.Rule {
+		if rule.needsSniffing() {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsSniffing returns true if any routing rule depends on the sniffed protocol of connections.
+func (r *Router) NeedsSniffing() bool {
+	r.access.RLock()
+	defer r.access.RUnlock()
+	return r.sniffing
+}
+
+func needsHTTPHeaders(config *Config) bool {
+	for _, rule := range config.Rule {
+		if rule.needsHTTPHeaders() {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsHTTPHeaders returns true if any routing rule matches headers of HTTP requests, so that all the headers of a
+// request must be sniffed, not only the Host header.
+func (r *Router) NeedsHTTPHeaders() bool {
+	r.access.RLock()
+	defer r.access.RUnlock()
+	return r.httpHeaders
+}
+
+func (r *Router) resolveIP(dest net.Destination, preference dns.IPPreference) []net.Address {
+	ips := r.dnsServer.GetIP(dest.Address.Domain(), preference)
 	if len(ips) == 0 {
 		return nil
 	}
@@ -64,26 +240,139 @@ func (r *Router) resolveIP(dest net.Destination) []net.Address {
 	return dests
 }
 
-func (r *Router) TakeDetour(ctx context.Context) (string, error) {
-	for _, rule := range r.rules {
-		if rule.Apply(ctx) {
-			return rule.Tag, nil
+// routingIPs resolves the IPs of the target domain on first use, for matching IP rules.
+type routingIPs struct {
+	router     *Router
+	dest       net.Destination
+	preference dns.IPPreference
+	trace      *Trace
+	resolved   bool
+	ctx        context.Context
+}
+
+// context returns the given context with resolved IPs, or the context as is if no IP is resolved.
+func (ips *routingIPs) context(ctx context.Context) context.Context {
+	if !ips.resolved {
+		ips.resolved = true
+		log.Trace(newError("looking up IP for ", ips.dest))
+		ipDests := ips.router.resolveIP(ips.dest, ips.preference)
+		if ips.trace != nil {
+			ips.trace.Resolved = true
+			ips.trace.ResolvedIPs = ipDests
 		}
+		if ipDests != nil {
+			ips.ctx = proxy.ContextWithResolveIPs(ctx, ipDests)
+		}
+	}
+	if ips.ctx == nil {
+		return ctx
+	}
+	return ips.ctx
+}
+
+// tryRule returns the outbound of the rule if it matches the context, and its outbound is healthy.
+// Evaluation of the rule is recorded in trace if it is not nil.
+func (r *Router) tryRule(ctx context.Context, rules []Rule, idx int, withIPs bool, trace *Trace) (string, bool) {
+	rule := &rules[idx]
+	if !rule.Apply(ctx) {
+		if trace != nil {
+			trace.addRule(rule, idx, explainMismatch(rule.Condition, ctx), withIPs)
+		}
+		return "", false
+	}
+	pick := rule.PickOutbound
+	if trace != nil {
+		pick = rule.PeekOutbound
+	}
+	tag, err := pick()
+	if err != nil {
+		if trace != nil {
+			trace.addRule(rule, idx, "failed to pick outbound: "+err.Error(), withIPs)
+		} else {
+			log.Trace(newError("failed to pick outbound").Base(err).AtWarning())
+		}
+		return "", false
+	}
+	if !isHealthy(r.ohm, tag) {
+		if trace != nil {
+			trace.addRule(rule, idx, "outbound "+tag+" is unhealthy", withIPs)
+		} else {
+			log.Trace(newError("skipping unhealthy outbound: ", tag).AtDebug())
+		}
+		return "", false
+	}
+	if trace != nil {
+		trace.addRule(rule, idx, "", withIPs)
+		trace.MatchedRule = idx
+		trace.OutboundTag = tag
+	} else {
+		ruleHits.With(tag).Inc()
+	}
+	return tag, true
+}
+
+func (r *Router) TakeDetour(ctx context.Context) (string, error) {
+	return r.route(ctx, nil)
+}
+
+// route returns the outbound of the first rule that matches the context. For a domain target, each rule is matched
+// with or without the resolved IPs according to its domain strategy. Rules with IpIfNonMatch strategy are matched
+// again with the resolved IPs if no rule matches.
+func (r *Router) route(ctx context.Context, trace *Trace) (string, error) {
+	r.access.RLock()
+	rules := r.rules
+	matcher := r.domainMatcher
+	domainStrategy := r.domainStrategy
+	preference := r.ipPreference
+	r.access.RUnlock()
+
+	if trace != nil {
+		trace.DomainStrategy = domainStrategy
 	}
 
 	dest, ok := proxy.TargetFromContext(ctx)
-	if !ok {
-		return "", ErrNoRuleApplicable
+	isDomain := ok && dest.Address.Family().IsDomain()
+	ips := &routingIPs{
+		router:     r,
+		dest:       dest,
+		preference: preference,
+		trace:      trace,
+	}
+	if isDomain {
+		ctx = contextWithDomainMatches(ctx, matcher, matcher.MatchSet(dest.Address.Domain(), len(rules)))
 	}
 
-	if r.domainStrategy == Config_IpIfNonMatch && dest.Address.Family().IsDomain() {
-		log.Trace(newError("looking up IP for ", dest))
-		ipDests := r.resolveIP(dest)
-		if ipDests != nil {
-			ctx = proxy.ContextWithResolveIPs(ctx, ipDests)
-			for _, rule := range r.rules {
-				if rule.Apply(ctx) {
-					return rule.Tag, nil
+	ipIfNonMatch := false
+	for i := range rules {
+		ruleCtx := ctx
+		withIPs := false
+		if isDomain {
+			switch rules[i].DomainStrategy {
+			case Config_UseIp:
+				withIPs = true
+			case Config_IpOnDemand:
+				withIPs = rules[i].hasIP
+			case Config_IpIfNonMatch:
+				ipIfNonMatch = true
+			}
+		}
+		if withIPs {
+			ruleCtx = ips.context(ctx)
+		}
+		if tag, found := r.tryRule(ruleCtx, rules, i, withIPs, trace); found {
+			return tag, nil
+		}
+	}
+
+	if ipIfNonMatch {
+		ipCtx := ips.context(ctx)
+		if _, resolved := proxy.ResolvedIPsFromContext(ipCtx); resolved {
+			for i := range rules {
+				if rules[i].DomainStrategy != Config_IpIfNonMatch {
+					continue
+				}
+				if tag, found := r.tryRule(ipCtx, rules, i, true, trace); found {
+					return tag, nil
 				}
 			}
 		}
@@ -96,11 +385,18 @@ func (*Router) Interface() interface{} {
 	return (*Router)(nil)
 }
 
-func (*Router) Start() error {
+func (r *Router) Start() error {
+	r.done = make(chan struct{})
+	go r.watchLists(r.done)
 	return nil
 }
 
-func (*Router) Close() {}
+func (r *Router) Close() {
+	if r.done != nil {
+		close(r.done)
+		r.done = nil
+	}
+}
 
 func FromSpace(space app.Space) *Router {
 	app := space.GetApplication((*Router)(nil))
@@ -46,3 +46,199 @@ func TestSimpleRouter(t *testing.T) {
 	assert.Error(err).IsNil()
 	assert.String(tag).Equals("test")
 }
+
+func TestRouterReload(t *testing.T) {
+	assert := assert.On(t)
+
+	config := &Config{
+		Rule: []*RoutingRule{
+			{
+				Tag: "test",
+				NetworkList: &net.NetworkList{
+					Network: []net.Network{net.Network_TCP},
+				},
+			},
+		},
+	}
+
+	space := app.NewSpace()
+	ctx := app.ContextWithSpace(context.Background(), space)
+	assert.Error(app.AddApplicationToSpace(ctx, new(dns.Config))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, new(dispatcher.Config))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, new(proxyman.OutboundConfig))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, config)).IsNil()
+	assert.Error(space.Initialize()).IsNil()
+
+	r := FromSpace(space)
+
+	assert.Error(r.Reload(&Config{
+		Rule: []*RoutingRule{
+			{
+				Tag: "reloaded",
+				NetworkList: &net.NetworkList{
+					Network: []net.Network{net.Network_TCP},
+				},
+			},
+		},
+	})).IsNil()
+
+	ctx = proxy.ContextWithTarget(ctx, net.TCPDestination(net.DomainAddress("v2ray.com"), 80))
+	tag, err := r.TakeDetour(ctx)
+	assert.Error(err).IsNil()
+	assert.String(tag).Equals("reloaded")
+
+	// Invalid rules don't replace the current ones.
+	assert.Error(r.Reload(&Config{
+		Rule: []*RoutingRule{
+			{
+				Tag: "invalid",
+			},
+		},
+	})).IsNotNil()
+
+	tag, err = r.TakeDetour(ctx)
+	assert.Error(err).IsNil()
+	assert.String(tag).Equals("reloaded")
+}
+
+func TestRouterTrace(t *testing.T) {
+	assert := assert.On(t)
+
+	config := &Config{
+		DomainStrategy: Config_IpIfNonMatch,
+		Rule: []*RoutingRule{
+			{
+				Tag:       "port",
+				PortRange: &net.PortRange{From: 53, To: 53},
+			},
+			{
+				Tag: "private",
+				Cidr: []*CIDR{
+					{Ip: []byte{10, 0, 0, 0}, Prefix: 8},
+				},
+			},
+		},
+	}
+
+	space := app.NewSpace()
+	ctx := app.ContextWithSpace(context.Background(), space)
+	assert.Error(app.AddApplicationToSpace(ctx, &dns.Config{
+		Hosts: map[string]*net.IPOrDomain{
+			"intranet.v2ray.com": net.NewIPOrDomain(net.ParseAddress("10.0.0.1")),
+		},
+	})).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, new(dispatcher.Config))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, new(proxyman.OutboundConfig))).IsNil()
+	assert.Error(app.AddApplicationToSpace(ctx, config)).IsNil()
+	assert.Error(space.Initialize()).IsNil()
+
+	r := FromSpace(space)
+
+	query := &RouteQuery{
+		Destination: net.TCPDestination(net.DomainAddress("intranet.v2ray.com"), 80),
+	}
+	trace := r.Trace(query.Context())
+	assert.Bool(trace.Resolved).IsTrue()
+	assert.Int(len(trace.ResolvedIPs)).Equals(1)
+	assert.Address(trace.ResolvedIPs[0]).Equals(net.ParseAddress("10.0.0.1"))
+	assert.Int(trace.MatchedRule).Equals(1)
+	assert.String(trace.OutboundTag).Equals("private")
+	assert.Int(len(trace.Rules)).Equals(4)
+	assert.String(trace.Rules[0].Reason).Equals("port does not match")
+	assert.String(trace.Rules[1].Reason).Equals("IP does not match")
+	assert.Bool(trace.Rules[1].AfterResolution).IsFalse()
+	assert.Bool(trace.Rules[3].AfterResolution).IsTrue()
+	assert.String(trace.Rules[3].Reason).Equals("")
+
+	query.Destination = net.TCPDestination(net.ParseAddress("8.8.8.8"), 80)
+	trace = r.Trace(query.Context())
+	assert.Bool(trace.Resolved).IsFalse()
+	assert.Int(trace.MatchedRule).Equals(-1)
+	assert.Int(len(trace.Rules)).Equals(2)
+}
+
+func TestRouterDomainStrategy(t *testing.T) {
+	assert := assert.On(t)
+
+	newRouter := func(config *Config) *Router {
+		space := app.NewSpace()
+		ctx := app.ContextWithSpace(context.Background(), space)
+		assert.Error(app.AddApplicationToSpace(ctx, &dns.Config{
+			Hosts: map[string]*net.IPOrDomain{
+				"intranet.v2ray.com": net.NewIPOrDomain(net.ParseAddress("10.0.0.1")),
+			},
+		})).IsNil()
+		assert.Error(app.AddApplicationToSpace(ctx, new(dispatcher.Config))).IsNil()
+		assert.Error(app.AddApplicationToSpace(ctx, new(proxyman.OutboundConfig))).IsNil()
+		assert.Error(app.AddApplicationToSpace(ctx, config)).IsNil()
+		assert.Error(space.Initialize()).IsNil()
+		return FromSpace(space)
+	}
+
+	trace := func(r *Router, domain string) *Trace {
+		query := &RouteQuery{
+			Destination: net.TCPDestination(net.DomainAddress(domain), 80),
+		}
+		return r.Trace(query.Context())
+	}
+
+	domainRule := &RoutingRule{
+		Tag: "www",
+		Domain: []*Domain{
+			{Type: Domain_Full, Value: "www.v2ray.com"},
+		},
+	}
+	ipRule := &RoutingRule{
+		Tag: "intranet",
+		Cidr: []*CIDR{
+			{Ip: []byte{10, 0, 0, 0}, Prefix: 8},
+		},
+	}
+
+	// IpOnDemand resolves only for rules with IP conditions.
+	r := newRouter(&Config{
+		DomainStrategy: Config_IpOnDemand,
+		Rule:           []*RoutingRule{domainRule, ipRule},
+	})
+	tr := trace(r, "www.v2ray.com")
+	assert.String(tr.OutboundTag).Equals("www")
+	assert.Bool(tr.Resolved).IsFalse()
+	tr = trace(r, "intranet.v2ray.com")
+	assert.String(tr.OutboundTag).Equals("intranet")
+	assert.Bool(tr.Resolved).IsTrue()
+
+	r = newRouter(&Config{
+		DomainStrategy: Config_IpOnDemand,
+		Rule:           []*RoutingRule{domainRule},
+	})
+	tr = trace(r, "intranet.v2ray.com")
+	assert.Int(tr.MatchedRule).Equals(-1)
+	assert.Bool(tr.Resolved).IsFalse()
+
+	// A rule overrides the domain strategy of the router.
+	r = newRouter(&Config{
+		Rule: []*RoutingRule{domainRule, ipRule},
+	})
+	tr = trace(r, "intranet.v2ray.com")
+	assert.Int(tr.MatchedRule).Equals(-1)
+	assert.Bool(tr.Resolved).IsFalse()
+
+	ipIfNonMatchRule := *ipRule
+	ipIfNonMatchRule.DomainStrategy = RoutingRule_IpIfNonMatch
+	r = newRouter(&Config{
+		Rule: []*RoutingRule{domainRule, &ipIfNonMatchRule},
+	})
+	tr = trace(r, "intranet.v2ray.com")
+	assert.String(tr.OutboundTag).Equals("intranet")
+	assert.Int(len(tr.Rules)).Equals(3)
+	assert.Bool(tr.Rules[2].AfterResolution).IsTrue()
+
+	asIsRule := *ipRule
+	asIsRule.DomainStrategy = RoutingRule_AsIs
+	r = newRouter(&Config{
+		DomainStrategy: Config_UseIp,
+		Rule:           []*RoutingRule{&asIsRule},
+	})
+	tr = trace(r, "intranet.v2ray.com")
+	assert.Int(tr.MatchedRule).Equals(-1)
+}
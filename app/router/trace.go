@@ -0,0 +1,157 @@
+package router
+
+import (
+	"context"
+
+	"v2ray.com/core/common/net"
+	"v2ray.com/core/common/protocol"
+	"v2ray.com/core/proxy"
+)
+
+// RouteQuery describes a connection for tracing its routing decision.
+type RouteQuery struct {
+	Destination net.Destination
+	// Source is optional.
+	Source     net.Destination
+	InboundTag string
+	// LocalPort is the port that the inbound receives the connection on. Optional.
+	LocalPort  net.Port
+	Attributes map[string]string
+	User       string
+	// SniffedDomain and SniffedProtocol are optional. If SniffedDomain is set and Destination is an IP, the
+	// domain overrides the destination, as if the inbound enables domain override.
+	SniffedDomain   string
+	SniffedProtocol string
+}
+
+// Context returns the context of the connection, the same as the one the dispatcher passes to the router.
+func (q *RouteQuery) Context() context.Context {
+	ctx := context.Background()
+	if len(q.InboundTag) > 0 {
+		ctx = proxy.ContextWithInboundTag(ctx, q.InboundTag)
+	}
+	if q.Source.IsValid() {
+		ctx = proxy.ContextWithSource(ctx, q.Source)
+	}
+	if q.LocalPort != 0 {
+		ctx = proxy.ContextWithInboundEntryPoint(ctx, net.Destination{
+			Network: q.Destination.Network,
+			Address: net.AnyIP,
+			Port:    q.LocalPort,
+		})
+	}
+	if len(q.Attributes) > 0 {
+		ctx = proxy.ContextWithAttributes(ctx, q.Attributes)
+	}
+	if len(q.User) > 0 {
+		ctx = protocol.ContextWithUser(ctx, &protocol.User{Email: q.User})
+	}
+	dest := q.Destination
+	if len(q.SniffedDomain) > 0 || len(q.SniffedProtocol) > 0 {
+		ctx = proxy.ContextWithSniffingResult(ctx, &proxy.SniffingResult{
+			Protocol: q.SniffedProtocol,
+			Domain:   q.SniffedDomain,
+		})
+		if len(q.SniffedDomain) > 0 && !dest.Address.Family().IsDomain() {
+			dest.Address = net.DomainAddress(q.SniffedDomain)
+		}
+	}
+	return proxy.ContextWithTarget(ctx, dest)
+}
+
+// RuleTrace is the result of evaluating one rule.
+type RuleTrace struct {
+	Index int
+	Tag   string
+	// Reason is why the rule is not taken, or empty if the rule is taken.
+	Reason string
+	// AfterResolution is true if the rule is evaluated with the IPs resolved from the target domain.
+	AfterResolution bool
+}
+
+// Trace records how the router makes the routing decision for a connection.
+type Trace struct {
+	DomainStrategy Config_DomainStrategy
+	// Rules are all rules evaluated, in order.
+	Rules []RuleTrace
+	// Resolved is true if the target domain is resolved for matching IP rules, with ResolvedIPs as the result.
+	Resolved    bool
+	ResolvedIPs []net.Address
+	// MatchedRule is the index of the rule taken, or -1 if no rule is taken and the default outbound is used.
+	MatchedRule int
+	OutboundTag string
+}
+
+func (t *Trace) addRule(rule *Rule, index int, reason string, afterResolution bool) {
+	t.Rules = append(t.Rules, RuleTrace{
+		Index:           index,
+		Tag:             rule.Tag,
+		Reason:          reason,
+		AfterResolution: afterResolution,
+	})
+}
+
+// conditionName returns a short description of the condition for tracing.
+func conditionName(cond Condition) string {
+	switch c := cond.(type) {
+	case *DomainCondition, PlainDomainMatcher, *RegexpDomainMatcher, SubDomainMatcher:
+		return "domain"
+	case *CIDRMatcher:
+		if c.onSource {
+			return "source IP"
+		}
+		return "IP"
+	case *IPv4Matcher:
+		if c.onSource {
+			return "source IP"
+		}
+		return "IP"
+	case *AnyCondition:
+		if len(*c) > 0 {
+			return conditionName((*c)[0])
+		}
+	case *PortMatcher:
+		return "port"
+	case *SourcePortMatcher:
+		return "source port"
+	case *LocalPortMatcher:
+		return "local port"
+	case *AttributeMatcher:
+		return "attributes"
+	case *NetworkMatcher:
+		return "network"
+	case *UserMatcher:
+		return "user"
+	case *InboundTagMatcher:
+		return "inbound tag"
+	case *ProtocolMatcher:
+		return "protocol"
+	case *HTTPMatcher:
+		return "HTTP request"
+	case *TimeMatcher:
+		return "time"
+	}
+	return "condition"
+}
+
+// explainMismatch returns the reason why the condition doesn't match the context.
+func explainMismatch(cond Condition, ctx context.Context) string {
+	if conds, ok := cond.(*ConditionChan); ok {
+		for _, c := range *conds {
+			if !c.Apply(ctx) {
+				return conditionName(c) + " does not match"
+			}
+		}
+	}
+	return conditionName(cond) + " does not match"
+}
+
+// Trace returns how the router would route the connection in the context, without side effects other than
+// DNS queries.
+func (r *Router) Trace(ctx context.Context) *Trace {
+	trace := &Trace{
+		MatchedRule: -1,
+	}
+	r.route(ctx, trace)
+	return trace
+}
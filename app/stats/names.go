@@ -0,0 +1,24 @@
+package stats
+
+// Direction of traffic.
+const (
+	// Uplink is the traffic from client to server.
+	Uplink = "uplink"
+	// Downlink is the traffic from server to client.
+	Downlink = "downlink"
+)
+
+// UserTrafficCounterName returns the name of the traffic counter of the user with the given email.
+func UserTrafficCounterName(email string, direction string) string {
+	return "user>>>" + email + ">>>traffic>>>" + direction
+}
+
+// InboundTrafficCounterName returns the name of the traffic counter of the inbound handler with the given tag.
+func InboundTrafficCounterName(tag string, direction string) string {
+	return "inbound>>>" + tag + ">>>traffic>>>" + direction
+}
+
+// OutboundTrafficCounterName returns the name of the traffic counter of the outbound handler with the given tag.
+func OutboundTrafficCounterName(tag string, direction string) string {
+	return "outbound>>>" + tag + ">>>traffic>>>" + direction
+}
@@ -0,0 +1,126 @@
+// Package stats provides named counters for collecting runtime statistics, such as traffic of users and handlers.
+package stats
+
+//go:generate go run $GOPATH/src/v2ray.com/core/tools/generrorgen/main.go -pkg stats -path App,Stats
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/log"
+	"v2ray.com/core/common"
+)
+
+// Counter is a named counter that is safe for concurrent use.
+type Counter struct {
+	value int64
+}
+
+// Value returns the current value of the counter.
+func (c *Counter) Value() int64 {
+	return atomic.LoadInt64(&c.value)
+}
+
+// Set sets the counter to the given value, and returns the previous value.
+func (c *Counter) Set(newValue int64) int64 {
+	return atomic.SwapInt64(&c.value, newValue)
+}
+
+// Add adds delta to the counter, and returns the new value.
+func (c *Counter) Add(delta int64) int64 {
+	return atomic.AddInt64(&c.value, delta)
+}
+
+// Manager is an application that keeps track of all counters.
+type Manager struct {
+	access   sync.RWMutex
+	counters map[string]*Counter
+}
+
+// NewManager creates a new Manager with the given config.
+func NewManager(ctx context.Context, config *Config) (*Manager, error) {
+	return &Manager{
+		counters: make(map[string]*Counter),
+	}, nil
+}
+
+// Interface implements app.Application.
+func (*Manager) Interface() interface{} {
+	return (*Manager)(nil)
+}
+
+// Start implements app.Application.
+func (*Manager) Start() error {
+	return nil
+}
+
+// Close implements app.Application.
+func (*Manager) Close() {}
+
+// RegisterCounter creates a new counter with the given name. It returns an error if the counter already exists.
+func (m *Manager) RegisterCounter(name string) (*Counter, error) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if _, found := m.counters[name]; found {
+		return nil, newError("Counter ", name, " already registered.")
+	}
+	log.Trace(newError("create new counter ", name).AtDebug())
+	c := new(Counter)
+	m.counters[name] = c
+	return c, nil
+}
+
+// GetCounter returns the counter with the given name, or nil if it doesn't exist.
+func (m *Manager) GetCounter(name string) *Counter {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	return m.counters[name]
+}
+
+// GetOrRegisterCounter returns the counter with the given name, and creates it if it doesn't exist.
+func (m *Manager) GetOrRegisterCounter(name string) *Counter {
+	if c := m.GetCounter(name); c != nil {
+		return c
+	}
+
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if c, found := m.counters[name]; found {
+		return c
+	}
+	c := new(Counter)
+	m.counters[name] = c
+	return c
+}
+
+// Visit calls visitor on each of the counters, until visitor returns false.
+func (m *Manager) Visit(visitor func(string, *Counter) bool) {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	for name, c := range m.counters {
+		if !visitor(name, c) {
+			break
+		}
+	}
+}
+
+// FromSpace returns the Manager in the given space, or nil if the space doesn't have one.
+func FromSpace(space app.Space) *Manager {
+	app := space.GetApplication((*Manager)(nil))
+	if app == nil {
+		return nil
+	}
+	return app.(*Manager)
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return NewManager(ctx, config.(*Config))
+	}))
+}
@@ -0,0 +1,45 @@
+package stats_test
+
+import (
+	"context"
+	"testing"
+
+	. "v2ray.com/core/app/stats"
+	"v2ray.com/core/testing/assert"
+)
+
+func TestCounter(t *testing.T) {
+	assert := assert.On(t)
+
+	c := new(Counter)
+	assert.Int64(c.Add(10)).Equals(10)
+	assert.Int64(c.Add(5)).Equals(15)
+	assert.Int64(c.Set(3)).Equals(15)
+	assert.Int64(c.Value()).Equals(3)
+}
+
+func TestManager(t *testing.T) {
+	assert := assert.On(t)
+
+	m, err := NewManager(context.Background(), &Config{})
+	assert.Error(err).IsNil()
+
+	c, err := m.RegisterCounter("test")
+	assert.Error(err).IsNil()
+	_, err = m.RegisterCounter("test")
+	assert.Error(err).IsNotNil()
+
+	c.Add(10)
+	assert.Int64(m.GetCounter("test").Value()).Equals(10)
+	assert.Bool(m.GetCounter("nonexist") == nil).IsTrue()
+	assert.Pointer(m.GetOrRegisterCounter("test")).Equals(c)
+
+	m.GetOrRegisterCounter("test2").Add(1)
+	names := make(map[string]int64)
+	m.Visit(func(name string, c *Counter) bool {
+		names[name] = c.Value()
+		return true
+	})
+	assert.Int(len(names)).Equals(2)
+	assert.Int64(names["test2"]).Equals(1)
+}
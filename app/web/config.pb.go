@@ -3,6 +3,7 @@ package web
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import v2ray_core_common_net "v2ray.com/core/common/net"
 import v2ray_core_common_serial "v2ray.com/core/common/serial"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -16,6 +17,7 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// FileServer serves static files and directories.
 type FileServer struct {
 	Entry []*FileServer_Entry `protobuf:"bytes,1,rep,name=entry" json:"entry,omitempty"`
 }
@@ -37,7 +39,8 @@ type FileServer_Entry struct {
 	//	*FileServer_Entry_File
 	//	*FileServer_Entry_Directory
 	FileOrDir isFileServer_Entry_FileOrDir `protobuf_oneof:"FileOrDir"`
-	Path      string                       `protobuf:"bytes,3,opt,name=path" json:"path,omitempty"`
+	// URL path that the file or directory is served on. A directory is served on all paths under it.
+	Path string `protobuf:"bytes,3,opt,name=path" json:"path,omitempty"`
 }
 
 func (m *FileServer_Entry) Reset()                    { *m = FileServer_Entry{} }
@@ -153,8 +156,11 @@ func _FileServer_Entry_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// Server is a virtual host.
 type Server struct {
-	Domain   []string                               `protobuf:"bytes,1,rep,name=domain" json:"domain,omitempty"`
+	// Domains that this server serves. A server without any domain serves requests that no other server matches.
+	Domain []string `protobuf:"bytes,1,rep,name=domain" json:"domain,omitempty"`
+	// Settings of this server, such as a FileServer.
 	Settings *v2ray_core_common_serial.TypedMessage `protobuf:"bytes,2,opt,name=settings" json:"settings,omitempty"`
 }
 
@@ -179,6 +185,11 @@ func (m *Server) GetSettings() *v2ray_core_common_serial.TypedMessage {
 
 type Config struct {
 	Server []*Server `protobuf:"bytes,1,rep,name=server" json:"server,omitempty"`
+	// Port that the web server listens on. If it is 0, the web server only serves connections handed over by inbounds,
+	// such as the HTTP proxy with web_fallback.
+	Port uint32 `protobuf:"varint,2,opt,name=port" json:"port,omitempty"`
+	// Address that the web server listens on. Default to 127.0.0.1 if unset.
+	Listen *v2ray_core_common_net.IPOrDomain `protobuf:"bytes,3,opt,name=listen" json:"listen,omitempty"`
 }
 
 func (m *Config) Reset()                    { *m = Config{} }
@@ -193,6 +204,20 @@ func (m *Config) GetServer() []*Server {
 	return nil
 }
 
+func (m *Config) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Config) GetListen() *v2ray_core_common_net.IPOrDomain {
+	if m != nil {
+		return m.Listen
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*FileServer)(nil), "v2ray.core.app.web.FileServer")
 	proto.RegisterType((*FileServer_Entry)(nil), "v2ray.core.app.web.FileServer.Entry")
@@ -203,26 +228,29 @@ func init() {
 func init() { proto.RegisterFile("v2ray.com/core/app/web/config.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 324 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xcf, 0x4a, 0xc3, 0x40,
-	0x10, 0x87, 0x4d, 0xff, 0x04, 0x33, 0xbd, 0x2d, 0x52, 0x42, 0x0f, 0x52, 0xaa, 0x48, 0x4f, 0x1b,
-	0x89, 0x9e, 0xc4, 0x8b, 0x6d, 0x15, 0x2f, 0xa2, 0xac, 0xa2, 0xe0, 0x41, 0xd9, 0xa4, 0x63, 0x5d,
-	0x68, 0xb2, 0xcb, 0x64, 0x69, 0xc9, 0x1b, 0x89, 0x4f, 0x29, 0xd9, 0x44, 0x2b, 0xda, 0x5b, 0x26,
-	0xf3, 0xfd, 0x76, 0x66, 0xbf, 0x85, 0x83, 0x55, 0x4c, 0xb2, 0xe4, 0xa9, 0xce, 0xa2, 0x54, 0x13,
-	0x46, 0xd2, 0x98, 0x68, 0x8d, 0x49, 0x94, 0xea, 0xfc, 0x4d, 0x2d, 0xb8, 0x21, 0x6d, 0x35, 0x63,
-	0xdf, 0x10, 0x21, 0x97, 0xc6, 0xf0, 0x35, 0x26, 0x83, 0xe3, 0x3f, 0xc1, 0x54, 0x67, 0x99, 0xce,
-	0xa3, 0x02, 0x49, 0xc9, 0x65, 0x64, 0x4b, 0x83, 0xf3, 0xd7, 0x0c, 0x8b, 0x42, 0x2e, 0xb0, 0x3e,
-	0x65, 0xf4, 0xe1, 0x01, 0x5c, 0xa9, 0x25, 0xde, 0x23, 0xad, 0x90, 0xd8, 0x19, 0x74, 0x31, 0xb7,
-	0x54, 0x86, 0xde, 0xb0, 0x3d, 0xee, 0xc5, 0x87, 0xfc, 0xff, 0x10, 0xbe, 0xc1, 0xf9, 0x65, 0xc5,
-	0x8a, 0x3a, 0x32, 0x78, 0x81, 0xae, 0xab, 0xd9, 0x1e, 0x74, 0x2a, 0x26, 0xf4, 0x86, 0xde, 0x38,
-	0xb8, 0xde, 0x11, 0xae, 0x62, 0xfb, 0x10, 0xcc, 0x14, 0x61, 0x6a, 0x35, 0x95, 0x61, 0xab, 0x69,
-	0x6d, 0x7e, 0x31, 0x06, 0x1d, 0x23, 0xed, 0x7b, 0xd8, 0xae, 0x5a, 0xc2, 0x7d, 0x4f, 0x7a, 0x10,
-	0x54, 0xd9, 0x5b, 0x9a, 0x29, 0x1a, 0xcd, 0xc1, 0x6f, 0xb6, 0xec, 0x83, 0x3f, 0xd7, 0x99, 0x54,
-	0xb9, 0x5b, 0x33, 0x10, 0x4d, 0xc5, 0x26, 0xb0, 0x5b, 0xa0, 0xb5, 0x2a, 0x5f, 0x14, 0x6e, 0x42,
-	0x2f, 0x3e, 0xfa, 0x7d, 0x81, 0xda, 0x06, 0xaf, 0x6d, 0xf0, 0x87, 0xca, 0xc6, 0x4d, 0x2d, 0x43,
-	0xfc, 0xe4, 0x46, 0xe7, 0xe0, 0x4f, 0x9d, 0x66, 0x16, 0x83, 0x5f, 0xb8, 0x79, 0x8d, 0x8c, 0xc1,
-	0x36, 0x19, 0xf5, 0x46, 0xa2, 0x21, 0x27, 0xa7, 0xd0, 0x4f, 0x75, 0xb6, 0x05, 0xbc, 0xf3, 0x9e,
-	0xdb, 0x6b, 0x4c, 0x3e, 0x5b, 0xec, 0x31, 0x16, 0xb2, 0xe4, 0xd3, 0xaa, 0x77, 0x61, 0x0c, 0x7f,
-	0xc2, 0x24, 0xf1, 0xdd, 0x5b, 0x9c, 0x7c, 0x05, 0x00, 0x00, 0xff, 0xff, 0xf9, 0x2a, 0xe1, 0x59,
-	0xf8, 0x01, 0x00, 0x00,
+	// 373 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x51, 0xcd, 0x8e, 0xd3, 0x30,
+	0x10, 0x26, 0xfd, 0x89, 0xc8, 0x44, 0x5c, 0x2c, 0x54, 0x45, 0x39, 0xa0, 0x52, 0x10, 0xf4, 0xe4,
+	0xa0, 0xc0, 0x05, 0x6e, 0xb4, 0x05, 0xc1, 0x01, 0xb5, 0x32, 0x08, 0x24, 0x0e, 0x20, 0x27, 0x19,
+	0x8a, 0xa5, 0xc6, 0xb6, 0x6c, 0xab, 0x55, 0x9e, 0x61, 0x5f, 0x64, 0xb5, 0x4f, 0xb9, 0x8a, 0x93,
+	0xdd, 0xae, 0x76, 0x73, 0xf3, 0x78, 0xbe, 0x4f, 0xdf, 0xcf, 0xc0, 0x8b, 0x63, 0x6e, 0x78, 0x43,
+	0x4b, 0x55, 0x67, 0xa5, 0x32, 0x98, 0x71, 0xad, 0xb3, 0x13, 0x16, 0x59, 0xa9, 0xe4, 0x3f, 0xb1,
+	0xa7, 0xda, 0x28, 0xa7, 0x08, 0xb9, 0x01, 0x19, 0xa4, 0x5c, 0x6b, 0x7a, 0xc2, 0x22, 0x7d, 0x7d,
+	0x8f, 0x58, 0xaa, 0xba, 0x56, 0x32, 0x93, 0xe8, 0x32, 0x5e, 0x55, 0x06, 0xad, 0xed, 0xc8, 0xe9,
+	0x9b, 0x61, 0xa0, 0x45, 0x23, 0xf8, 0x21, 0x73, 0x8d, 0xc6, 0xea, 0x6f, 0x8d, 0xd6, 0xf2, 0x3d,
+	0x76, 0x8c, 0xc5, 0x65, 0x00, 0xf0, 0x59, 0x1c, 0xf0, 0x3b, 0x9a, 0x23, 0x1a, 0xf2, 0x01, 0xa6,
+	0x28, 0x9d, 0x69, 0x92, 0x60, 0x3e, 0x5e, 0xc6, 0xf9, 0x4b, 0xfa, 0xd0, 0x0d, 0x3d, 0xc3, 0xe9,
+	0xa7, 0x16, 0xcb, 0x3a, 0x4a, 0xfa, 0x07, 0xa6, 0x7e, 0x26, 0x4f, 0x61, 0xd2, 0x62, 0x92, 0x60,
+	0x1e, 0x2c, 0xa3, 0x2f, 0x8f, 0x98, 0x9f, 0xc8, 0x33, 0x88, 0x36, 0xc2, 0x60, 0xe9, 0x94, 0x69,
+	0x92, 0x51, 0xbf, 0x3a, 0x7f, 0x11, 0x02, 0x13, 0xcd, 0xdd, 0xff, 0x64, 0xdc, 0xae, 0x98, 0x7f,
+	0xaf, 0x62, 0x88, 0x5a, 0xee, 0xd6, 0x6c, 0x84, 0x59, 0x54, 0x10, 0xf6, 0x2e, 0x67, 0x10, 0x56,
+	0xaa, 0xe6, 0x42, 0x7a, 0x9b, 0x11, 0xeb, 0x27, 0xb2, 0x82, 0xc7, 0x16, 0x9d, 0x13, 0x72, 0x6f,
+	0xbd, 0x42, 0x9c, 0xbf, 0xba, 0x1b, 0xa0, 0x6b, 0x83, 0x76, 0x6d, 0xd0, 0x1f, 0x6d, 0x1b, 0xdf,
+	0xba, 0x32, 0xd8, 0x2d, 0x6f, 0x71, 0x11, 0x40, 0xb8, 0xf6, 0x07, 0x21, 0x39, 0x84, 0xd6, 0x0b,
+	0xf6, 0x6d, 0xa4, 0x43, 0x6d, 0x74, 0x96, 0x58, 0x8f, 0xf4, 0x29, 0x94, 0x71, 0x5e, 0xfe, 0x09,
+	0xf3, 0x6f, 0xf2, 0x1e, 0xc2, 0x83, 0xb0, 0x0e, 0xa5, 0xcf, 0x16, 0xe7, 0xcf, 0x07, 0x4c, 0x49,
+	0x74, 0xf4, 0xeb, 0x6e, 0x6b, 0x36, 0x3e, 0x09, 0xeb, 0x09, 0xab, 0x77, 0x30, 0x2b, 0x55, 0x3d,
+	0xa0, 0xbb, 0x0b, 0x7e, 0x8f, 0x4f, 0x58, 0x5c, 0x8d, 0xc8, 0xcf, 0x9c, 0xf1, 0x86, 0xae, 0xdb,
+	0xdd, 0x47, 0xad, 0xe9, 0x2f, 0x2c, 0x8a, 0xd0, 0xdf, 0xf6, 0xed, 0xf5, 0x00, 0x94, 0xcf, 0x54,
+	0x32, 0x71, 0x02, 0x00, 0x00,
 }
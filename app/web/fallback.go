@@ -0,0 +1,83 @@
+package web
+
+import (
+	"context"
+	"net"
+	"sync"
+)
+
+// connListener is a net.Listener that accepts connections handed over by inbounds.
+type connListener struct {
+	conns chan net.Conn
+	done  chan struct{}
+	once  sync.Once
+}
+
+func newConnListener() *connListener {
+	return &connListener{
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+}
+
+// Accept implements net.Listener.
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, newError("listener closed")
+	}
+}
+
+// Close implements net.Listener.
+func (l *connListener) Close() error {
+	l.once.Do(func() {
+		close(l.done)
+	})
+	return nil
+}
+
+// Addr implements net.Listener.
+func (l *connListener) Addr() net.Addr {
+	return &net.TCPAddr{
+		IP: net.IPv4zero,
+	}
+}
+
+// fallbackConn is a connection handed over by an inbound. It notifies when the web server closes it.
+type fallbackConn struct {
+	net.Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+func (c *fallbackConn) Close() error {
+	c.once.Do(func() {
+		close(c.closed)
+	})
+	return c.Conn.Close()
+}
+
+// ServeConn serves HTTP requests on a connection handed over by an inbound, for example from clients that are not
+// proxy clients, so that the proxy looks like a web site to them. Any data already read from the connection must be
+// readable from conn again. It returns when the web server closes the connection, or the context is done.
+func (s *WebServer) ServeConn(ctx context.Context, conn net.Conn) error {
+	c := &fallbackConn{
+		Conn:   conn,
+		closed: make(chan struct{}),
+	}
+	select {
+	case s.fallback.conns <- c:
+	case <-s.fallback.done:
+		return newError("web server closed")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-c.closed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -1 +1,39 @@
 package web
+
+import (
+	"net/http"
+	"strings"
+)
+
+// NewFileServer creates an http.Handler that serves the entries in the given config.
+func NewFileServer(config *FileServer) (http.Handler, error) {
+	mux := http.NewServeMux()
+	paths := make(map[string]bool)
+	for _, entry := range config.Entry {
+		path := entry.Path
+		if !strings.HasPrefix(path, "/") {
+			return nil, newError("path must start with '/': ", path)
+		}
+		var handler http.Handler
+		switch fileOrDir := entry.FileOrDir.(type) {
+		case *FileServer_Entry_File:
+			file := fileOrDir.File
+			handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.ServeFile(w, r, file)
+			})
+		case *FileServer_Entry_Directory:
+			if !strings.HasSuffix(path, "/") {
+				path += "/"
+			}
+			handler = http.StripPrefix(path, http.FileServer(http.Dir(fileOrDir.Directory)))
+		default:
+			return nil, newError("neither file nor directory is set for path: ", path)
+		}
+		if paths[path] {
+			return nil, newError("duplicate path: ", path)
+		}
+		paths[path] = true
+		mux.Handle(path, handler)
+	}
+	return mux, nil
+}
@@ -1,5 +1,152 @@
+// Package web provides a web server that serves static files, for example as a decoy site or for distributing PAC files.
 package web
 
-type WebServer interface {
-	Handle()
+//go:generate go run $GOPATH/src/v2ray.com/core/tools/generrorgen/main.go -pkg web -path App,Web
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/log"
+	"v2ray.com/core/common"
+	v2net "v2ray.com/core/common/net"
+)
+
+const (
+	readHeaderTimeout = time.Second * 10
+	readTimeout       = time.Second * 30
+	idleTimeout       = time.Second * 120
+)
+
+// WebServer is an application that serves HTTP requests by virtual hosts.
+type WebServer struct {
+	config         *Config
+	hosts          map[string]http.Handler
+	defaultHandler http.Handler
+	server         *http.Server
+	fallback       *connListener
+}
+
+// NewWebServer creates a new WebServer with the given config.
+func NewWebServer(ctx context.Context, config *Config) (*WebServer, error) {
+	s := &WebServer{
+		config:   config,
+		hosts:    make(map[string]http.Handler),
+		fallback: newConnListener(),
+	}
+
+	for _, server := range config.Server {
+		if server.Settings == nil {
+			return nil, newError("settings is not specified for server: ", server.Domain)
+		}
+		settings, err := server.Settings.GetInstance()
+		if err != nil {
+			return nil, err
+		}
+		var handler http.Handler
+		switch settings := settings.(type) {
+		case *FileServer:
+			h, err := NewFileServer(settings)
+			if err != nil {
+				return nil, newError("failed to create file server").Base(err)
+			}
+			handler = h
+		default:
+			return nil, newError("unknown server settings: ", server.Settings.Type)
+		}
+
+		if len(server.Domain) == 0 {
+			if s.defaultHandler == nil {
+				s.defaultHandler = handler
+			}
+			continue
+		}
+		for _, domain := range server.Domain {
+			s.hosts[strings.ToLower(domain)] = handler
+		}
+	}
+
+	s.server = &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler. Requests are handled by the server whose domain matches the host of the request.
+func (s *WebServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	handler, found := s.hosts[strings.ToLower(host)]
+	if !found {
+		handler = s.defaultHandler
+	}
+	if handler == nil {
+		http.NotFound(w, r)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
+
+// Interface implements app.Application.
+func (*WebServer) Interface() interface{} {
+	return (*WebServer)(nil)
+}
+
+// Start implements app.Application.
+func (s *WebServer) Start() error {
+	go s.serve(s.fallback)
+	if s.config.Port == 0 {
+		return nil
+	}
+
+	address := v2net.LocalHostIP
+	if s.config.Listen != nil {
+		address = s.config.Listen.AsAddress()
+	}
+	listener, err := net.Listen("tcp", v2net.TCPDestination(address, v2net.Port(s.config.Port)).NetAddr())
+	if err != nil {
+		return newError("failed to listen on ", address, ":", s.config.Port).Base(err)
+	}
+
+	go s.serve(listener)
+
+	log.Trace(newError("web server listening on ", listener.Addr()).AtWarning())
+	return nil
+}
+
+func (s *WebServer) serve(listener net.Listener) {
+	if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		log.Trace(newError("web server stopped").Base(err).AtWarning())
+	}
+}
+
+// Close implements app.Application.
+func (s *WebServer) Close() {
+	s.server.Close()
+	s.fallback.Close()
+}
+
+// FromSpace returns the WebServer in the given space, or nil if it doesn't exist.
+func FromSpace(space app.Space) *WebServer {
+	app := space.GetApplication((*WebServer)(nil))
+	if app == nil {
+		return nil
+	}
+	return app.(*WebServer)
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return NewWebServer(ctx, config.(*Config))
+	}))
 }
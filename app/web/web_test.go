@@ -0,0 +1,190 @@
+package web_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "v2ray.com/core/app/web"
+	"v2ray.com/core/common"
+	v2net "v2ray.com/core/common/net"
+	"v2ray.com/core/common/serial"
+	"v2ray.com/core/testing/assert"
+)
+
+func get(handler http.Handler, host string, path string) (int, string) {
+	request := httptest.NewRequest("GET", "http://"+host+path, nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder.Code, recorder.Body.String()
+}
+
+func TestWebServer(t *testing.T) {
+	assert := assert.On(t)
+
+	dir, err := ioutil.TempDir("", "v2ray-web")
+	assert.Error(err).IsNil()
+	defer os.RemoveAll(dir)
+
+	common.Must(ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte("decoy"), 0644))
+	common.Must(ioutil.WriteFile(filepath.Join(dir, "proxy.pac"), []byte("pac"), 0644))
+
+	server, err := NewWebServer(context.Background(), &Config{
+		Server: []*Server{
+			{
+				Domain: []string{"pac.v2ray.com"},
+				Settings: serial.ToTypedMessage(&FileServer{
+					Entry: []*FileServer_Entry{
+						{
+							Path:      "/proxy.pac",
+							FileOrDir: &FileServer_Entry_File{File: filepath.Join(dir, "proxy.pac")},
+						},
+					},
+				}),
+			},
+			{
+				Settings: serial.ToTypedMessage(&FileServer{
+					Entry: []*FileServer_Entry{
+						{
+							Path:      "/",
+							FileOrDir: &FileServer_Entry_Directory{Directory: dir},
+						},
+					},
+				}),
+			},
+		},
+	})
+	assert.Error(err).IsNil()
+
+	code, body := get(server, "pac.v2ray.com:8080", "/proxy.pac")
+	assert.Int(code).Equals(200)
+	assert.String(body).Equals("pac")
+
+	code, _ = get(server, "pac.v2ray.com", "/index.html")
+	assert.Int(code).Equals(404)
+
+	code, body = get(server, "www.v2ray.com", "/")
+	assert.Int(code).Equals(200)
+	assert.String(body).Equals("decoy")
+}
+
+func TestWebServerListen(t *testing.T) {
+	assert := assert.On(t)
+
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	common.Must(err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	dir, err := ioutil.TempDir("", "v2ray-web")
+	assert.Error(err).IsNil()
+	defer os.RemoveAll(dir)
+	common.Must(ioutil.WriteFile(filepath.Join(dir, "client.json"), []byte("{}"), 0644))
+
+	server, err := NewWebServer(context.Background(), &Config{
+		Port: uint32(port),
+		Server: []*Server{
+			{
+				Settings: serial.ToTypedMessage(&FileServer{
+					Entry: []*FileServer_Entry{
+						{
+							Path:      "/configs",
+							FileOrDir: &FileServer_Entry_Directory{Directory: dir},
+						},
+					},
+				}),
+			},
+		},
+	})
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	resp, err := http.Get("http://" + v2net.TCPDestination(v2net.LocalHostIP, v2net.Port(port)).NetAddr() + "/configs/client.json")
+	assert.Error(err).IsNil()
+	defer resp.Body.Close()
+	assert.Int(resp.StatusCode).Equals(200)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.Error(err).IsNil()
+	assert.String(string(body)).Equals("{}")
+}
+
+func TestInvalidFileServer(t *testing.T) {
+	assert := assert.On(t)
+
+	_, err := NewFileServer(&FileServer{
+		Entry: []*FileServer_Entry{
+			{
+				Path:      "relative",
+				FileOrDir: &FileServer_Entry_File{File: "file"},
+			},
+		},
+	})
+	assert.Error(err).IsNotNil()
+}
+
+func TestDuplicateFilePath(t *testing.T) {
+	assert := assert.On(t)
+
+	_, err := NewFileServer(&FileServer{
+		Entry: []*FileServer_Entry{
+			{
+				Path:      "/a/",
+				FileOrDir: &FileServer_Entry_File{File: "/tmp/a"},
+			},
+			{
+				Path:      "/a",
+				FileOrDir: &FileServer_Entry_Directory{Directory: "/tmp"},
+			},
+		},
+	})
+	assert.Error(err).IsNotNil()
+	assert.String(err.Error()).Contains("duplicate path")
+}
+
+func TestServeConn(t *testing.T) {
+	assert := assert.On(t)
+
+	dir, err := ioutil.TempDir("", "v2ray-web")
+	assert.Error(err).IsNil()
+	defer os.RemoveAll(dir)
+	common.Must(ioutil.WriteFile(filepath.Join(dir, "decoy.txt"), []byte("decoy"), 0644))
+
+	server, err := NewWebServer(context.Background(), &Config{
+		Server: []*Server{
+			{
+				Settings: serial.ToTypedMessage(&FileServer{
+					Entry: []*FileServer_Entry{
+						{
+							Path:      "/",
+							FileOrDir: &FileServer_Entry_Directory{Directory: dir},
+						},
+					},
+				}),
+			},
+		},
+	})
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	clientConn, serverConn := net.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- server.ServeConn(context.Background(), serverConn)
+	}()
+
+	_, err = clientConn.Write([]byte("GET /decoy.txt HTTP/1.1\r\nHost: www.v2ray.com\r\nConnection: close\r\n\r\n"))
+	assert.Error(err).IsNil()
+	response, err := ioutil.ReadAll(clientConn)
+	assert.Error(err).IsNil()
+	assert.Bool(strings.HasPrefix(string(response), "HTTP/1.1 200 OK")).IsTrue()
+	assert.Bool(strings.HasSuffix(string(response), "decoy")).IsTrue()
+	assert.Error(<-served).IsNil()
+}
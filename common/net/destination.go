@@ -2,6 +2,7 @@ package net
 
 import (
 	"net"
+	"strings"
 )
 
 // Destination represents a network destination including address and protocol (tcp / udp).
@@ -23,6 +24,32 @@ func DestinationFromAddr(addr net.Addr) Destination {
 	}
 }
 
+// ParseDestination parses a destination in the form of "tcp:host:port", "udp:host:port" or "host:port".
+// The network is TCP if not specified.
+func ParseDestination(dest string) (Destination, error) {
+	network := Network_TCP
+	switch {
+	case strings.HasPrefix(dest, "tcp:"):
+		dest = dest[4:]
+	case strings.HasPrefix(dest, "udp:"):
+		network = Network_UDP
+		dest = dest[4:]
+	}
+	host, portStr, err := net.SplitHostPort(dest)
+	if err != nil {
+		return Destination{}, newError("invalid destination: ", dest).Base(err)
+	}
+	port, err := PortFromString(portStr)
+	if err != nil {
+		return Destination{}, err
+	}
+	return Destination{
+		Network: network,
+		Address: ParseAddress(host),
+		Port:    port,
+	}, nil
+}
+
 // TCPDestination creates a TCP destination with given address
 func TCPDestination(address Address, port Port) Destination {
 	return Destination{
@@ -24,3 +24,24 @@ func TestUDPDestination(t *testing.T) {
 	assert.Destination(dest).IsUDP()
 	assert.Destination(dest).EqualsString("udp:[2001:4860:4860::8888]:53")
 }
+
+func TestParseDestination(t *testing.T) {
+	assert := assert.On(t)
+
+	cases := []struct {
+		input  string
+		output string
+	}{
+		{"tcp:1.2.3.4:80", "tcp:1.2.3.4:80"},
+		{"udp:[2001:4860:4860::8888]:53", "udp:[2001:4860:4860::8888]:53"},
+		{"v2ray.com:443", "tcp:v2ray.com:443"},
+	}
+	for _, test := range cases {
+		dest, err := ParseDestination(test.input)
+		assert.Error(err).IsNil()
+		assert.Destination(dest).EqualsString(test.output)
+	}
+
+	_, err := ParseDestination("tcp:v2ray.com")
+	assert.Error(err).IsNotNil()
+}
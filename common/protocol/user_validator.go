@@ -3,4 +3,6 @@ package protocol
 type UserValidator interface {
 	Add(user *User) error
 	Get(timeHash []byte) (*User, Timestamp, bool)
+	// Remove removes the user with the given email. It returns false if no such user exists.
+	Remove(email string) bool
 }
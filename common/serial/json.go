@@ -0,0 +1,167 @@
+package serial
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// MarshalJSON encodes the message in canonical protobuf JSON. Every TypedMessage in the message tree
+// is expanded, i.e., its value is written as a JSON object of the actual message, instead of base64 encoded bytes.
+func MarshalJSON(message proto.Message) ([]byte, error) {
+	tree, err := toJSONTree(message)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(tree, "", "  ")
+}
+
+// UnmarshalJSON decodes the message from JSON produced by MarshalJSON.
+func UnmarshalJSON(input io.Reader, message proto.Message) error {
+	var tree interface{}
+	decoder := json.NewDecoder(input)
+	decoder.UseNumber()
+	if err := decoder.Decode(&tree); err != nil {
+		return err
+	}
+	tree, err := compactTypedMessages(tree)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	return jsonpb.Unmarshal(bytes.NewReader(data), message)
+}
+
+func toJSONTree(message proto.Message) (interface{}, error) {
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	str, err := marshaler.MarshalToString(message)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(str)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return expandTypedMessages(tree)
+}
+
+// typedMessageFields returns the type and value of a JSON object, if the object looks like a TypedMessage.
+func typedMessageFields(obj map[string]interface{}) (string, interface{}, bool) {
+	t, ok := obj["type"].(string)
+	if !ok || len(obj) > 2 {
+		return "", nil, false
+	}
+	value, found := obj["value"]
+	if len(obj) == 2 && !found {
+		return "", nil, false
+	}
+	if proto.MessageType(t) == nil {
+		return "", nil, false
+	}
+	return t, value, true
+}
+
+func expandTypedMessages(tree interface{}) (interface{}, error) {
+	switch node := tree.(type) {
+	case map[string]interface{}:
+		if t, value, ok := typedMessageFields(node); ok {
+			encoded, isString := value.(string)
+			if value == nil || isString {
+				data, err := base64.StdEncoding.DecodeString(encoded)
+				if err != nil {
+					return nil, err
+				}
+				instance, err := (&TypedMessage{Type: t, Value: data}).GetInstance()
+				if err != nil {
+					return nil, err
+				}
+				expanded, err := toJSONTree(instance)
+				if err != nil {
+					return nil, err
+				}
+				return map[string]interface{}{
+					"type":  t,
+					"value": expanded,
+				}, nil
+			}
+		}
+		for k, v := range node {
+			expanded, err := expandTypedMessages(v)
+			if err != nil {
+				return nil, err
+			}
+			node[k] = expanded
+		}
+		return node, nil
+	case []interface{}:
+		for i, v := range node {
+			expanded, err := expandTypedMessages(v)
+			if err != nil {
+				return nil, err
+			}
+			node[i] = expanded
+		}
+		return node, nil
+	default:
+		return tree, nil
+	}
+}
+
+func compactTypedMessages(tree interface{}) (interface{}, error) {
+	switch node := tree.(type) {
+	case map[string]interface{}:
+		for k, v := range node {
+			compacted, err := compactTypedMessages(v)
+			if err != nil {
+				return nil, err
+			}
+			node[k] = compacted
+		}
+		if t, value, ok := typedMessageFields(node); ok {
+			if obj, isObject := value.(map[string]interface{}); isObject {
+				instance, err := GetInstance(t)
+				if err != nil {
+					return nil, err
+				}
+				message, ok := instance.(proto.Message)
+				if !ok {
+					return nil, errors.New("Serial: Not a protobuf message: " + t)
+				}
+				data, err := json.Marshal(obj)
+				if err != nil {
+					return nil, err
+				}
+				if err := jsonpb.Unmarshal(bytes.NewReader(data), message); err != nil {
+					return nil, err
+				}
+				encoded, err := proto.Marshal(message)
+				if err != nil {
+					return nil, err
+				}
+				node["value"] = base64.StdEncoding.EncodeToString(encoded)
+			}
+		}
+		return node, nil
+	case []interface{}:
+		for i, v := range node {
+			compacted, err := compactTypedMessages(v)
+			if err != nil {
+				return nil, err
+			}
+			node[i] = compacted
+		}
+		return node, nil
+	default:
+		return tree, nil
+	}
+}
@@ -0,0 +1,98 @@
+package serial
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+var typedPlaceholder = regexp.MustCompile(`(?m)^( *)value: "@@typed([0-9]+)@@"$`)
+
+// MarshalText encodes the message in protobuf text format. Every TypedMessage in the message tree is expanded,
+// in the same way as MarshalJSON. The output is meant for human review, and can't be parsed back by proto.UnmarshalText.
+func MarshalText(message proto.Message) (string, error) {
+	message = proto.Clone(message)
+	var expanded []string
+	var walkErr error
+	walkTypedMessages(reflect.ValueOf(message), func(tm *TypedMessage) {
+		if walkErr != nil {
+			return
+		}
+		instance, err := tm.GetInstance()
+		if err != nil {
+			walkErr = err
+			return
+		}
+		text, err := MarshalText(instance)
+		if err != nil {
+			walkErr = err
+			return
+		}
+		tm.Value = []byte(fmt.Sprintf("@@typed%d@@", len(expanded)))
+		expanded = append(expanded, text)
+	})
+	if walkErr != nil {
+		return "", walkErr
+	}
+
+	text := proto.MarshalTextString(message)
+	return typedPlaceholder.ReplaceAllStringFunc(text, func(line string) string {
+		match := typedPlaceholder.FindStringSubmatch(line)
+		indent := match[1]
+		idx, _ := strconv.Atoi(match[2])
+		body := strings.TrimRight(expanded[idx], "\n")
+		if len(body) == 0 {
+			return indent + "value: <>"
+		}
+		lines := strings.Split(body, "\n")
+		for i := range lines {
+			lines[i] = indent + "  " + lines[i]
+		}
+		return indent + "value: <\n" + strings.Join(lines, "\n") + "\n" + indent + ">"
+	}), nil
+}
+
+var typedMessageType = reflect.TypeOf((*TypedMessage)(nil))
+
+// walkTypedMessages calls f on every TypedMessage in v, excluding those inside other TypedMessages.
+func walkTypedMessages(v reflect.Value, f func(*TypedMessage)) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if v.Type() == typedMessageType {
+			f(v.Interface().(*TypedMessage))
+			return
+		}
+		walkTypedMessages(v.Elem(), f)
+	case reflect.Interface:
+		if !v.IsNil() {
+			walkTypedMessages(v.Elem(), f)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := t.Field(i)
+			if len(field.PkgPath) > 0 || strings.HasPrefix(field.Name, "XXX_") {
+				continue
+			}
+			walkTypedMessages(v.Field(i), f)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			walkTypedMessages(v.Index(i), f)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			walkTypedMessages(v.MapIndex(key), f)
+		}
+	}
+}
@@ -24,15 +24,31 @@ type ConfigFormat int32
 const (
 	ConfigFormat_Protobuf ConfigFormat = 0
 	ConfigFormat_JSON     ConfigFormat = 1
+	// Canonical JSON mapping of the protobuf config, with typed messages expanded.
+	ConfigFormat_ProtobufJSON ConfigFormat = 2
+	// Protobuf text format, with typed messages expanded. Output only.
+	ConfigFormat_ProtobufText ConfigFormat = 3
+	// YAML equivalent of the JSON config.
+	ConfigFormat_YAML ConfigFormat = 4
+	// TOML equivalent of the JSON config.
+	ConfigFormat_TOML ConfigFormat = 5
 )
 
 var ConfigFormat_name = map[int32]string{
 	0: "Protobuf",
 	1: "JSON",
+	2: "ProtobufJSON",
+	3: "ProtobufText",
+	4: "YAML",
+	5: "TOML",
 }
 var ConfigFormat_value = map[string]int32{
-	"Protobuf": 0,
-	"JSON":     1,
+	"Protobuf":     0,
+	"JSON":         1,
+	"ProtobufJSON": 2,
+	"ProtobufText": 3,
+	"YAML":         4,
+	"TOML":         5,
 }
 
 func (x ConfigFormat) String() string {
@@ -103,26 +119,28 @@ func init() {
 func init() { proto.RegisterFile("v2ray.com/core/config.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 336 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0xcd, 0x4a, 0xf3, 0x40,
-	0x18, 0x85, 0xbf, 0xb4, 0xf9, 0x6a, 0xfa, 0xb6, 0x48, 0x99, 0x55, 0xa8, 0x2e, 0x8a, 0xd0, 0x52,
-	0x04, 0x27, 0x12, 0x37, 0xe2, 0xd2, 0x8a, 0x3f, 0x05, 0x6d, 0xa9, 0xe2, 0xc2, 0x8d, 0x4c, 0xd3,
-	0x69, 0x09, 0x74, 0xe6, 0x1d, 0x26, 0x53, 0x69, 0x6e, 0xc9, 0x9b, 0xf2, 0x56, 0x24, 0x99, 0xfe,
-	0xa5, 0xe2, 0xc2, 0x55, 0x20, 0x73, 0x9e, 0xe7, 0x4c, 0x4e, 0xe0, 0xe8, 0x23, 0xd4, 0x2c, 0xa5,
-	0x11, 0x8a, 0x20, 0x42, 0xcd, 0x83, 0x08, 0xe5, 0x34, 0x9e, 0x51, 0xa5, 0xd1, 0x20, 0x81, 0xf5,
-	0xa1, 0xe6, 0xcd, 0xee, 0x5e, 0x90, 0x29, 0x15, 0x28, 0x8d, 0xcb, 0x54, 0x30, 0x59, 0xa0, 0x9a,
-	0xe7, 0x3f, 0x94, 0x42, 0xa0, 0x0c, 0x12, 0xae, 0x63, 0x36, 0x0f, 0x4c, 0xaa, 0xf8, 0xe4, 0x5d,
-	0xf0, 0x24, 0x61, 0x33, 0xbe, 0x22, 0xda, 0x7b, 0x84, 0xd1, 0x4c, 0x26, 0x0a, 0xb5, 0x29, 0x88,
-	0x4f, 0xbe, 0x4a, 0x50, 0xe9, 0xe5, 0x2f, 0xc8, 0x1d, 0x1c, 0xc4, 0x72, 0x8c, 0x0b, 0x39, 0xf1,
-	0x9d, 0x56, 0xb9, 0x5b, 0x0b, 0xcf, 0xe8, 0xf6, 0xae, 0x94, 0x29, 0x45, 0xd7, 0x77, 0xa3, 0x0f,
-	0x36, 0x77, 0xcf, 0xe4, 0x64, 0xce, 0xb5, 0xe5, 0x47, 0x6b, 0x9a, 0xf4, 0xc1, 0xc3, 0x85, 0xb1,
-	0xa6, 0x52, 0x6e, 0xa2, 0xbf, 0x9a, 0x06, 0xab, 0x60, 0x51, 0xb5, 0xe1, 0xc9, 0x25, 0x94, 0x99,
-	0x52, 0xbe, 0x9b, 0x6b, 0x3a, 0xbb, 0x1a, 0x3b, 0x01, 0xb5, 0x13, 0xd0, 0x97, 0x6c, 0x82, 0x47,
-	0xbb, 0xc0, 0x28, 0x43, 0xc8, 0x15, 0x54, 0x37, 0xdf, 0xec, 0xff, 0x6f, 0x39, 0xdd, 0x5a, 0x78,
-	0xbc, 0xcb, 0x6f, 0x0e, 0xe9, 0xaa, 0x74, 0x1b, 0x27, 0x37, 0x50, 0xe5, 0x4b, 0xc3, 0x65, 0x12,
-	0xa3, 0xf4, 0x2b, 0x7f, 0xea, 0xde, 0x82, 0x7d, 0xd7, 0x2b, 0x37, 0xdc, 0xd3, 0x0e, 0xd4, 0x6d,
-	0xc1, 0x2d, 0x6a, 0xc1, 0x0c, 0xa9, 0x83, 0x37, 0xcc, 0xa6, 0x1f, 0x2f, 0xa6, 0x8d, 0x7f, 0xc4,
-	0x03, 0xb7, 0xff, 0x3c, 0x78, 0x6a, 0x38, 0xd7, 0x6d, 0x38, 0x8c, 0x50, 0xec, 0xb4, 0x0c, 0x9d,
-	0x37, 0x37, 0x7b, 0x7e, 0x96, 0xe0, 0x35, 0x1c, 0xb1, 0x94, 0xf6, 0x50, 0xf3, 0x71, 0x25, 0xff,
-	0x6f, 0x17, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x62, 0x93, 0x6d, 0x78, 0x65, 0x02, 0x00, 0x00,
+	// 364 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0xcd, 0x6a, 0xea, 0x40,
+	0x14, 0xc7, 0x6f, 0x3e, 0xf4, 0xc6, 0x51, 0x2e, 0x61, 0x56, 0xc1, 0x7b, 0x17, 0x72, 0xc1, 0x22,
+	0x85, 0x4e, 0x4a, 0xba, 0x29, 0xdd, 0xb5, 0x96, 0x7e, 0x88, 0x56, 0xb1, 0x52, 0x68, 0x17, 0x2d,
+	0x63, 0x1c, 0x25, 0x60, 0xe6, 0x0c, 0x93, 0x49, 0x31, 0xaf, 0xd4, 0x97, 0xea, 0xab, 0x94, 0x24,
+	0x46, 0xa3, 0x6d, 0x17, 0x5d, 0x25, 0x9c, 0x73, 0x7e, 0xbf, 0x73, 0xf2, 0x0f, 0xfa, 0xfb, 0xea,
+	0x49, 0x9a, 0x10, 0x1f, 0x42, 0xd7, 0x07, 0xc9, 0x5c, 0x1f, 0xf8, 0x3c, 0x58, 0x10, 0x21, 0x41,
+	0x01, 0x46, 0x45, 0x53, 0xb2, 0x66, 0x67, 0x6f, 0x90, 0x0a, 0xe1, 0x0a, 0x09, 0xab, 0x24, 0xa4,
+	0x7c, 0x87, 0x6a, 0x1e, 0x7f, 0x52, 0x86, 0x21, 0x70, 0x37, 0x62, 0x32, 0xa0, 0x4b, 0x57, 0x25,
+	0x82, 0xcd, 0x5e, 0x42, 0x16, 0x45, 0x74, 0xc1, 0xd6, 0x44, 0x7b, 0x8f, 0x50, 0x92, 0xf2, 0x48,
+	0x80, 0x54, 0x3b, 0xe2, 0xff, 0xef, 0x3a, 0xaa, 0x76, 0xb3, 0x02, 0xbe, 0x46, 0xbf, 0x03, 0x3e,
+	0x85, 0x98, 0xcf, 0x1c, 0xad, 0x65, 0x74, 0xea, 0xde, 0x11, 0xd9, 0xde, 0x4a, 0xa8, 0x10, 0xa4,
+	0xb8, 0x8d, 0xdc, 0xe6, 0x73, 0x37, 0x94, 0xcf, 0x96, 0x4c, 0xe6, 0xfc, 0xb8, 0xa0, 0x71, 0x0f,
+	0x59, 0x10, 0xab, 0xdc, 0xa4, 0x67, 0x26, 0xf2, 0xad, 0x69, 0x18, 0xab, 0x2f, 0x54, 0x1b, 0x1e,
+	0x9f, 0x22, 0x83, 0x0a, 0xe1, 0x98, 0x99, 0xe6, 0xa0, 0xac, 0xc9, 0x23, 0x20, 0x79, 0x04, 0x64,
+	0x92, 0x46, 0x30, 0xc8, 0x13, 0x18, 0xa7, 0x08, 0x3e, 0x43, 0xb5, 0xcd, 0x37, 0x3b, 0x95, 0x96,
+	0xd6, 0xa9, 0x7b, 0xff, 0xca, 0xfc, 0xa6, 0x49, 0xd6, 0x4b, 0xb7, 0xe3, 0xf8, 0x12, 0xd5, 0xd8,
+	0x4a, 0x31, 0x1e, 0x05, 0xc0, 0x9d, 0xea, 0x8f, 0x76, 0x6f, 0xc1, 0x9e, 0x69, 0x19, 0xb6, 0x79,
+	0xf8, 0x8c, 0x1a, 0xf9, 0x82, 0x2b, 0x90, 0x21, 0x55, 0xb8, 0x81, 0xac, 0x51, 0x1a, 0xfd, 0x34,
+	0x9e, 0xdb, 0xbf, 0xb0, 0x85, 0xcc, 0xde, 0xfd, 0xf0, 0xce, 0xd6, 0xb0, 0x8d, 0x1a, 0x45, 0x3d,
+	0xab, 0xe8, 0xe5, 0xca, 0x84, 0xad, 0x94, 0x6d, 0xa4, 0xd3, 0x8f, 0xe7, 0x83, 0xbe, 0x6d, 0xa6,
+	0x6f, 0x93, 0xe1, 0xa0, 0x6f, 0x57, 0x2e, 0xda, 0xe8, 0x8f, 0x0f, 0x61, 0xe9, 0xba, 0x91, 0xf6,
+	0x64, 0xa6, 0xcf, 0x37, 0x1d, 0x3d, 0x78, 0x63, 0x9a, 0x90, 0x2e, 0x48, 0x36, 0xad, 0x66, 0xff,
+	0xfb, 0xe4, 0x63, 0x00, 0xac, 0xf6, 0x74, 0xff, 0x9d, 0x02, 0x00, 0x00,
 }
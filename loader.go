@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"v2ray.com/core/common"
+	"v2ray.com/core/common/serial"
 )
 
 // ConfigLoader is an utility to load V2Ray config from external source.
@@ -28,6 +29,26 @@ func LoadConfig(format ConfigFormat, input io.Reader) (*Config, error) {
 	return loader(input)
 }
 
+// ConfigWriter is an utility to save V2Ray config into external destination.
+type ConfigWriter func(config *Config, output io.Writer) error
+
+var configWriterCache = make(map[ConfigFormat]ConfigWriter)
+
+// RegisterConfigWriter add a new ConfigWriter.
+func RegisterConfigWriter(format ConfigFormat, writer ConfigWriter) error {
+	configWriterCache[format] = writer
+	return nil
+}
+
+// WriteConfig writes config with given format into given destination.
+func WriteConfig(format ConfigFormat, config *Config, output io.Writer) error {
+	writer, found := configWriterCache[format]
+	if !found {
+		return newError(ConfigFormat_name[int32(format)], " is not writable.")
+	}
+	return writer(config, output)
+}
+
 func loadProtobufConfig(input io.Reader) (*Config, error) {
 	config := new(Config)
 	data, _ := ioutil.ReadAll(input)
@@ -37,6 +58,46 @@ func loadProtobufConfig(input io.Reader) (*Config, error) {
 	return config, nil
 }
 
+func writeProtobufConfig(config *Config, output io.Writer) error {
+	data, err := proto.Marshal(config)
+	if err != nil {
+		return err
+	}
+	_, err = output.Write(data)
+	return err
+}
+
+func loadProtobufJSONConfig(input io.Reader) (*Config, error) {
+	config := new(Config)
+	if err := serial.UnmarshalJSON(input, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func writeProtobufJSONConfig(config *Config, output io.Writer) error {
+	data, err := serial.MarshalJSON(config)
+	if err != nil {
+		return err
+	}
+	_, err = output.Write(append(data, '\n'))
+	return err
+}
+
+func writeProtobufTextConfig(config *Config, output io.Writer) error {
+	text, err := serial.MarshalText(config)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(output, text)
+	return err
+}
+
 func init() {
 	common.Must(RegisterConfigLoader(ConfigFormat_Protobuf, loadProtobufConfig))
+	common.Must(RegisterConfigLoader(ConfigFormat_ProtobufJSON, loadProtobufJSONConfig))
+
+	common.Must(RegisterConfigWriter(ConfigFormat_Protobuf, writeProtobufConfig))
+	common.Must(RegisterConfigWriter(ConfigFormat_ProtobufJSON, writeProtobufJSONConfig))
+	common.Must(RegisterConfigWriter(ConfigFormat_ProtobufText, writeProtobufTextConfig))
 }
@@ -0,0 +1,70 @@
+package core_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	. "v2ray.com/core"
+	"v2ray.com/core/app/proxyman"
+	v2net "v2ray.com/core/common/net"
+	"v2ray.com/core/common/serial"
+	"v2ray.com/core/proxy/dokodemo"
+	"v2ray.com/core/proxy/freedom"
+	"v2ray.com/core/testing/assert"
+)
+
+func TestConfigConversion(t *testing.T) {
+	assert := assert.On(t)
+
+	config := &Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			{
+				Tag: "in",
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: v2net.SinglePortRange(v2net.Port(10086)),
+					Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+				}),
+				ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+					Address: v2net.NewIPOrDomain(v2net.DomainAddress("v2ray.com")),
+					Port:    443,
+					NetworkList: &v2net.NetworkList{
+						Network: []v2net.Network{v2net.Network_TCP},
+					},
+				}),
+			},
+		},
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	pbBuffer := new(bytes.Buffer)
+	assert.Error(WriteConfig(ConfigFormat_Protobuf, config, pbBuffer)).IsNil()
+	pbConfig, err := LoadConfig(ConfigFormat_Protobuf, pbBuffer)
+	assert.Error(err).IsNil()
+	assert.Bool(proto.Equal(config, pbConfig)).IsTrue()
+
+	jsonBuffer := new(bytes.Buffer)
+	assert.Error(WriteConfig(ConfigFormat_ProtobufJSON, config, jsonBuffer)).IsNil()
+	jsonText := jsonBuffer.String()
+	assert.Bool(strings.Contains(jsonText, "\"v2ray.core.proxy.dokodemo.Config\"")).IsTrue()
+	assert.Bool(strings.Contains(jsonText, "\"v2ray.com\"")).IsTrue()
+
+	jsonConfig, err := LoadConfig(ConfigFormat_ProtobufJSON, jsonBuffer)
+	assert.Error(err).IsNil()
+	assert.Bool(proto.Equal(config, jsonConfig)).IsTrue()
+
+	textBuffer := new(bytes.Buffer)
+	assert.Error(WriteConfig(ConfigFormat_ProtobufText, config, textBuffer)).IsNil()
+	text := textBuffer.String()
+	assert.Bool(strings.Contains(text, "type: \"v2ray.core.proxy.dokodemo.Config\"")).IsTrue()
+	assert.Bool(strings.Contains(text, "domain: \"v2ray.com\"")).IsTrue()
+	assert.Bool(strings.Contains(text, "@@typed")).IsFalse()
+
+	_, err = LoadConfig(ConfigFormat_ProtobufText, textBuffer)
+	assert.Error(err).IsNotNil()
+}
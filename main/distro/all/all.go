@@ -2,13 +2,19 @@ package all
 
 import (
 	// The following are necessary as they register handlers in their init functions.
+	_ "v2ray.com/core/app/api"
+	_ "v2ray.com/core/app/conntrack"
 	_ "v2ray.com/core/app/dispatcher/impl"
 	_ "v2ray.com/core/app/dns/server"
+	_ "v2ray.com/core/app/metrics"
 	_ "v2ray.com/core/app/proxyman/inbound"
 	_ "v2ray.com/core/app/proxyman/outbound"
 	_ "v2ray.com/core/app/router"
+	_ "v2ray.com/core/app/stats"
+	_ "v2ray.com/core/app/web"
 
 	_ "v2ray.com/core/proxy/blackhole"
+	_ "v2ray.com/core/proxy/dns"
 	_ "v2ray.com/core/proxy/dokodemo"
 	_ "v2ray.com/core/proxy/freedom"
 	_ "v2ray.com/core/proxy/http"
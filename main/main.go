@@ -13,6 +13,8 @@ import (
 	"syscall"
 
 	"v2ray.com/core"
+	"v2ray.com/core/app/router"
+	"v2ray.com/core/common/net"
 
 	_ "v2ray.com/core/main/distro/all"
 )
@@ -21,7 +23,17 @@ var (
 	configFile string
 	version    = flag.Bool("version", false, "Show current version of V2Ray.")
 	test       = flag.Bool("test", false, "Test config file only, without launching V2Ray server.")
-	format     = flag.String("format", "json", "Format of input file.")
+	format     = flag.String("format", "json", "Format of input file: json, yaml, toml, pb or pbjson.")
+	convert    = flag.String("convert", "", "Convert config file into the given format (pb, pbjson or pbtext) and write it to stdout, without launching V2Ray server.")
+
+	route         = flag.String("route", "", "Show how a connection to the given destination, such as tcp:example.com:443, is routed by the config, without launching V2Ray server.")
+	routeSource   = flag.String("route-source", "", "Source address of the connection for -route.")
+	routeInbound  = flag.String("route-inbound", "", "Inbound tag of the connection for -route.")
+	routeLocal    = flag.Uint("route-local-port", 0, "Port that the inbound receives the connection on for -route.")
+	routeAttrs    = flag.String("route-attrs", "", "Attributes of the connection for -route, in the form of key1=value1,key2=value2.")
+	routeUser     = flag.String("route-user", "", "User email of the connection for -route.")
+	routeDomain   = flag.String("route-domain", "", "Sniffed domain of the connection for -route.")
+	routeProtocol = flag.String("route-protocol", "", "Sniffed protocol of the connection for -route.")
 )
 
 func init() {
@@ -33,18 +45,31 @@ func init() {
 	flag.StringVar(&configFile, "config", defaultConfigFile, "Config file for this Point server.")
 }
 
-func GetConfigFormat() core.ConfigFormat {
-	switch strings.ToLower(*format) {
+func parseConfigFormat(name string) (core.ConfigFormat, bool) {
+	switch strings.ToLower(name) {
 	case "json":
-		return core.ConfigFormat_JSON
+		return core.ConfigFormat_JSON, true
 	case "pb", "protobuf":
-		return core.ConfigFormat_Protobuf
+		return core.ConfigFormat_Protobuf, true
+	case "pbjson":
+		return core.ConfigFormat_ProtobufJSON, true
+	case "pbtext", "prototext":
+		return core.ConfigFormat_ProtobufText, true
+	case "yaml", "yml":
+		return core.ConfigFormat_YAML, true
+	case "toml":
+		return core.ConfigFormat_TOML, true
 	default:
-		return core.ConfigFormat_JSON
+		return core.ConfigFormat_JSON, false
 	}
 }
 
-func startV2Ray() (core.Server, error) {
+func GetConfigFormat() core.ConfigFormat {
+	f, _ := parseConfigFormat(*format)
+	return f
+}
+
+func loadConfig() (*core.Config, error) {
 	if len(configFile) == 0 {
 		return nil, newError("config file is not set")
 	}
@@ -64,6 +89,102 @@ func startV2Ray() (core.Server, error) {
 	if err != nil {
 		return nil, newError("failed to read config file: ", configFile).Base(err)
 	}
+	return config, nil
+}
+
+func convertConfig() error {
+	outputFormat, ok := parseConfigFormat(*convert)
+	if !ok {
+		return newError("unknown config format: ", *convert)
+	}
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := core.WriteConfig(outputFormat, config, os.Stdout); err != nil {
+		return newError("failed to convert config").Base(err)
+	}
+	return nil
+}
+
+func traceRoute() error {
+	dest, err := net.ParseDestination(*route)
+	if err != nil {
+		return err
+	}
+	query := &router.RouteQuery{
+		Destination:     dest,
+		InboundTag:      *routeInbound,
+		User:            *routeUser,
+		SniffedDomain:   *routeDomain,
+		SniffedProtocol: *routeProtocol,
+	}
+	if *routeLocal > 0 {
+		port, err := net.PortFromInt(uint32(*routeLocal))
+		if err != nil {
+			return err
+		}
+		query.LocalPort = port
+	}
+	if len(*routeAttrs) > 0 {
+		query.Attributes = make(map[string]string)
+		for _, attr := range strings.Split(*routeAttrs, ",") {
+			parts := strings.SplitN(attr, "=", 2)
+			if len(parts) == 1 {
+				query.Attributes[parts[0]] = ""
+			} else {
+				query.Attributes[parts[0]] = parts[1]
+			}
+		}
+	}
+	if len(*routeSource) > 0 {
+		source, err := net.ParseDestination(*routeSource)
+		if err != nil {
+			return err
+		}
+		query.Source = source
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	trace, err := core.TraceRoute(config, query)
+	if err != nil {
+		return newError("failed to trace route").Base(err)
+	}
+
+	fmt.Println("Domain strategy:", trace.DomainStrategy)
+	for _, rule := range trace.Rules {
+		result := rule.Reason
+		if len(result) == 0 {
+			result = "taken"
+		}
+		if rule.AfterResolution {
+			result += " (with resolved IPs)"
+		}
+		fmt.Printf("Rule %d [%s]: %s\n", rule.Index, rule.Tag, result)
+	}
+	if trace.Resolved {
+		if len(trace.ResolvedIPs) == 0 {
+			fmt.Println("Resolved IPs: none")
+		} else {
+			fmt.Println("Resolved IPs:", trace.ResolvedIPs)
+		}
+	}
+	if trace.MatchedRule < 0 {
+		fmt.Println("Outbound: default")
+	} else {
+		fmt.Println("Outbound:", trace.OutboundTag)
+	}
+	return nil
+}
+
+func startV2Ray() (core.Server, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
 
 	server, err := core.New(config)
 	if err != nil {
@@ -73,9 +194,40 @@ func startV2Ray() (core.Server, error) {
 	return server, nil
 }
 
+func reload(server core.Server) {
+	if configFile == "stdin:" {
+		fmt.Println("Unable to reload config from stdin.")
+		return
+	}
+	config, err := loadConfig()
+	if err != nil {
+		fmt.Println("Failed to reload config:", err)
+		return
+	}
+	if err := server.Reload(config); err != nil {
+		fmt.Println("Failed to reload", err)
+	}
+}
+
 func main() {
 	flag.Parse()
 
+	if len(*convert) > 0 {
+		if err := convertConfig(); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(*route) > 0 {
+		if err := traceRoute(); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	core.PrintVersion()
 
 	if *version {
@@ -98,8 +250,13 @@ func main() {
 	}
 
 	osSignals := make(chan os.Signal, 1)
-	signal.Notify(osSignals, os.Interrupt, os.Kill, syscall.SIGTERM)
+	signal.Notify(osSignals, os.Interrupt, os.Kill, syscall.SIGTERM, syscall.SIGHUP)
 
-	<-osSignals
+	for sig := range osSignals {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		reload(server)
+	}
 	server.Close()
 }
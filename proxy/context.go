@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"context"
+	"net/http"
 
 	"v2ray.com/core/common/net"
 )
@@ -15,6 +16,8 @@ const (
 	inboundEntryPointKey
 	inboundTagKey
 	resolvedIPsKey
+	sniffingResultKey
+	attributesKey
 )
 
 func ContextWithSource(ctx context.Context, src net.Destination) context.Context {
@@ -70,3 +73,43 @@ func ResolvedIPsFromContext(ctx context.Context) ([]net.Address, bool) {
 	ips, ok := ctx.Value(resolvedIPsKey).([]net.Address)
 	return ips, ok
 }
+
+// SniffingResult is the result of protocol sniffing on the first packet of a connection.
+type SniffingResult struct {
+	// Protocol is one of "http", "tls" and "bittorrent", or empty if the protocol is not recognized.
+	Protocol string
+	// Domain is the domain in HTTP Host header or TLS server name, if any.
+	Domain string
+	// HTTPMethod, HTTPPath and HTTPHeader are set for plain HTTP requests.
+	HTTPMethod string
+	HTTPPath   string
+	HTTPHeader http.Header
+}
+
+func ContextWithSniffingResult(ctx context.Context, result *SniffingResult) context.Context {
+	return context.WithValue(ctx, sniffingResultKey, result)
+}
+
+func SniffingResultFromContext(ctx context.Context) (*SniffingResult, bool) {
+	result, ok := ctx.Value(sniffingResultKey).(*SniffingResult)
+	return result, ok
+}
+
+// ContextWithAttributes returns a context with the given attributes added to the existing ones.
+// Attributes are free-form key-value pairs of a connection that routing rules can match on.
+func ContextWithAttributes(ctx context.Context, attributes map[string]string) context.Context {
+	merged := make(map[string]string)
+	for k, v := range AttributesFromContext(ctx) {
+		merged[k] = v
+	}
+	for k, v := range attributes {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, attributesKey, merged)
+}
+
+// AttributesFromContext returns the attributes in the context. The returned map must not be modified.
+func AttributesFromContext(ctx context.Context) map[string]string {
+	attributes, _ := ctx.Value(attributesKey).(map[string]string)
+	return attributes
+}
@@ -0,0 +1,91 @@
+package dns
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import v2ray_core_common_net2 "v2ray.com/core/common/net"
+import v2ray_core_common_net "v2ray.com/core/common/net"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Config struct {
+	// Upstream DNS server for queries other than A and AAAA, and for A and AAAA queries that can't be resolved locally.
+	// Network defaults to UDP, and port defaults to 53. If not set, such queries are answered with NOTIMP.
+	Server *v2ray_core_common_net2.Endpoint `protobuf:"bytes,1,opt,name=server" json:"server,omitempty"`
+	// TTL in seconds of A and AAAA records in answers. Default to 60.
+	Ttl uint32 `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+	// Networks to listen on. Default to both TCP and UDP.
+	NetworkList *v2ray_core_common_net.NetworkList `protobuf:"bytes,3,opt,name=network_list,json=networkList" json:"network_list,omitempty"`
+	// Answer A and AAAA queries with fake IPs from the fake DNS of the DNS server, which must be enabled.
+	// Connections to the fake IPs are then dispatched to the domains.
+	FakeIp bool `protobuf:"varint,4,opt,name=fake_ip,json=fakeIp" json:"fake_ip,omitempty"`
+}
+
+func (m *Config) Reset()                    { *m = Config{} }
+func (m *Config) String() string            { return proto.CompactTextString(m) }
+func (*Config) ProtoMessage()               {}
+func (*Config) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *Config) GetServer() *v2ray_core_common_net2.Endpoint {
+	if m != nil {
+		return m.Server
+	}
+	return nil
+}
+
+func (m *Config) GetTtl() uint32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *Config) GetNetworkList() *v2ray_core_common_net.NetworkList {
+	if m != nil {
+		return m.NetworkList
+	}
+	return nil
+}
+
+func (m *Config) GetFakeIp() bool {
+	if m != nil {
+		return m.FakeIp
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*Config)(nil), "v2ray.core.proxy.dns.Config")
+}
+
+func init() { proto.RegisterFile("v2ray.com/core/proxy/dns/config.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 264 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0x41, 0x4b, 0xc3, 0x30,
+	0x14, 0xc7, 0xc9, 0x2a, 0x55, 0x32, 0x05, 0x29, 0x03, 0xcb, 0x2e, 0x96, 0x81, 0x58, 0x10, 0x12,
+	0xa8, 0x07, 0x3d, 0x3b, 0x77, 0x10, 0x44, 0x46, 0x0f, 0x1e, 0xbc, 0x8c, 0xda, 0x64, 0x12, 0xb6,
+	0xbe, 0x17, 0x92, 0xc7, 0xb4, 0x5f, 0xc9, 0xab, 0x5f, 0x50, 0x9a, 0x4e, 0x14, 0xd9, 0x6e, 0x79,
+	0xfc, 0x7f, 0xf9, 0xfd, 0x93, 0xc7, 0x2f, 0x36, 0x85, 0xab, 0x5a, 0x51, 0x63, 0x23, 0x6b, 0x74,
+	0x5a, 0x5a, 0x87, 0x1f, 0xad, 0x54, 0xe0, 0x65, 0x8d, 0xb0, 0x34, 0x6f, 0xc2, 0x3a, 0x24, 0x4c,
+	0x46, 0x3f, 0x98, 0xd3, 0x22, 0x20, 0x42, 0x81, 0x1f, 0x5f, 0xfd, 0xbb, 0x5c, 0x63, 0xd3, 0x20,
+	0x48, 0xd0, 0x24, 0x95, 0xf6, 0x64, 0xa0, 0x22, 0x83, 0xd0, 0x2b, 0xc6, 0x97, 0xfb, 0x61, 0xd0,
+	0xf4, 0x8e, 0x6e, 0xd5, 0x83, 0x93, 0x2f, 0xc6, 0xe3, 0x69, 0x28, 0x4f, 0x6e, 0x78, 0xec, 0xb5,
+	0xdb, 0x68, 0x97, 0xb2, 0x8c, 0xe5, 0xc3, 0xe2, 0x5c, 0xfc, 0x79, 0x47, 0x2f, 0x10, 0xa0, 0x49,
+	0xcc, 0x40, 0x59, 0x34, 0x40, 0xe5, 0x16, 0x4f, 0x4e, 0x79, 0x44, 0xb4, 0x4e, 0x07, 0x19, 0xcb,
+	0x4f, 0xca, 0xee, 0x98, 0xcc, 0xf8, 0xf1, 0xb6, 0x66, 0xb1, 0x36, 0x9e, 0xd2, 0x28, 0x08, 0x27,
+	0x7b, 0x84, 0x4f, 0x3d, 0xfa, 0x68, 0x3c, 0x95, 0x43, 0xf8, 0x1d, 0x92, 0x33, 0x7e, 0xb8, 0xac,
+	0x56, 0x7a, 0x61, 0x6c, 0x7a, 0x90, 0xb1, 0xfc, 0xa8, 0x8c, 0xbb, 0xf1, 0xc1, 0xde, 0xdd, 0xf2,
+	0xb4, 0xc6, 0x46, 0xec, 0xda, 0xd3, 0x9c, 0xbd, 0x44, 0x0a, 0xfc, 0xe7, 0x60, 0xf4, 0x5c, 0x94,
+	0x55, 0x2b, 0xa6, 0x5d, 0x3a, 0x0f, 0xe9, 0x3d, 0xf8, 0xd7, 0x38, 0x7c, 0xfb, 0xfa, 0x7b, 0x00,
+	0x56, 0x76, 0x2a, 0x85, 0x8b, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,263 @@
+// Package dns is an inbound proxy that answers DNS queries from clients, using the DNS server of V2Ray.
+package dns
+
+//go:generate go run $GOPATH/src/v2ray.com/core/tools/generrorgen/main.go -pkg dns -path Proxy,DNS
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	dnsmsg "github.com/miekg/dns"
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/dispatcher"
+	"v2ray.com/core/app/dns"
+	dnsserver "v2ray.com/core/app/dns/server"
+	"v2ray.com/core/app/log"
+	"v2ray.com/core/app/proxyman/outbound"
+	"v2ray.com/core/common"
+	"v2ray.com/core/common/buf"
+	"v2ray.com/core/common/errors"
+	v2net "v2ray.com/core/common/net"
+	"v2ray.com/core/transport/internet"
+)
+
+const (
+	defaultTTL     = 60
+	forwardTimeout = time.Second * 8
+	tcpIdleTimeout = time.Second * 10
+)
+
+// Server answers A and AAAA queries from the DNS server in space, and forwards other queries to an upstream server.
+type Server struct {
+	config   *Config
+	upstream v2net.Destination
+	ttl      uint32
+	dns      dns.Server
+	fake     dns.FakeDNS
+}
+
+func New(ctx context.Context, config *Config) (*Server, error) {
+	space := app.SpaceFromContext(ctx)
+	if space == nil {
+		return nil, newError("no space in context")
+	}
+	s := &Server{
+		config: config,
+		ttl:    config.Ttl,
+	}
+	if s.ttl == 0 {
+		s.ttl = defaultTTL
+	}
+	if config.Server != nil {
+		s.upstream = config.Server.AsDestination()
+		if s.upstream.Network != v2net.Network_TCP {
+			s.upstream.Network = v2net.Network_UDP
+		}
+		if s.upstream.Port == 0 {
+			s.upstream.Port = v2net.Port(53)
+		}
+	}
+	space.OnInitialize(func() error {
+		s.dns = dns.FromSpace(space)
+		if s.dns == nil {
+			return newError("DNS server is not found in the space")
+		}
+		if config.FakeIp {
+			s.fake = dns.FakeDNSFromSpace(space)
+			if s.fake == nil {
+				return newError("fake DNS is not enabled in the DNS server")
+			}
+		}
+		return nil
+	})
+	return s, nil
+}
+
+func (s *Server) Network() v2net.NetworkList {
+	if s.config.NetworkList == nil || s.config.NetworkList.Size() == 0 {
+		return v2net.NetworkList{
+			Network: []v2net.Network{v2net.Network_TCP, v2net.Network_UDP},
+		}
+	}
+	return *(s.config.NetworkList)
+}
+
+func (s *Server) Process(ctx context.Context, network v2net.Network, conn internet.Connection, dispatcher dispatcher.Interface) error {
+	log.Trace(newError("processing DNS queries from: ", conn.RemoteAddr()).AtDebug())
+	if network == v2net.Network_TCP {
+		return s.processTCP(ctx, conn, dispatcher)
+	}
+	return s.processUDP(ctx, conn, dispatcher)
+}
+
+// processTCP answers queries on a TCP connection in order, until the client closes the connection or stays idle for too long.
+func (s *Server) processTCP(ctx context.Context, conn internet.Connection, dispatcher dispatcher.Interface) error {
+	for {
+		conn.SetReadDeadline(time.Now().Add(tcpIdleTimeout))
+		query, err := dnsserver.ReadTCPMessage(conn)
+		if err != nil {
+			if errors.Cause(err) == io.EOF {
+				return nil
+			}
+			return newError("failed to read query").Base(err)
+		}
+		if err := dnsserver.WriteTCPMessage(conn, s.answer(ctx, query, dispatcher)); err != nil {
+			return newError("failed to write answer").Base(err)
+		}
+	}
+}
+
+// processUDP answers each datagram from the client concurrently.
+func (s *Server) processUDP(ctx context.Context, conn internet.Connection, dispatcher dispatcher.Interface) error {
+	queries := make(chan *dnsmsg.Msg, 16)
+	go func() {
+		defer close(queries)
+		b := make([]byte, buf.Size)
+		for {
+			n, err := conn.Read(b)
+			if err != nil {
+				return
+			}
+			query := new(dnsmsg.Msg)
+			if err := query.Unpack(b[:n]); err != nil {
+				log.Trace(newError("failed to parse query from ", conn.RemoteAddr()).Base(err).AtWarning())
+				continue
+			}
+			queries <- query
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case query, open := <-queries:
+			if !open {
+				return nil
+			}
+			go func() {
+				payload, err := s.answer(ctx, query, dispatcher).Pack()
+				if err != nil {
+					log.Trace(newError("failed to pack answer").Base(err).AtWarning())
+					return
+				}
+				if _, err := conn.Write(payload); err != nil {
+					log.Trace(newError("failed to write answer").Base(err).AtWarning())
+				}
+			}()
+		}
+	}
+}
+
+// answer returns the response to the query. It never returns nil.
+func (s *Server) answer(ctx context.Context, query *dnsmsg.Msg, dispatcher dispatcher.Interface) *dnsmsg.Msg {
+	reply := new(dnsmsg.Msg)
+	if len(query.Question) != 1 {
+		return reply.SetRcodeFormatError(query)
+	}
+	q := query.Question[0]
+
+	if q.Qclass == dnsmsg.ClassINET && (q.Qtype == dnsmsg.TypeA || q.Qtype == dnsmsg.TypeAAAA) {
+		preference := dns.IPPreference_IPv4Only
+		if q.Qtype == dnsmsg.TypeAAAA {
+			preference = dns.IPPreference_IPv6Only
+		}
+		var ips []net.IP
+		if s.fake != nil {
+			ip := s.fake.GetFakeIP(strings.TrimSuffix(q.Name, "."))
+			if (ip.To4() != nil) == (q.Qtype == dnsmsg.TypeA) {
+				ips = append(ips, ip)
+			}
+		} else {
+			ips = s.dns.GetIP(strings.TrimSuffix(q.Name, "."), preference)
+		}
+		if len(ips) > 0 || s.fake != nil || !s.upstream.IsValid() {
+			reply.SetReply(query)
+			reply.RecursionAvailable = true
+			header := dnsmsg.RR_Header{
+				Name:   q.Name,
+				Rrtype: q.Qtype,
+				Class:  dnsmsg.ClassINET,
+				Ttl:    s.ttl,
+			}
+			for _, ip := range ips {
+				if q.Qtype == dnsmsg.TypeA {
+					reply.Answer = append(reply.Answer, &dnsmsg.A{Hdr: header, A: ip})
+				} else {
+					reply.Answer = append(reply.Answer, &dnsmsg.AAAA{Hdr: header, AAAA: ip})
+				}
+			}
+			log.Trace(newError("answering ", len(ips), " IPs for ", q.Name).AtDebug())
+			return reply
+		}
+	}
+
+	if !s.upstream.IsValid() {
+		return reply.SetRcode(query, dnsmsg.RcodeNotImplemented)
+	}
+	response, err := s.forward(ctx, query, dispatcher)
+	if err != nil {
+		log.Trace(newError("failed to forward query for ", q.Name, " to ", s.upstream).Base(err).AtWarning())
+		return reply.SetRcode(query, dnsmsg.RcodeServerFailure)
+	}
+	return response
+}
+
+// forward sends the query to the upstream server through the dispatcher, and returns its response.
+func (s *Server) forward(ctx context.Context, query *dnsmsg.Msg, dispatcher dispatcher.Interface) (*dnsmsg.Msg, error) {
+	ctx, cancel := context.WithTimeout(ctx, forwardTimeout)
+	defer cancel()
+
+	stream, err := dispatcher.Dispatch(ctx, s.upstream)
+	if err != nil {
+		return nil, newError("failed to dispatch query").Base(err)
+	}
+	conn := outbound.NewConnection(stream)
+	defer conn.Close()
+
+	if s.upstream.Network == v2net.Network_TCP {
+		if err := dnsserver.WriteTCPMessage(conn, query); err != nil {
+			return nil, err
+		}
+		for {
+			response, err := dnsserver.ReadTCPMessage(conn)
+			if err != nil {
+				return nil, err
+			}
+			if response.Id == query.Id {
+				return response, nil
+			}
+		}
+	}
+
+	payload, err := query.Pack()
+	if err != nil {
+		return nil, newError("failed to pack query").Base(err)
+	}
+	if _, err := conn.Write(payload); err != nil {
+		return nil, newError("failed to send query").Base(err)
+	}
+	for {
+		mb, err := conn.ReadMultiBuffer()
+		if err != nil {
+			return nil, newError("failed to read response").Base(err)
+		}
+		for _, b := range mb {
+			response := new(dnsmsg.Msg)
+			if response.Unpack(b.Bytes()) == nil && response.Id == query.Id {
+				mb.Release()
+				return response, nil
+			}
+		}
+		mb.Release()
+	}
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return New(ctx, config.(*Config))
+	}))
+}
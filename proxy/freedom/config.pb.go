@@ -4,6 +4,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import v2ray_core_common_protocol1 "v2ray.com/core/common/protocol"
+import v2ray_core_app_dns "v2ray.com/core/app/dns"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -57,6 +58,8 @@ type Config struct {
 	DomainStrategy      Config_DomainStrategy `protobuf:"varint,1,opt,name=domain_strategy,json=domainStrategy,enum=v2ray.core.proxy.freedom.Config_DomainStrategy" json:"domain_strategy,omitempty"`
 	Timeout             uint32                `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
 	DestinationOverride *DestinationOverride  `protobuf:"bytes,3,opt,name=destination_override,json=destinationOverride" json:"destination_override,omitempty"`
+	// Address families of resolved IPs, when domain strategy is USE_IP.
+	IpPreference v2ray_core_app_dns.IPPreference `protobuf:"varint,4,opt,name=ip_preference,json=ipPreference,enum=v2ray.core.app.dns.IPPreference" json:"ip_preference,omitempty"`
 }
 
 func (m *Config) Reset()                    { *m = Config{} }
@@ -85,6 +88,13 @@ func (m *Config) GetDestinationOverride() *DestinationOverride {
 	return nil
 }
 
+func (m *Config) GetIpPreference() v2ray_core_app_dns.IPPreference {
+	if m != nil {
+		return m.IpPreference
+	}
+	return v2ray_core_app_dns.IPPreference_PreferIPv4
+}
+
 func init() {
 	proto.RegisterType((*DestinationOverride)(nil), "v2ray.core.proxy.freedom.DestinationOverride")
 	proto.RegisterType((*Config)(nil), "v2ray.core.proxy.freedom.Config")
@@ -94,25 +104,28 @@ func init() {
 func init() { proto.RegisterFile("v2ray.com/core/proxy/freedom/config.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 318 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0xcf, 0x4a, 0xc3, 0x40,
-	0x10, 0xc6, 0x4d, 0xc5, 0x14, 0x47, 0xac, 0x65, 0xeb, 0x21, 0x88, 0x87, 0xd2, 0x8b, 0x55, 0x70,
-	0x23, 0xf1, 0x09, 0xec, 0x1f, 0xa1, 0x27, 0x4b, 0x82, 0xa2, 0x5e, 0x62, 0xcc, 0x4e, 0xcb, 0x82,
-	0xd9, 0x09, 0x9b, 0xb5, 0x98, 0x27, 0xf0, 0x5d, 0x7c, 0x4a, 0x71, 0x93, 0xa2, 0x55, 0x73, 0xdb,
-	0x99, 0xfd, 0x7d, 0xdf, 0xcc, 0x37, 0x70, 0xba, 0x0a, 0x74, 0x52, 0xf2, 0x94, 0x32, 0x3f, 0x25,
-	0x8d, 0x7e, 0xae, 0xe9, 0xad, 0xf4, 0x17, 0x1a, 0x51, 0xd8, 0x96, 0x5a, 0xc8, 0x25, 0xcf, 0x35,
-	0x19, 0x62, 0xde, 0x1a, 0xd5, 0xc8, 0x2d, 0xc6, 0x6b, 0xec, 0xe8, 0xe2, 0x97, 0x49, 0x4a, 0x59,
-	0x46, 0xca, 0xb7, 0xb2, 0x94, 0x5e, 0xfc, 0x02, 0xf5, 0x0a, 0x75, 0x5c, 0xe4, 0x98, 0x56, 0x5e,
-	0x83, 0x07, 0xe8, 0x4d, 0xb0, 0x30, 0x52, 0x25, 0x46, 0x92, 0xba, 0x59, 0xa1, 0xd6, 0x52, 0x20,
-	0x1b, 0x81, 0x5b, 0xb1, 0x9e, 0xd3, 0x77, 0x86, 0x7b, 0xc1, 0x19, 0xff, 0x31, 0xb3, 0x72, 0xe5,
-	0x6b, 0x57, 0x1e, 0x59, 0x72, 0xaa, 0x44, 0x4e, 0x52, 0x99, 0xb0, 0x56, 0x0e, 0xde, 0x5b, 0xe0,
-	0x8e, 0xed, 0xde, 0xec, 0x1e, 0x0e, 0x04, 0x65, 0x89, 0x54, 0x71, 0x61, 0x74, 0x62, 0x70, 0x59,
-	0x5a, 0xdf, 0x4e, 0xe0, 0xf3, 0xa6, 0x2c, 0xbc, 0x92, 0xf2, 0x89, 0xd5, 0x45, 0xb5, 0x2c, 0xec,
-	0x88, 0x8d, 0x9a, 0x79, 0xd0, 0x36, 0x32, 0x43, 0x7a, 0x35, 0x5e, 0xab, 0xef, 0x0c, 0xf7, 0xc3,
-	0x75, 0xc9, 0x9e, 0xe0, 0x50, 0x7c, 0x27, 0x8b, 0xa9, 0x8e, 0xe6, 0x6d, 0xdb, 0x40, 0xe7, 0xcd,
-	0x83, 0xff, 0xb9, 0x47, 0xd8, 0x13, 0x7f, 0x9b, 0x83, 0x13, 0xe8, 0x6c, 0x6e, 0xc7, 0x76, 0x61,
-	0xe7, 0x2a, 0x8a, 0x67, 0x51, 0x77, 0x8b, 0x01, 0xb8, 0xb7, 0xd1, 0x34, 0x9e, 0xcd, 0xbb, 0xce,
-	0x68, 0x02, 0xc7, 0x29, 0x65, 0x8d, 0x13, 0xe7, 0xce, 0x63, 0xbb, 0x7e, 0x7e, 0xb4, 0xbc, 0xbb,
-	0x20, 0x4c, 0x4a, 0x3e, 0xfe, 0xa2, 0xe6, 0x96, 0xba, 0xae, 0xbe, 0x9e, 0x5d, 0x7b, 0xf0, 0xcb,
-	0xcf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x45, 0xb3, 0x84, 0x2e, 0x2a, 0x02, 0x00, 0x00,
+	// 361 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xcd, 0x4e, 0xbb, 0x40,
+	0x14, 0xc5, 0xff, 0xf4, 0xaf, 0x34, 0x8e, 0xb6, 0x36, 0xd4, 0x05, 0x69, 0x5c, 0x34, 0x75, 0x61,
+	0x35, 0x71, 0x30, 0xf8, 0x04, 0xf6, 0xc3, 0xa4, 0x2b, 0x09, 0x44, 0xa3, 0x6e, 0x10, 0x99, 0xdb,
+	0x66, 0x12, 0x99, 0x3b, 0x19, 0xc6, 0x46, 0x5e, 0xc9, 0x87, 0xf1, 0x99, 0x8c, 0x03, 0xb5, 0x1f,
+	0x69, 0x77, 0x5c, 0xf8, 0x9d, 0x73, 0xee, 0xb9, 0x90, 0x8b, 0xb9, 0xaf, 0x92, 0x82, 0xa6, 0x98,
+	0x79, 0x29, 0x2a, 0xf0, 0xa4, 0xc2, 0xcf, 0xc2, 0x9b, 0x2a, 0x00, 0x66, 0x5e, 0x89, 0x29, 0x9f,
+	0x51, 0xa9, 0x50, 0xa3, 0xe3, 0x2e, 0x50, 0x05, 0xd4, 0x60, 0xb4, 0xc2, 0x3a, 0xd7, 0x1b, 0x26,
+	0x29, 0x66, 0x19, 0x0a, 0xcf, 0xc8, 0x52, 0x7c, 0xf7, 0x72, 0x50, 0x73, 0x50, 0x71, 0x2e, 0x21,
+	0x2d, 0xbd, 0x3a, 0x67, 0x1b, 0x8a, 0x44, 0x4a, 0x8f, 0x89, 0x7c, 0x2d, 0xb0, 0xf7, 0x4c, 0xda,
+	0x23, 0xc8, 0x35, 0x17, 0x89, 0xe6, 0x28, 0xee, 0xe7, 0xa0, 0x14, 0x67, 0xe0, 0x0c, 0x88, 0x5d,
+	0x1a, 0xba, 0x56, 0xd7, 0xea, 0x1f, 0xfa, 0x97, 0x74, 0x65, 0xb1, 0x32, 0x9a, 0x2e, 0xa2, 0x69,
+	0x64, 0xc8, 0xb1, 0x60, 0x12, 0xb9, 0xd0, 0x61, 0xa5, 0xec, 0x7d, 0xd7, 0x88, 0x3d, 0x34, 0x59,
+	0xce, 0x13, 0x39, 0x66, 0x98, 0x25, 0x5c, 0xc4, 0xb9, 0x56, 0x89, 0x86, 0x59, 0x61, 0x7c, 0x9b,
+	0xbe, 0x47, 0x77, 0x15, 0xa6, 0xa5, 0x94, 0x8e, 0x8c, 0x2e, 0xaa, 0x64, 0x61, 0x93, 0xad, 0xcd,
+	0x8e, 0x4b, 0xea, 0x9a, 0x67, 0x80, 0x1f, 0xda, 0xad, 0x75, 0xad, 0x7e, 0x23, 0x5c, 0x8c, 0xce,
+	0x2b, 0x39, 0x61, 0xcb, 0x66, 0x31, 0x56, 0xd5, 0xdc, 0xff, 0xa6, 0xd0, 0xd5, 0xee, 0xe0, 0x2d,
+	0xf7, 0x08, 0xdb, 0x6c, 0xcb, 0x91, 0xc6, 0xa4, 0xc1, 0x65, 0x2c, 0x15, 0x4c, 0x41, 0x81, 0x48,
+	0xc1, 0xdd, 0x33, 0x9d, 0xba, 0xab, 0xd6, 0x89, 0x94, 0x94, 0x89, 0x9c, 0x4e, 0x82, 0xe0, 0x8f,
+	0x0b, 0x8f, 0xb8, 0x5c, 0x4e, 0xbd, 0x73, 0xd2, 0x5c, 0x2f, 0xe9, 0x1c, 0x90, 0xfd, 0xdb, 0x28,
+	0x9e, 0x44, 0xad, 0x7f, 0x0e, 0x21, 0xf6, 0x43, 0x34, 0x8e, 0x27, 0x41, 0xcb, 0x1a, 0x8c, 0xc8,
+	0x69, 0x8a, 0xd9, 0xce, 0xc5, 0x03, 0xeb, 0xa5, 0x5e, 0x3d, 0x7e, 0xd5, 0xdc, 0x47, 0x3f, 0x4c,
+	0x0a, 0x3a, 0xfc, 0xa5, 0x02, 0x43, 0xdd, 0x95, 0x9f, 0xde, 0x6c, 0xf3, 0xdf, 0x6e, 0x7e, 0x06,
+	0x00, 0xa4, 0xfe, 0xff, 0x4c, 0x96, 0x02, 0x00, 0x00,
 }
@@ -23,6 +23,7 @@ import (
 
 type Handler struct {
 	domainStrategy Config_DomainStrategy
+	ipPreference   dns.IPPreference
 	timeout        uint32
 	dns            dns.Server
 	destOverride   *DestinationOverride
@@ -35,6 +36,7 @@ func New(ctx context.Context, config *Config) (*Handler, error) {
 	}
 	f := &Handler{
 		domainStrategy: config.DomainStrategy,
+		ipPreference:   config.IpPreference,
 		timeout:        config.Timeout,
 		destOverride:   config.DestinationOverride,
 	}
@@ -55,7 +57,7 @@ func (v *Handler) ResolveIP(destination net.Destination) net.Destination {
 		return destination
 	}
 
-	ips := v.dns.Get(destination.Address.Domain())
+	ips := v.dns.GetIP(destination.Address.Domain(), v.ipPreference)
 	if len(ips) == 0 {
 		log.Trace(newError("DNS returns nil answer. Keep domain as is."))
 		return destination
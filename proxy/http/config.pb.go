@@ -18,6 +18,9 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 // Config for HTTP proxy server.
 type ServerConfig struct {
 	Timeout uint32 `protobuf:"varint,1,opt,name=timeout" json:"timeout,omitempty"`
+	// If true, requests that are not proxy requests, such as "GET / HTTP/1.1", are served by the web server app, so that
+	// the proxy looks like a web site to clients that are not proxy clients.
+	WebFallback bool `protobuf:"varint,2,opt,name=web_fallback,json=webFallback" json:"web_fallback,omitempty"`
 }
 
 func (m *ServerConfig) Reset()                    { *m = ServerConfig{} }
@@ -32,6 +35,13 @@ func (m *ServerConfig) GetTimeout() uint32 {
 	return 0
 }
 
+func (m *ServerConfig) GetWebFallback() bool {
+	if m != nil {
+		return m.WebFallback
+	}
+	return false
+}
+
 // ClientConfig for HTTP proxy client.
 type ClientConfig struct {
 }
@@ -49,16 +59,17 @@ func init() {
 func init() { proto.RegisterFile("v2ray.com/core/proxy/http/config.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 161 bytes of a gzipped FileDescriptorProto
+	// 188 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x2b, 0x33, 0x2a, 0x4a,
 	0xac, 0xd4, 0x4b, 0xce, 0xcf, 0xd5, 0x4f, 0xce, 0x2f, 0x4a, 0xd5, 0x2f, 0x28, 0xca, 0xaf, 0xa8,
 	0xd4, 0xcf, 0x28, 0x29, 0x29, 0xd0, 0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0x2b, 0x28, 0xca,
-	0x2f, 0xc9, 0x17, 0x12, 0x85, 0xa9, 0x2b, 0x4a, 0xd5, 0x03, 0xab, 0xd1, 0x03, 0xa9, 0x51, 0xd2,
-	0xe0, 0xe2, 0x09, 0x4e, 0x2d, 0x2a, 0x4b, 0x2d, 0x72, 0x06, 0x2b, 0x16, 0x92, 0xe0, 0x62, 0x2f,
-	0xc9, 0xcc, 0x4d, 0xcd, 0x2f, 0x2d, 0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0d, 0x82, 0x71, 0x95,
-	0xf8, 0xb8, 0x78, 0x9c, 0x73, 0x32, 0x53, 0xf3, 0x4a, 0x20, 0x2a, 0x9d, 0xac, 0xb9, 0x24, 0x93,
-	0xf3, 0x73, 0xf5, 0xb0, 0x1a, 0x1b, 0xc0, 0x18, 0xc5, 0x02, 0xa2, 0x57, 0x31, 0x89, 0x86, 0x19,
-	0x05, 0x25, 0x56, 0xea, 0x39, 0x83, 0xe4, 0x03, 0xc0, 0xf2, 0x1e, 0x25, 0x25, 0x05, 0x49, 0x6c,
-	0x60, 0x47, 0x19, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xc9, 0xae, 0x86, 0xab, 0xbe, 0x00, 0x00,
-	0x00,
+	0x2f, 0xc9, 0x17, 0x12, 0x85, 0xa9, 0x2b, 0x4a, 0xd5, 0x03, 0xab, 0xd1, 0x03, 0xa9, 0x51, 0xf2,
+	0xe6, 0xe2, 0x09, 0x4e, 0x2d, 0x2a, 0x4b, 0x2d, 0x72, 0x06, 0x2b, 0x16, 0x92, 0xe0, 0x62, 0x2f,
+	0xc9, 0xcc, 0x4d, 0xcd, 0x2f, 0x2d, 0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0d, 0x82, 0x71, 0x85,
+	0x14, 0xb9, 0x78, 0xca, 0x53, 0x93, 0xe2, 0xd3, 0x12, 0x73, 0x72, 0x92, 0x12, 0x93, 0xb3, 0x25,
+	0x98, 0x14, 0x18, 0x35, 0x38, 0x82, 0xb8, 0xcb, 0x53, 0x93, 0xdc, 0xa0, 0x42, 0x4a, 0x7c, 0x5c,
+	0x3c, 0xce, 0x39, 0x99, 0xa9, 0x79, 0x25, 0x10, 0xc3, 0x9c, 0xac, 0xb9, 0x24, 0x93, 0xf3, 0x73,
+	0xf5, 0xb0, 0xda, 0x1c, 0xc0, 0x18, 0xc5, 0x02, 0xa2, 0x57, 0x31, 0x89, 0x86, 0x19, 0x05, 0x25,
+	0x56, 0xea, 0x39, 0x83, 0xe4, 0x03, 0xc0, 0xf2, 0x1e, 0x25, 0x25, 0x05, 0x49, 0x6c, 0x60, 0x77,
+	0x1b, 0x03, 0x06, 0x00, 0xf4, 0x74, 0xa1, 0xc4, 0xe1, 0x00, 0x00, 0x00,
 }
@@ -2,6 +2,7 @@ package http
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"io"
 	"net"
@@ -14,6 +15,7 @@ import (
 	"v2ray.com/core/app"
 	"v2ray.com/core/app/dispatcher"
 	"v2ray.com/core/app/log"
+	"v2ray.com/core/app/web"
 	"v2ray.com/core/common"
 	"v2ray.com/core/common/buf"
 	"v2ray.com/core/common/errors"
@@ -25,6 +27,7 @@ import (
 // Server is a HTTP proxy server.
 type Server struct {
 	config *ServerConfig
+	web    *web.WebServer
 }
 
 // NewServer creates a new HTTP inbound handler.
@@ -36,6 +39,15 @@ func NewServer(ctx context.Context, config *ServerConfig) (*Server, error) {
 	s := &Server{
 		config: config,
 	}
+	if config.WebFallback {
+		space.OnInitialize(func() error {
+			s.web = web.FromSpace(space)
+			if s.web == nil {
+				return newError("web server is not found in the space")
+			}
+			return nil
+		})
+	}
 	return s, nil
 }
 
@@ -73,12 +85,53 @@ func isTimeout(err error) bool {
 	return ok && nerr.Timeout()
 }
 
+// peekRequestTarget returns the request target in the request line of the next request in the reader, without
+// consuming it.
+func peekRequestTarget(reader *bufio.Reader) (string, error) {
+	for n := 1; ; n = reader.Buffered() + 1 {
+		if _, err := reader.Peek(n); err != nil {
+			return "", err
+		}
+		b, _ := reader.Peek(reader.Buffered())
+		if idx := bytes.IndexByte(b, '\n'); idx >= 0 {
+			fields := strings.Fields(string(b[:idx]))
+			if len(fields) < 2 {
+				return "", nil
+			}
+			return fields[1], nil
+		}
+	}
+}
+
+// bufferedConn is a connection whose data already in the reader is read first.
+type bufferedConn struct {
+	internet.Connection
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
 func (s *Server) Process(ctx context.Context, network v2net.Network, conn internet.Connection, dispatcher dispatcher.Interface) error {
 	reader := bufio.NewReaderSize(conn, 2048)
 
 Start:
 	conn.SetReadDeadline(time.Now().Add(time.Second * 16))
 
+	if s.web != nil {
+		// Proxy requests have an absolute URL or a host as the target, while requests to web sites have a path.
+		target, err := peekRequestTarget(reader)
+		if err == nil && strings.HasPrefix(target, "/") {
+			log.Trace(newError("serving non-proxy request from ", conn.RemoteAddr(), " by web server"))
+			conn.SetReadDeadline(time.Time{})
+			return s.web.ServeConn(ctx, &bufferedConn{
+				Connection: conn,
+				reader:     reader,
+			})
+		}
+	}
+
 	request, err := http.ReadRequest(reader)
 	if err != nil {
 		trace := newError("failed to read http request").Base(err)
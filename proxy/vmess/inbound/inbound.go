@@ -6,10 +6,12 @@ import (
 	"context"
 	"io"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
 	"v2ray.com/core/app"
+	"v2ray.com/core/app/conntrack"
 	"v2ray.com/core/app/dispatcher"
 	"v2ray.com/core/app/log"
 	"v2ray.com/core/app/proxyman"
@@ -21,12 +23,14 @@ import (
 	"v2ray.com/core/common/serial"
 	"v2ray.com/core/common/signal"
 	"v2ray.com/core/common/uuid"
+	"v2ray.com/core/proxy"
 	"v2ray.com/core/proxy/vmess"
 	"v2ray.com/core/proxy/vmess/encoding"
 	"v2ray.com/core/transport/internet"
 	"v2ray.com/core/transport/ray"
 )
 
+// userByEmail is a cache of users indexed by email. Emails are case insensitive.
 type userByEmail struct {
 	sync.RWMutex
 	cache           map[string]*protocol.User
@@ -37,7 +41,7 @@ type userByEmail struct {
 func NewUserByEmail(users []*protocol.User, config *DefaultConfig) *userByEmail {
 	cache := make(map[string]*protocol.User)
 	for _, user := range users {
-		cache[user.Email] = user
+		cache[strings.ToLower(user.Email)] = user
 	}
 	return &userByEmail{
 		cache:           cache,
@@ -49,12 +53,13 @@ func NewUserByEmail(users []*protocol.User, config *DefaultConfig) *userByEmail
 func (v *userByEmail) Get(email string) (*protocol.User, bool) {
 	var user *protocol.User
 	var found bool
+	key := strings.ToLower(email)
 	v.RLock()
-	user, found = v.cache[email]
+	user, found = v.cache[key]
 	v.RUnlock()
 	if !found {
 		v.Lock()
-		user, found = v.cache[email]
+		user, found = v.cache[key]
 		if !found {
 			account := &vmess.Account{
 				Id:      uuid.New().String(),
@@ -65,16 +70,43 @@ func (v *userByEmail) Get(email string) (*protocol.User, bool) {
 				Email:   email,
 				Account: serial.ToTypedMessage(account),
 			}
-			v.cache[email] = user
+			v.cache[key] = user
 		}
 		v.Unlock()
 	}
 	return user, found
 }
 
+// Add adds the given user into the cache. It returns false if a user with the same email already exists.
+func (v *userByEmail) Add(user *protocol.User) bool {
+	v.Lock()
+	defer v.Unlock()
+
+	key := strings.ToLower(user.Email)
+	if _, found := v.cache[key]; found {
+		return false
+	}
+	v.cache[key] = user
+	return true
+}
+
+// Remove removes the user with the given email from the cache. It returns false if the user doesn't exist.
+func (v *userByEmail) Remove(email string) bool {
+	v.Lock()
+	defer v.Unlock()
+
+	key := strings.ToLower(email)
+	if _, found := v.cache[key]; !found {
+		return false
+	}
+	delete(v.cache, key)
+	return true
+}
+
 // Handler is an inbound connection handler that handles messages in VMess protocol.
 type Handler struct {
 	inboundHandlerManager proxyman.InboundHandlerManager
+	tracker               *conntrack.Tracker
 	clients               protocol.UserValidator
 	usersByEmail          *userByEmail
 	detours               *DetourConfig
@@ -106,6 +138,7 @@ func New(ctx context.Context, config *Config) (*Handler, error) {
 		if handler.inboundHandlerManager == nil {
 			return newError("InboundHandlerManager is not found is space")
 		}
+		handler.tracker = conntrack.FromSpace(space)
 		return nil
 	})
 
@@ -127,6 +160,38 @@ func (v *Handler) GetUser(email string) *protocol.User {
 	return user
 }
 
+// AddUser implements proxy.UserManager.AddUser().
+func (v *Handler) AddUser(ctx context.Context, user *protocol.User) error {
+	if len(user.Email) == 0 {
+		return newError("email must not be empty")
+	}
+	if !v.usersByEmail.Add(user) {
+		return newError("user ", user.Email, " already exists")
+	}
+	if err := v.clients.Add(user); err != nil {
+		v.usersByEmail.Remove(user.Email)
+		return newError("failed to add user ", user.Email).Base(err)
+	}
+	return nil
+}
+
+// RemoveUser implements proxy.UserManager.RemoveUser(). If the inbound tag is in the context and connection tracking
+// is enabled, open sessions of the user on this inbound are closed as well.
+func (v *Handler) RemoveUser(ctx context.Context, email string) error {
+	if len(email) == 0 {
+		return newError("email must not be empty")
+	}
+	if !v.usersByEmail.Remove(email) {
+		return newError("user ", email, " not found")
+	}
+	v.clients.Remove(email)
+	if tag, ok := proxy.InboundTagFromContext(ctx); ok && v.tracker != nil {
+		closed := v.tracker.CloseInboundUser(tag, email)
+		log.Trace(newError("closed ", closed, " sessions of removed user ", email).AtDebug())
+	}
+	return nil
+}
+
 func transferRequest(timer signal.ActivityTimer, session *encoding.ServerSession, request *protocol.RequestHeader, input io.Reader, output ray.OutputStream) error {
 	defer output.Close()
 
@@ -9,6 +9,7 @@ package vmess
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
@@ -99,13 +100,17 @@ func (v *TimedUserValidator) Add(user *protocol.User) error {
 	v.Lock()
 	defer v.Unlock()
 
-	idx := len(v.validUsers)
-	v.validUsers = append(v.validUsers, user)
 	rawAccount, err := user.GetTypedAccount()
 	if err != nil {
 		return err
 	}
-	account := rawAccount.(*InternalAccount)
+	account, ok := rawAccount.(*InternalAccount)
+	if !ok {
+		return newError("not a VMess account")
+	}
+
+	idx := len(v.validUsers)
+	v.validUsers = append(v.validUsers, user)
 
 	nowSec := time.Now().Unix()
 
@@ -131,6 +136,58 @@ func (v *TimedUserValidator) Add(user *protocol.User) error {
 	return nil
 }
 
+// Remove implements protocol.UserValidator. Emails are case insensitive. All hashes of the removed user are purged
+// immediately.
+func (v *TimedUserValidator) Remove(email string) bool {
+	v.Lock()
+	defer v.Unlock()
+
+	email = strings.ToLower(email)
+	idx := -1
+	for i, user := range v.validUsers {
+		if strings.ToLower(user.Email) == email {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return false
+	}
+
+	// Move the last user into the place of the removed one, so that indexes of other users stay valid.
+	last := len(v.validUsers) - 1
+	v.validUsers[idx] = v.validUsers[last]
+	v.validUsers[last] = nil
+	v.validUsers = v.validUsers[:last]
+
+	for hash, pair := range v.userHash {
+		switch pair.index {
+		case idx:
+			delete(v.userHash, hash)
+		case last:
+			pair.index = idx
+			v.userHash[hash] = pair
+		}
+	}
+
+	ids := v.ids[:0]
+	for _, entry := range v.ids {
+		if entry.userIdx == idx {
+			continue
+		}
+		if entry.userIdx == last {
+			entry.userIdx = idx
+		}
+		ids = append(ids, entry)
+	}
+	for i := len(ids); i < len(v.ids); i++ {
+		v.ids[i] = nil
+	}
+	v.ids = ids
+
+	return true
+}
+
 func (v *TimedUserValidator) Get(userHash []byte) (*protocol.User, protocol.Timestamp, bool) {
 	defer v.RUnlock()
 	v.RLock()
@@ -0,0 +1,61 @@
+package vmess_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"v2ray.com/core/common/protocol"
+	"v2ray.com/core/common/serial"
+	"v2ray.com/core/common/uuid"
+	. "v2ray.com/core/proxy/vmess"
+	"v2ray.com/core/testing/assert"
+)
+
+func hashOf(id *uuid.UUID, ts protocol.Timestamp) []byte {
+	idHash := protocol.DefaultIDHash(id.Bytes())
+	idHash.Write(ts.Bytes(nil))
+	return idHash.Sum(nil)
+}
+
+func TestUserValidatorRemove(t *testing.T) {
+	assert := assert.On(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	v := NewTimedUserValidator(ctx, protocol.DefaultIDHash)
+
+	id1 := uuid.New()
+	user1 := &protocol.User{
+		Email:   "user1@v2ray.com",
+		Account: serial.ToTypedMessage(&Account{Id: id1.String()}),
+	}
+	id2 := uuid.New()
+	user2 := &protocol.User{
+		Email:   "user2@v2ray.com",
+		Account: serial.ToTypedMessage(&Account{Id: id2.String()}),
+	}
+	assert.Error(v.Add(user1)).IsNil()
+	assert.Error(v.Add(user2)).IsNil()
+
+	ts := protocol.Timestamp(time.Now().Unix())
+
+	u, _, found := v.Get(hashOf(id1, ts))
+	assert.Bool(found).IsTrue()
+	assert.String(u.Email).Equals(user1.Email)
+
+	assert.Bool(v.Remove(user1.Email)).IsTrue()
+	assert.Bool(v.Remove(user1.Email)).IsFalse()
+
+	_, _, found = v.Get(hashOf(id1, ts))
+	assert.Bool(found).IsFalse()
+
+	u, _, found = v.Get(hashOf(id2, ts))
+	assert.Bool(found).IsTrue()
+	assert.String(u.Email).Equals(user2.Email)
+
+	assert.Bool(v.Remove("USER2@v2ray.com")).IsTrue()
+	_, _, found = v.Get(hashOf(id2, ts))
+	assert.Bool(found).IsFalse()
+}
@@ -0,0 +1,345 @@
+package core
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+
+	"v2ray.com/core/app"
+	"v2ray.com/core/app/dns"
+	"v2ray.com/core/app/log"
+	"v2ray.com/core/app/proxyman"
+	"v2ray.com/core/app/router"
+	"v2ray.com/core/common/serial"
+)
+
+// Reload implements Server. Changes are applied one by one, and the config of the server records the ones actually
+// applied, even if the reload fails halfway. So a later reload starts from the real state of the server.
+func (s *simpleServer) Reload(config *Config) error {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if !proto.Equal(s.config.Transport, config.Transport) {
+		log.Trace(newError("changes to transport settings require a restart").AtWarning())
+	}
+
+	ctx := app.ContextWithSpace(context.Background(), s.space)
+	applied := proto.Clone(s.config).(*Config)
+	defer func() {
+		s.config = applied
+	}()
+
+	if err := s.reloadApps(applied, config.App); err != nil {
+		return err
+	}
+	if err := s.reloadInbounds(ctx, applied, config.Inbound); err != nil {
+		return err
+	}
+	if err := s.reloadOutbounds(ctx, applied, config.Outbound); err != nil {
+		return err
+	}
+
+	log.Trace(newError("V2Ray reloaded").AtWarning())
+	return nil
+}
+
+func findApp(apps []*serial.TypedMessage, msgType string) *serial.TypedMessage {
+	for _, app := range apps {
+		if app.Type == msgType {
+			return app
+		}
+	}
+	return nil
+}
+
+// setApp replaces the app of the same type in the list, or appends the app if there is none.
+func setApp(apps []*serial.TypedMessage, app *serial.TypedMessage) []*serial.TypedMessage {
+	for idx := range apps {
+		if apps[idx].Type == app.Type {
+			apps[idx] = app
+			return apps
+		}
+	}
+	return append(apps, app)
+}
+
+func (s *simpleServer) reloadApps(applied *Config, apps []*serial.TypedMessage) error {
+	for _, newApp := range apps {
+		oldApp := findApp(applied.App, newApp.Type)
+		if oldApp != nil && proto.Equal(oldApp, newApp) {
+			continue
+		}
+		settings, err := newApp.GetInstance()
+		if err != nil {
+			return err
+		}
+		switch config := settings.(type) {
+		case *router.Config:
+			r := router.FromSpace(s.space)
+			if r == nil {
+				log.Trace(newError("adding router requires a restart").AtWarning())
+				continue
+			}
+			if err := r.Reload(config); err != nil {
+				return newError("failed to reload router").Base(err)
+			}
+			applied.App = setApp(applied.App, newApp)
+			log.Trace(newError("routing rules reloaded").AtInfo())
+		case *dns.Config:
+			d, ok := dns.FromSpace(s.space).(interface {
+				Reload(*dns.Config) error
+			})
+			if !ok {
+				log.Trace(newError("DNS server doesn't support reloading").AtWarning())
+				continue
+			}
+			if oldApp != nil {
+				if oldSettings, err := oldApp.GetInstance(); err == nil && !nameServersEqual(oldSettings.(*dns.Config), config) {
+					log.Trace(newError("changes to DNS name servers require a restart").AtWarning())
+				}
+			}
+			if err := d.Reload(config); err != nil {
+				return newError("failed to reload DNS").Base(err)
+			}
+			applied.App = setApp(applied.App, newApp)
+			log.Trace(newError("DNS hosts reloaded").AtInfo())
+		default:
+			log.Trace(newError("changes to ", newApp.Type, " require a restart").AtWarning())
+		}
+	}
+	return nil
+}
+
+func nameServersEqual(a, b *dns.Config) bool {
+	if len(a.NameServers) != len(b.NameServers) {
+		return false
+	}
+	for idx := range a.NameServers {
+		if !proto.Equal(a.NameServers[idx], b.NameServers[idx]) {
+			return false
+		}
+	}
+	if len(a.NameServer) != len(b.NameServer) {
+		return false
+	}
+	for idx := range a.NameServer {
+		if !proto.Equal(a.NameServer[idx], b.NameServer[idx]) {
+			return false
+		}
+	}
+	return true
+}
+
+func untaggedEqual(a, b []proto.Message) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if !proto.Equal(a[idx], b[idx]) {
+			return false
+		}
+	}
+	return true
+}
+
+// appliedInbounds returns the inbounds in effect. Untagged inbounds are never reloaded. Tagged inbounds are the ones in
+// the given map, in the order of the old config followed by the new one.
+func appliedInbounds(old []*proxyman.InboundHandlerConfig, inbounds []*proxyman.InboundHandlerConfig, tagged map[string]*proxyman.InboundHandlerConfig) []*proxyman.InboundHandlerConfig {
+	var result []*proxyman.InboundHandlerConfig
+	added := make(map[string]bool)
+	for _, inbound := range old {
+		if len(inbound.Tag) == 0 {
+			result = append(result, inbound)
+		}
+	}
+	for _, list := range [][]*proxyman.InboundHandlerConfig{old, inbounds} {
+		for _, inbound := range list {
+			if applied, found := tagged[inbound.Tag]; found && !added[inbound.Tag] {
+				added[inbound.Tag] = true
+				result = append(result, applied)
+			}
+		}
+	}
+	return result
+}
+
+func (s *simpleServer) reloadInbounds(ctx context.Context, applied *Config, inbounds []*proxyman.InboundHandlerConfig) error {
+	ihm := proxyman.InboundHandlerManagerFromSpace(s.space)
+
+	oldTagged := make(map[string]*proxyman.InboundHandlerConfig)
+	tagged := make(map[string]*proxyman.InboundHandlerConfig)
+	var oldUntagged []proto.Message
+	for _, inbound := range applied.Inbound {
+		if len(inbound.Tag) > 0 {
+			oldTagged[inbound.Tag] = inbound
+			tagged[inbound.Tag] = inbound
+		} else {
+			oldUntagged = append(oldUntagged, inbound)
+		}
+	}
+
+	newTagged := make(map[string]*proxyman.InboundHandlerConfig)
+	var newUntagged []proto.Message
+	for _, inbound := range inbounds {
+		if len(inbound.Tag) > 0 {
+			newTagged[inbound.Tag] = inbound
+		} else {
+			newUntagged = append(newUntagged, inbound)
+		}
+	}
+
+	if !untaggedEqual(oldUntagged, newUntagged) {
+		log.Trace(newError("changes to inbounds without tag require a restart").AtWarning())
+	}
+
+	old := applied.Inbound
+	defer func() {
+		applied.Inbound = appliedInbounds(old, inbounds, tagged)
+	}()
+
+	// removeHandler removes the inbound of the tag. An inbound already gone is considered removed.
+	removeHandler := func(tag string) error {
+		if _, err := ihm.GetHandler(ctx, tag); err != nil {
+			log.Trace(newError("inbound ", tag, " is already removed").AtWarning())
+		} else if err := ihm.RemoveHandler(ctx, tag); err != nil {
+			return newError("failed to remove inbound ", tag).Base(err)
+		}
+		delete(tagged, tag)
+		return nil
+	}
+
+	for tag := range oldTagged {
+		if _, found := newTagged[tag]; !found {
+			if err := removeHandler(tag); err != nil {
+				return err
+			}
+			log.Trace(newError("inbound ", tag, " removed").AtInfo())
+		}
+	}
+
+	for _, inbound := range inbounds {
+		if len(inbound.Tag) == 0 {
+			continue
+		}
+		oldInbound, found := oldTagged[inbound.Tag]
+		if found && proto.Equal(oldInbound, inbound) {
+			continue
+		}
+		if found {
+			// The old handler has to be closed first, as the new one may listen on the same port.
+			if err := removeHandler(inbound.Tag); err != nil {
+				return err
+			}
+		}
+		if err := ihm.AddHandler(ctx, inbound); err != nil {
+			if found {
+				if err := ihm.AddHandler(ctx, oldInbound); err != nil {
+					log.Trace(newError("failed to restore inbound ", inbound.Tag).Base(err).AtError())
+				} else {
+					tagged[inbound.Tag] = oldInbound
+				}
+			}
+			return newError("failed to add inbound ", inbound.Tag).Base(err)
+		}
+		tagged[inbound.Tag] = inbound
+		log.Trace(newError("inbound ", inbound.Tag, " reloaded").AtInfo())
+	}
+
+	return nil
+}
+
+// appliedOutbounds is the same as appliedInbounds, but for outbounds. As the old outbounds come first, the default
+// outbound stays the first one, which reloading never changes.
+func appliedOutbounds(old []*proxyman.OutboundHandlerConfig, outbounds []*proxyman.OutboundHandlerConfig, tagged map[string]*proxyman.OutboundHandlerConfig) []*proxyman.OutboundHandlerConfig {
+	var result []*proxyman.OutboundHandlerConfig
+	added := make(map[string]bool)
+	for _, list := range [][]*proxyman.OutboundHandlerConfig{old, outbounds} {
+		for _, outbound := range list {
+			if applied, found := tagged[outbound.Tag]; found && !added[outbound.Tag] {
+				added[outbound.Tag] = true
+				result = append(result, applied)
+			}
+		}
+	}
+	for idx, outbound := range old {
+		if len(outbound.Tag) == 0 {
+			if idx == 0 {
+				result = append([]*proxyman.OutboundHandlerConfig{outbound}, result...)
+			} else {
+				result = append(result, outbound)
+			}
+		}
+	}
+	return result
+}
+
+func (s *simpleServer) reloadOutbounds(ctx context.Context, applied *Config, outbounds []*proxyman.OutboundHandlerConfig) error {
+	ohm := proxyman.OutboundHandlerManagerFromSpace(s.space)
+
+	oldTagged := make(map[string]*proxyman.OutboundHandlerConfig)
+	tagged := make(map[string]*proxyman.OutboundHandlerConfig)
+	var oldUntagged []proto.Message
+	for _, outbound := range applied.Outbound {
+		if len(outbound.Tag) > 0 {
+			oldTagged[outbound.Tag] = outbound
+			tagged[outbound.Tag] = outbound
+		} else {
+			oldUntagged = append(oldUntagged, outbound)
+		}
+	}
+
+	newTagged := make(map[string]*proxyman.OutboundHandlerConfig)
+	var newUntagged []proto.Message
+	for _, outbound := range outbounds {
+		if len(outbound.Tag) > 0 {
+			newTagged[outbound.Tag] = outbound
+		} else {
+			newUntagged = append(newUntagged, outbound)
+		}
+	}
+
+	if !untaggedEqual(oldUntagged, newUntagged) {
+		log.Trace(newError("changes to outbounds without tag require a restart").AtWarning())
+	}
+	if len(applied.Outbound) > 0 && len(outbounds) > 0 && applied.Outbound[0].Tag != outbounds[0].Tag {
+		log.Trace(newError("changes to the default outbound require a restart").AtWarning())
+	}
+
+	old := applied.Outbound
+	defer func() {
+		applied.Outbound = appliedOutbounds(old, outbounds, tagged)
+	}()
+
+	for tag := range oldTagged {
+		if _, found := newTagged[tag]; !found {
+			if ohm.GetHandler(tag) == nil {
+				log.Trace(newError("outbound ", tag, " is already removed").AtWarning())
+				delete(tagged, tag)
+				continue
+			}
+			if err := ohm.RemoveHandler(ctx, tag); err != nil {
+				log.Trace(newError("failed to remove outbound ", tag).Base(err).AtWarning())
+				continue
+			}
+			delete(tagged, tag)
+			log.Trace(newError("outbound ", tag, " removed").AtInfo())
+		}
+	}
+
+	for _, outbound := range outbounds {
+		if len(outbound.Tag) == 0 {
+			continue
+		}
+		if oldOutbound, found := oldTagged[outbound.Tag]; found && proto.Equal(oldOutbound, outbound) {
+			continue
+		}
+		// AddHandler replaces the existing handler with the same tag.
+		if err := ohm.AddHandler(ctx, outbound); err != nil {
+			return newError("failed to add outbound ", outbound.Tag).Base(err)
+		}
+		tagged[outbound.Tag] = outbound
+		log.Trace(newError("outbound ", outbound.Tag, " reloaded").AtInfo())
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package core_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	. "v2ray.com/core"
+	"v2ray.com/core/app/proxyman"
+	"v2ray.com/core/common"
+	v2net "v2ray.com/core/common/net"
+	"v2ray.com/core/common/serial"
+	"v2ray.com/core/proxy/dokodemo"
+	"v2ray.com/core/proxy/freedom"
+	"v2ray.com/core/testing/assert"
+	"v2ray.com/core/testing/servers/tcp"
+)
+
+func pickPort() v2net.Port {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	return v2net.Port(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func xor(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i, v := range b {
+		r[i] = v ^ 'c'
+	}
+	return r
+}
+
+func dokodemoInbound(tag string, port v2net.Port, dest v2net.Destination) *proxyman.InboundHandlerConfig {
+	return &proxyman.InboundHandlerConfig{
+		Tag: tag,
+		ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+			PortRange: v2net.SinglePortRange(port),
+			Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+		}),
+		ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+			Address: v2net.NewIPOrDomain(dest.Address),
+			Port:    uint32(dest.Port),
+			NetworkList: &v2net.NetworkList{
+				Network: []v2net.Network{v2net.Network_TCP},
+			},
+		}),
+	}
+}
+
+func roundTrip(assert *assert.Assert, conn net.Conn, payload string) []byte {
+	nBytes, err := conn.Write([]byte(payload))
+	assert.Error(err).IsNil()
+	assert.Int(nBytes).Equals(len(payload))
+
+	response := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+	nBytes, err = conn.Read(response)
+	assert.Error(err).IsNil()
+	return response[:nBytes]
+}
+
+func TestV2RayReload(t *testing.T) {
+	assert := assert.On(t)
+
+	xorServer := tcp.Server{
+		MsgProcessor: xor,
+	}
+	xorDest, err := xorServer.Start()
+	assert.Error(err).IsNil()
+	defer xorServer.Close()
+
+	echoServer := tcp.Server{
+		MsgProcessor: func(b []byte) []byte { return b },
+	}
+	echoDest, err := echoServer.Start()
+	assert.Error(err).IsNil()
+	defer echoServer.Close()
+
+	port1 := pickPort()
+	port2 := pickPort()
+	config := &Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			dokodemoInbound("kept", port1, xorDest),
+			dokodemoInbound("changed", port2, xorDest),
+		},
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	server, err := New(config)
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	keptConn, err := net.DialTCP("tcp", nil, &net.TCPAddr{IP: []byte{127, 0, 0, 1}, Port: int(port1)})
+	assert.Error(err).IsNil()
+	defer keptConn.Close()
+	assert.Bytes(roundTrip(assert, keptConn, "before reload")).Equals(xor([]byte("before reload")))
+
+	assert.Error(server.Reload(&Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			dokodemoInbound("kept", port1, xorDest),
+			dokodemoInbound("changed", port2, echoDest),
+		},
+		Outbound: config.Outbound,
+	})).IsNil()
+
+	// Connections of untouched handlers survive the reload.
+	assert.Bytes(roundTrip(assert, keptConn, "after reload")).Equals(xor([]byte("after reload")))
+
+	changedConn, err := net.DialTCP("tcp", nil, &net.TCPAddr{IP: []byte{127, 0, 0, 1}, Port: int(port2)})
+	assert.Error(err).IsNil()
+	defer changedConn.Close()
+	assert.Bytes(roundTrip(assert, changedConn, "changed")).Equals([]byte("changed"))
+
+	assert.Error(server.Reload(&Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			dokodemoInbound("kept", port1, xorDest),
+		},
+		Outbound: config.Outbound,
+	})).IsNil()
+
+	_, err = net.DialTCP("tcp", nil, &net.TCPAddr{IP: []byte{127, 0, 0, 1}, Port: int(port2)})
+	assert.Error(err).IsNotNil()
+}
+
+func TestV2RayReloadPartialFailure(t *testing.T) {
+	assert := assert.On(t)
+
+	xorServer := tcp.Server{
+		MsgProcessor: xor,
+	}
+	xorDest, err := xorServer.Start()
+	assert.Error(err).IsNil()
+	defer xorServer.Close()
+
+	port1 := pickPort()
+	port2 := pickPort()
+	config := &Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			dokodemoInbound("kept", port1, xorDest),
+			dokodemoInbound("removed", port2, xorDest),
+		},
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	server, err := New(config)
+	assert.Error(err).IsNil()
+	assert.Error(server.Start()).IsNil()
+	defer server.Close()
+
+	busy, err := net.Listen("tcp4", "127.0.0.1:0")
+	assert.Error(err).IsNil()
+	busyPort := v2net.Port(busy.Addr().(*net.TCPAddr).Port)
+
+	// "removed" is gone before the second inbound fails to bind.
+	assert.Error(server.Reload(&Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			dokodemoInbound("kept", port1, xorDest),
+			dokodemoInbound("busy", busyPort, xorDest),
+		},
+		Outbound: config.Outbound,
+	})).IsNotNil()
+
+	_, err = net.DialTCP("tcp", nil, &net.TCPAddr{IP: []byte{127, 0, 0, 1}, Port: int(port2)})
+	assert.Error(err).IsNotNil()
+
+	// A later reload starts from what was actually applied.
+	busy.Close()
+	assert.Error(server.Reload(&Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			dokodemoInbound("kept", port1, xorDest),
+			dokodemoInbound("busy", busyPort, xorDest),
+		},
+		Outbound: config.Outbound,
+	})).IsNil()
+
+	conn, err := net.DialTCP("tcp", nil, &net.TCPAddr{IP: []byte{127, 0, 0, 1}, Port: int(busyPort)})
+	assert.Error(err).IsNil()
+	defer conn.Close()
+	assert.Bytes(roundTrip(assert, conn, "after reload")).Equals(xor([]byte("after reload")))
+}
@@ -0,0 +1,21 @@
+package core
+
+import (
+	"v2ray.com/core/app/router"
+)
+
+// TraceRoute creates a V2Ray server with the given config without starting it, and returns how its router
+// routes the connection described by the query.
+func TraceRoute(config *Config, query *router.RouteQuery) (*router.Trace, error) {
+	server, err := newSimpleServer(config)
+	if err != nil {
+		return nil, err
+	}
+	defer server.Close()
+
+	r := router.FromSpace(server.space)
+	if r == nil {
+		return nil, newError("no router in config")
+	}
+	return r.Trace(query.Context()), nil
+}
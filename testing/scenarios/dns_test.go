@@ -2,8 +2,11 @@ package scenarios
 
 import (
 	"fmt"
+	"net"
 	"testing"
+	"time"
 
+	dnsmsg "github.com/miekg/dns"
 	xproxy "golang.org/x/net/proxy"
 	"v2ray.com/core"
 	"v2ray.com/core/app/dns"
@@ -12,6 +15,7 @@ import (
 	v2net "v2ray.com/core/common/net"
 	"v2ray.com/core/common/serial"
 	"v2ray.com/core/proxy/blackhole"
+	dnsproxy "v2ray.com/core/proxy/dns"
 	"v2ray.com/core/proxy/freedom"
 	"v2ray.com/core/proxy/socks"
 	"v2ray.com/core/testing/assert"
@@ -103,3 +107,169 @@ func TestResolveIP(t *testing.T) {
 
 	CloseAllServers(servers)
 }
+
+func TestDNSInbound(t *testing.T) {
+	assert := assert.On(t)
+
+	upstreamPort := pickUDPPort()
+	upstreamConn, err := net.ListenUDP("udp", &net.UDPAddr{
+		IP:   v2net.LocalHostIP.IP(),
+		Port: int(upstreamPort),
+	})
+	assert.Error(err).IsNil()
+	upstream := &dnsmsg.Server{
+		PacketConn: upstreamConn,
+		Handler: dnsmsg.HandlerFunc(func(w dnsmsg.ResponseWriter, query *dnsmsg.Msg) {
+			reply := new(dnsmsg.Msg)
+			reply.SetReply(query)
+			rr, _ := dnsmsg.NewRR(query.Question[0].Name + " 60 IN TXT \"upstream\"")
+			reply.Answer = append(reply.Answer, rr)
+			w.WriteMsg(reply)
+		}),
+	}
+	go upstream.ActivateAndServe()
+	defer upstream.Shutdown()
+
+	serverPort := pickPort()
+	serverConfig := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dns.Config{
+				Hosts: map[string]*v2net.IPOrDomain{
+					"v2ray.com": v2net.NewIPOrDomain(v2net.ParseAddress("1.2.3.4")),
+				},
+			}),
+		},
+		Inbound: []*proxyman.InboundHandlerConfig{
+			{
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: v2net.SinglePortRange(serverPort),
+					Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+				}),
+				ProxySettings: serial.ToTypedMessage(&dnsproxy.Config{
+					Server: &v2net.Endpoint{
+						Network: v2net.Network_UDP,
+						Address: v2net.NewIPOrDomain(v2net.LocalHostIP),
+						Port:    uint32(upstreamPort),
+					},
+				}),
+			},
+		},
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	servers, err := InitializeServerConfigs(serverConfig)
+	assert.Error(err).IsNil()
+
+	serverAddr := v2net.TCPDestination(v2net.LocalHostIP, serverPort).NetAddr()
+	for _, network := range []string{"udp", "tcp"} {
+		client := &dnsmsg.Client{
+			Net:     network,
+			Timeout: time.Second * 5,
+		}
+
+		query := new(dnsmsg.Msg)
+		query.SetQuestion("v2ray.com.", dnsmsg.TypeA)
+		reply, _, err := client.Exchange(query, serverAddr)
+		assert.Error(err).IsNil()
+		assert.Int(len(reply.Answer)).Equals(1)
+		assert.String(reply.Answer[0].(*dnsmsg.A).A.String()).Equals("1.2.3.4")
+
+		query = new(dnsmsg.Msg)
+		query.SetQuestion("v2ray.com.", dnsmsg.TypeTXT)
+		reply, _, err = client.Exchange(query, serverAddr)
+		assert.Error(err).IsNil()
+		assert.Int(len(reply.Answer)).Equals(1)
+		assert.String(reply.Answer[0].(*dnsmsg.TXT).Txt[0]).Equals("upstream")
+	}
+
+	CloseAllServers(servers)
+}
+
+func TestFakeDNS(t *testing.T) {
+	assert := assert.On(t)
+
+	tcpServer := tcp.Server{
+		MsgProcessor: xor,
+	}
+	dest, err := tcpServer.Start()
+	assert.Error(err).IsNil()
+	defer tcpServer.Close()
+
+	dnsPort := pickPort()
+	socksPort := pickPort()
+	serverConfig := &core.Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&dns.Config{
+				Hosts: map[string]*v2net.IPOrDomain{
+					"fake.test": v2net.NewIPOrDomain(dest.Address),
+				},
+				FakeDns: &dns.FakeDNSConfig{},
+			}),
+		},
+		Inbound: []*proxyman.InboundHandlerConfig{
+			{
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: v2net.SinglePortRange(dnsPort),
+					Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+				}),
+				ProxySettings: serial.ToTypedMessage(&dnsproxy.Config{
+					FakeIp: true,
+				}),
+			},
+			{
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: v2net.SinglePortRange(socksPort),
+					Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+				}),
+				ProxySettings: serial.ToTypedMessage(&socks.ServerConfig{
+					AuthType: socks.AuthType_NO_AUTH,
+					Address:  v2net.NewIPOrDomain(v2net.LocalHostIP),
+				}),
+			},
+		},
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{
+					DomainStrategy: freedom.Config_USE_IP,
+				}),
+			},
+		},
+	}
+
+	servers, err := InitializeServerConfigs(serverConfig)
+	assert.Error(err).IsNil()
+
+	client := &dnsmsg.Client{
+		Net:     "udp",
+		Timeout: time.Second * 5,
+	}
+	query := new(dnsmsg.Msg)
+	query.SetQuestion("fake.test.", dnsmsg.TypeA)
+	reply, _, err := client.Exchange(query, v2net.UDPDestination(v2net.LocalHostIP, dnsPort).NetAddr())
+	assert.Error(err).IsNil()
+	assert.Int(len(reply.Answer)).Equals(1)
+	fakeIP := reply.Answer[0].(*dnsmsg.A).A
+	assert.String(fakeIP.String()).Equals("198.18.0.1")
+
+	{
+		noAuthDialer, err := xproxy.SOCKS5("tcp", v2net.TCPDestination(v2net.LocalHostIP, socksPort).NetAddr(), nil, xproxy.Direct)
+		assert.Error(err).IsNil()
+		conn, err := noAuthDialer.Dial("tcp", v2net.TCPDestination(v2net.IPAddress(fakeIP), dest.Port).NetAddr())
+		assert.Error(err).IsNil()
+
+		payload := "test payload"
+		nBytes, err := conn.Write([]byte(payload))
+		assert.Error(err).IsNil()
+		assert.Int(nBytes).Equals(len(payload))
+
+		response := readFrom(conn, time.Second*5, len(payload))
+		assert.Bytes(response).Equals(xor([]byte(payload)))
+		assert.Error(conn.Close()).IsNil()
+	}
+
+	CloseAllServers(servers)
+}
@@ -4,10 +4,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"v2ray.com/core"
 	"v2ray.com/core/app/proxyman"
+	"v2ray.com/core/app/web"
+	"v2ray.com/core/common"
 	v2net "v2ray.com/core/common/net"
 	"v2ray.com/core/common/serial"
 	"v2ray.com/core/proxy/freedom"
@@ -72,3 +76,86 @@ func TestHttpConformance(t *testing.T) {
 
 	CloseAllServers(servers)
 }
+
+func TestHttpWebFallback(t *testing.T) {
+	assert := assert.On(t)
+
+	httpServerPort := pickPort()
+	httpServer := &v2httptest.Server{
+		Port:        httpServerPort,
+		PathHandler: make(map[string]http.HandlerFunc),
+	}
+	_, err := httpServer.Start()
+	assert.Error(err).IsNil()
+	defer httpServer.Close()
+
+	dir, err := ioutil.TempDir("", "v2ray-web")
+	assert.Error(err).IsNil()
+	defer os.RemoveAll(dir)
+	common.Must(ioutil.WriteFile(filepath.Join(dir, "decoy.txt"), []byte("decoy"), 0644))
+
+	serverPort := pickPort()
+	serverConfig := &core.Config{
+		Inbound: []*proxyman.InboundHandlerConfig{
+			{
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: v2net.SinglePortRange(serverPort),
+					Listen:    v2net.NewIPOrDomain(v2net.LocalHostIP),
+				}),
+				ProxySettings: serial.ToTypedMessage(&v2http.ServerConfig{
+					WebFallback: true,
+				}),
+			},
+		},
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&web.Config{
+				Server: []*web.Server{
+					{
+						Settings: serial.ToTypedMessage(&web.FileServer{
+							Entry: []*web.FileServer_Entry{
+								{
+									Path:      "/",
+									FileOrDir: &web.FileServer_Entry_Directory{Directory: dir},
+								},
+							},
+						}),
+					},
+				},
+			}),
+		},
+	}
+
+	servers, err := InitializeServerConfigs(serverConfig)
+	assert.Error(err).IsNil()
+	defer CloseAllServers(servers)
+
+	// A plain request to the proxy port gets the decoy site.
+	resp, err := http.Get("http://127.0.0.1:" + serverPort.String() + "/decoy.txt")
+	assert.Error(err).IsNil()
+	assert.Int(resp.StatusCode).Equals(200)
+	content, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Error(err).IsNil()
+	assert.String(string(content)).Equals("decoy")
+
+	// Proxy requests still work.
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy: func(req *http.Request) (*url.URL, error) {
+				return url.Parse("http://127.0.0.1:" + serverPort.String())
+			},
+		},
+	}
+	resp, err = client.Get("http://127.0.0.1:" + httpServerPort.String())
+	assert.Error(err).IsNil()
+	assert.Int(resp.StatusCode).Equals(200)
+	content, err = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Error(err).IsNil()
+	assert.String(string(content)).Equals("Home")
+}
@@ -1,16 +1,40 @@
 package conf
 
 import (
+	"bytes"
 	"io"
 
 	"v2ray.com/core"
+	"v2ray.com/core/common"
 	jsonconf "v2ray.com/ext/tools/conf/serial"
 )
 
 //go:generate go run $GOPATH/src/v2ray.com/core/tools/generrorgen/main.go -pkg conf -path Tools,Conf
 
+func jsonEquivalentLoader(toJSON func(io.Reader) ([]byte, error)) core.ConfigLoader {
+	return func(input io.Reader) (*core.Config, error) {
+		data, err := toJSON(input)
+		if err != nil {
+			return nil, err
+		}
+		return jsonconf.LoadJSONConfig(bytes.NewReader(data))
+	}
+}
+
 func init() {
 	core.RegisterConfigLoader(core.ConfigFormat_JSON, func(input io.Reader) (*core.Config, error) {
 		return jsonconf.LoadJSONConfig(input)
 	})
+	common.Must(core.RegisterConfigLoader(core.ConfigFormat_YAML, func(input io.Reader) (*core.Config, error) {
+		data, locator, err := yamlToJSON(input)
+		if err != nil {
+			return nil, err
+		}
+		config, err := jsonconf.LoadJSONConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil, locator.locateError(err)
+		}
+		return config, nil
+	}))
+	common.Must(core.RegisterConfigLoader(core.ConfigFormat_TOML, jsonEquivalentLoader(tomlToJSON)))
 }
@@ -0,0 +1,202 @@
+package conf
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"v2ray.com/core/testing/assert"
+)
+
+const expectedJSON = `{
+  "log": {"loglevel": "warning"},
+  "inbound": {
+    "port": 1080,
+    "listen": "127.0.0.1",
+    "protocol": "socks",
+    "settings": {"auth": "noauth", "udp": true}
+  },
+  "outbound": {"protocol": "freedom", "settings": {}},
+  "inboundDetour": [
+    {"port": "10000-10010", "protocol": "dokodemo-door", "settings": {"address": "1.1.1.1", "port": 53, "network": "tcp,udp"}}
+  ]
+}`
+
+func assertSameJSON(assert *assert.Assert, actual []byte, expected string) {
+	var a, e interface{}
+	assert.Error(json.Unmarshal(actual, &a)).IsNil()
+	assert.Error(json.Unmarshal([]byte(expected), &e)).IsNil()
+	aText, _ := json.Marshal(a)
+	eText, _ := json.Marshal(e)
+	assert.String(string(aText)).Equals(string(eText))
+}
+
+func TestYAMLToJSON(t *testing.T) {
+	assert := assert.On(t)
+
+	data, _, err := yamlToJSON(strings.NewReader(`
+# Local socks proxy.
+log:
+  loglevel: warning
+inbound:
+  port: 1080
+  listen: 127.0.0.1
+  protocol: socks
+  settings: &socks
+    auth: noauth
+    udp: true
+outbound:
+  protocol: freedom
+  settings: {}
+inboundDetour:
+  - port: "10000-10010"
+    protocol: dokodemo-door
+    settings:
+      address: 1.1.1.1 # upstream DNS
+      port: 53
+      network: tcp,udp
+`))
+	assert.Error(err).IsNil()
+	assertSameJSON(assert, data, expectedJSON)
+}
+
+func TestYAMLMerge(t *testing.T) {
+	assert := assert.On(t)
+
+	data, _, err := yamlToJSON(strings.NewReader(`
+base: &base
+  a: 1
+  b: 2
+derived:
+  <<: *base
+  b: 3
+`))
+	assert.Error(err).IsNil()
+	assertSameJSON(assert, data, `{"base": {"a": 1, "b": 2}, "derived": {"a": 1, "b": 3}}`)
+}
+
+func TestYAMLError(t *testing.T) {
+	assert := assert.On(t)
+
+	_, _, err := yamlToJSON(strings.NewReader("inbound:\n  port: 1080\n   protocol: socks\n"))
+	assert.Error(err).IsNotNil()
+	assert.String(err.Error()).Contains("line 3")
+
+	_, _, err = yamlToJSON(strings.NewReader("inbound:\n  ? [a, b]\n  : c\n"))
+	assert.Error(err).IsNotNil()
+	assert.String(err.Error()).Contains("line 2 column 5")
+}
+
+func TestYAMLAlias(t *testing.T) {
+	assert := assert.On(t)
+
+	_, _, err := yamlToJSON(strings.NewReader("a: &x\n  b: *x\n"))
+	assert.Error(err).IsNotNil()
+	assert.String(err.Error()).Contains("recursive alias")
+
+	_, _, err = yamlToJSON(strings.NewReader("a: &x\n  <<: *x\n"))
+	assert.Error(err).IsNotNil()
+	assert.String(err.Error()).Contains("recursive alias")
+
+	laughs := "a0: &a0 [lol, lol, lol, lol, lol, lol, lol, lol, lol, lol]\n"
+	for i := 1; i < 10; i++ {
+		name := "a" + strconv.Itoa(i)
+		refs := strings.Repeat(", *a"+strconv.Itoa(i-1), 10)
+		laughs += name + ": &" + name + " [" + refs[2:] + "]\n"
+	}
+	_, _, err = yamlToJSON(strings.NewReader(laughs))
+	assert.Error(err).IsNotNil()
+	assert.String(err.Error()).Contains("too many nodes")
+
+	data, _, err := yamlToJSON(strings.NewReader("a: &x [1, 2]\nb: [*x, *x]\n"))
+	assert.Error(err).IsNil()
+	assertSameJSON(assert, data, `{"a": [1, 2], "b": [[1, 2], [1, 2]]}`)
+}
+
+func TestYAMLLocateError(t *testing.T) {
+	assert := assert.On(t)
+
+	data, locator, err := yamlToJSON(strings.NewReader(`
+base: &base
+  port: 1080
+inbound:
+  <<: *base
+  settings:
+    clients:
+      - id: 1
+      - id: x
+`))
+	assert.Error(err).IsNil()
+
+	var config struct {
+		Inbound struct {
+			Port     int             `json:"port"`
+			Settings json.RawMessage `json:"settings"`
+		} `json:"inbound"`
+	}
+	assert.Error(json.Unmarshal(data, &config)).IsNil()
+
+	// Settings are decoded on their own, so the path in the error is relative to them.
+	var settings struct {
+		Clients []struct {
+			ID int `json:"id"`
+		} `json:"clients"`
+	}
+	err = json.Unmarshal(config.Inbound.Settings, &settings)
+	assert.Error(err).IsNotNil()
+	err = locator.locateError(newError("failed to build inbound settings").Base(err))
+	assert.String(err.Error()).Contains("YAML line 9 column 13")
+
+	data, locator, err = yamlToJSON(strings.NewReader("inbound:\n  port: [1080]\n"))
+	assert.Error(err).IsNil()
+	err = json.Unmarshal(data, &config)
+	assert.Error(err).IsNotNil()
+	assert.String(locator.locateError(err).Error()).Contains("YAML line 2 column 9")
+
+	err = newError("invalid port")
+	assert.Error(locator.locateError(err)).Equals(err)
+}
+
+func TestTOMLToJSON(t *testing.T) {
+	assert := assert.On(t)
+
+	data, err := tomlToJSON(strings.NewReader(`
+# Local socks proxy.
+[log]
+loglevel = "warning"
+
+[inbound]
+port = 1080
+listen = "127.0.0.1"
+protocol = "socks"
+
+[inbound.settings]
+auth = "noauth"
+udp = true
+
+[outbound]
+protocol = "freedom"
+
+[outbound.settings]
+
+[[inboundDetour]]
+port = "10000-10010"
+protocol = "dokodemo-door"
+
+[inboundDetour.settings]
+address = "1.1.1.1" # upstream DNS
+port = 53
+network = "tcp,udp"
+`))
+	assert.Error(err).IsNil()
+	assertSameJSON(assert, data, expectedJSON)
+}
+
+func TestTOMLError(t *testing.T) {
+	assert := assert.On(t)
+
+	_, err := tomlToJSON(strings.NewReader("[inbound]\nport = 1080\nprotocol = socks\n"))
+	assert.Error(err).IsNotNil()
+	assert.String(err.Error()).Contains("TOML line 3 column 12")
+}
@@ -0,0 +1,69 @@
+package conf
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// tomlToJSON converts a TOML document into its JSON equivalent, so that it can be loaded as a JSON config.
+func tomlToJSON(input io.Reader) ([]byte, error) {
+	var root map[string]interface{}
+	if _, err := toml.NewDecoder(input).Decode(&root); err != nil {
+		if pe, ok := err.(toml.ParseError); ok {
+			return nil, newError("TOML line ", pe.Position.Line, " column ", pe.Position.Col, ": ", pe.Message)
+		}
+		return nil, newError("failed to parse TOML config").Base(err)
+	}
+	value, err := tomlNormalize(root)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// tomlNormalize converts values that have no JSON equivalent.
+func tomlNormalize(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			normalized, err := tomlNormalize(item)
+			if err != nil {
+				return nil, newError("invalid value of ", key).Base(err)
+			}
+			v[key] = normalized
+		}
+		return v, nil
+	case []map[string]interface{}:
+		list := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			normalized, err := tomlNormalize(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, normalized)
+		}
+		return list, nil
+	case []interface{}:
+		for i, item := range v {
+			normalized, err := tomlNormalize(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = normalized
+		}
+		return v, nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, newError("invalid number ", v)
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,255 @@
+package conf
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"v2ray.com/core/common/errors"
+)
+
+// yamlToJSON converts a YAML document into its JSON equivalent, so that it can be loaded as a JSON config. The
+// returned locator finds the positions of values in the YAML document for errors of loading the JSON config.
+func yamlToJSON(input io.Reader) ([]byte, *yamlLocator, error) {
+	data, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, nil, newError("failed to read YAML config").Base(err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, newError("failed to parse YAML config").Base(err)
+	}
+	if len(root.Content) == 0 {
+		return nil, nil, newError("empty YAML config")
+	}
+	decoder := &yamlDecoder{
+		anchors: make(map[*yaml.Node]bool),
+		locator: &yamlLocator{
+			nodes: make(map[string]*yaml.Node),
+		},
+	}
+	value, err := decoder.nodeToValue(root.Content[0], "")
+	if err != nil {
+		return nil, nil, err
+	}
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return jsonData, decoder.locator, nil
+}
+
+func yamlError(node *yaml.Node, values ...interface{}) *errors.Error {
+	return newError(append([]interface{}{"YAML line ", node.Line, " column ", node.Column, ": "}, values...)...)
+}
+
+// yamlLocator maps the paths of values in the JSON equivalent config back to their nodes in the YAML document. A path
+// is the keys and indexes from the root joined by ".", in the form of encoding/json errors.
+type yamlLocator struct {
+	nodes map[string]*yaml.Node
+}
+
+// record records the node of the value at the given path. The first node recorded for a path is kept, as it is
+// the one in the final value when keys are merged.
+func (l *yamlLocator) record(path string, node *yaml.Node) {
+	path = strings.ToLower(path)
+	if _, found := l.nodes[path]; !found {
+		l.nodes[path] = node
+	}
+}
+
+// locate returns the node of the value at the given path, or nil if it is unknown. As settings of proxies are
+// decoded on their own, the path may also be relative to such a value, which is accepted if it matches only one
+// value in the document.
+func (l *yamlLocator) locate(path string) *yaml.Node {
+	path = strings.ToLower(path)
+	if node, found := l.nodes[path]; found {
+		return node
+	}
+	var match *yaml.Node
+	for p, node := range l.nodes {
+		if strings.HasSuffix(p, "."+path) {
+			if match != nil {
+				return nil
+			}
+			match = node
+		}
+	}
+	return match
+}
+
+// locateError adds the YAML position to an error of loading the JSON equivalent config, if the error is caused by
+// a value of wrong type. Other errors are returned as is, as they don't tell which value is invalid.
+func (l *yamlLocator) locateError(err error) error {
+	for cause := err; cause != nil; {
+		if typeErr, ok := cause.(*json.UnmarshalTypeError); ok {
+			if node := l.locate(typeErr.Field); node != nil {
+				return yamlError(node, "invalid value of ", typeErr.Field).Base(err)
+			}
+			return err
+		}
+		switch e := cause.(type) {
+		case interface{ Inner() error }:
+			cause = e.Inner()
+		case interface{ Unwrap() error }:
+			cause = e.Unwrap()
+		default:
+			cause = nil
+		}
+	}
+	return err
+}
+
+func joinYAMLPath(path string, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+// yamlMaxNodes is the maximum number of nodes in a YAML config after expanding aliases.
+const yamlMaxNodes = 1 << 20
+
+// yamlDecoder converts YAML nodes into values. It rejects recursive aliases, and limits the total size of the
+// expanded document, so that a small document with nested aliases can't expand into a huge one.
+type yamlDecoder struct {
+	// anchors are the anchored nodes on the path to the current node.
+	anchors map[*yaml.Node]bool
+	nodes   int
+	locator *yamlLocator
+}
+
+// enter is called when converting the given node starts. The returned function must be called when it finishes.
+func (d *yamlDecoder) enter(node *yaml.Node) (func(), error) {
+	d.nodes++
+	if d.nodes > yamlMaxNodes {
+		return nil, yamlError(node, "too many nodes after expanding aliases")
+	}
+	if node.Kind == yaml.AliasNode && d.anchors[node.Alias] {
+		return nil, yamlError(node, "recursive alias ", node.Value)
+	}
+	if len(node.Anchor) == 0 {
+		return func() {}, nil
+	}
+	d.anchors[node] = true
+	return func() { delete(d.anchors, node) }, nil
+}
+
+func (d *yamlDecoder) nodeToValue(node *yaml.Node, path string) (interface{}, error) {
+	leave, err := d.enter(node)
+	if err != nil {
+		return nil, err
+	}
+	defer leave()
+	d.locator.record(path, node)
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return d.nodeToValue(node.Content[0], path)
+	case yaml.AliasNode:
+		return d.nodeToValue(node.Alias, path)
+	case yaml.ScalarNode:
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return nil, yamlError(node, "invalid value ", node.Value).Base(err)
+		}
+		switch v := value.(type) {
+		case float64:
+			if math.IsInf(v, 0) || math.IsNaN(v) {
+				return nil, yamlError(node, "invalid number ", node.Value)
+			}
+			return v, nil
+		case nil, bool, int, int64, uint64, string:
+			return value, nil
+		default:
+			// Timestamps and binary values are kept as written.
+			return node.Value, nil
+		}
+	case yaml.SequenceNode:
+		list := make([]interface{}, 0, len(node.Content))
+		for i, item := range node.Content {
+			value, err := d.nodeToValue(item, joinYAMLPath(path, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case yaml.MappingNode:
+		obj := make(map[string]interface{})
+		if err := d.mergeMapping(obj, node, path); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	default:
+		return nil, yamlError(node, "unsupported YAML node")
+	}
+}
+
+// mergeMapping converts the entries of the mapping node into obj. Explicit keys are converted before merged ones,
+// so that they are recorded first in the locator.
+func (d *yamlDecoder) mergeMapping(obj map[string]interface{}, node *yaml.Node, path string) error {
+	var merges []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode := node.Content[i]
+		valueNode := node.Content[i+1]
+		if keyNode.Kind == yaml.ScalarNode && keyNode.Tag == "!!merge" {
+			merges = append(merges, valueNode)
+			continue
+		}
+		if keyNode.Kind != yaml.ScalarNode {
+			return yamlError(keyNode, "mapping key must be a scalar")
+		}
+		value, err := d.nodeToValue(valueNode, joinYAMLPath(path, keyNode.Value))
+		if err != nil {
+			return err
+		}
+		obj[keyNode.Value] = value
+	}
+	for _, valueNode := range merges {
+		if err := d.mergeValue(obj, valueNode, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *yamlDecoder) mergeValue(obj map[string]interface{}, node *yaml.Node, path string) error {
+	leave, err := d.enter(node)
+	if err != nil {
+		return err
+	}
+	defer leave()
+
+	switch node.Kind {
+	case yaml.AliasNode:
+		return d.mergeValue(obj, node.Alias, path)
+	case yaml.MappingNode:
+		merged := make(map[string]interface{})
+		if err := d.mergeMapping(merged, node, path); err != nil {
+			return err
+		}
+		for k, v := range merged {
+			if _, found := obj[k]; !found {
+				obj[k] = v
+			}
+		}
+		return nil
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if err := d.mergeValue(obj, item, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return yamlError(node, "merge value must be a mapping")
+	}
+}
@@ -0,0 +1,5 @@
+package main
+
+import "v2ray.com/core/common/errors"
+
+func newError(values ...interface{}) *errors.Error { return errors.New(values...).Path("Tools", "GeoData") }
@@ -0,0 +1,264 @@
+// Command geodata generates external data files for router rules.
+//
+// geoip.dat is a GeoIPList and geosite.dat is a GeoSiteList, both defined in v2ray.com/core/app/router/config.proto.
+//
+// IP lists are read from a directory, where each file contains the IP ranges of one entry, named after the file
+// without extension. Each line is an IP or CIDR. IP ranges of all countries may also be read from an APNIC
+// delegated stats file.
+//
+// Domain lists are read from a directory, where each file contains the domains of one entry. Each line is
+// one of "domain:example.com" (the domain and its sub-domains), "full:example.com" (the exact domain),
+// "keyword:label", "regexp:pattern" and "plain:text" (substring), or a domain without prefix.
+//
+// Text after "#" is ignored in all lists.
+package main
+
+//go:generate go run $GOPATH/src/v2ray.com/core/tools/generrorgen/main.go -pkg main -path Tools,GeoData
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"v2ray.com/core/app/router"
+)
+
+var (
+	ipDir    = flag.String("ipdir", "", "Directory of IP lists.")
+	apnic    = flag.String("apnic", "", "File or URL of APNIC delegated stats, e.g. http://ftp.apnic.net/apnic/stats/apnic/delegated-apnic-latest")
+	siteDir  = flag.String("sitedir", "", "Directory of domain lists.")
+	ipOut    = flag.String("ipout", "geoip.dat", "Output GeoIP file.")
+	siteOut  = flag.String("siteout", "geosite.dat", "Output domain list file.")
+	revision = flag.String("revision", time.Now().UTC().Format("20060102150405"), "Revision of the generated data.")
+)
+
+func readLines(reader io.Reader, f func(line string) error) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if len(line) == 0 {
+			continue
+		}
+		if err := f(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// listFiles returns entry names and paths of all regular files in the directory.
+func listFiles(dir string) (map[string]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]string)
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		name := strings.TrimSuffix(info.Name(), filepath.Ext(info.Name()))
+		files[strings.ToUpper(name)] = filepath.Join(dir, info.Name())
+	}
+	return files, nil
+}
+
+func loadIPDir(dir string, entries map[string]*router.GeoIP) error {
+	files, err := listFiles(dir)
+	if err != nil {
+		return err
+	}
+	for code, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		entry := getGeoIP(entries, code)
+		err = readLines(file, func(line string) error {
+			cidr, err := router.ParseCIDR(line)
+			if err != nil {
+				return newError("invalid line in ", path).Base(err)
+			}
+			entry.Cidr = append(entry.Cidr, cidr)
+			return nil
+		})
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func openSource(source string) (io.ReadCloser, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := http.Get(source)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != 200 {
+			resp.Body.Close()
+			return nil, newError("unexpected status ", resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+	return os.Open(source)
+}
+
+// loadAPNIC reads lines like "apnic|CN|ipv4|1.0.1.0|256|20110414|allocated".
+func loadAPNIC(source string, entries map[string]*router.GeoIP) error {
+	reader, err := openSource(source)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return readLines(reader, func(line string) error {
+		parts := strings.Split(line, "|")
+		if len(parts) < 5 || len(parts[1]) != 2 || parts[1] == "*" {
+			return nil
+		}
+		var cidr *router.CIDR
+		switch strings.ToLower(parts[2]) {
+		case "ipv4":
+			count, err := strconv.Atoi(parts[4])
+			if err != nil {
+				return nil
+			}
+			ip := net.ParseIP(parts[3]).To4()
+			if ip == nil {
+				return newError("invalid IP: ", parts[3])
+			}
+			mask := uint32(math.Floor(math.Log2(float64(count)) + 0.5))
+			cidr = &router.CIDR{Ip: []byte(ip), Prefix: 32 - mask}
+		case "ipv6":
+			prefix, err := strconv.Atoi(parts[4])
+			if err != nil {
+				return nil
+			}
+			ip := net.ParseIP(parts[3]).To16()
+			if ip == nil {
+				return newError("invalid IP: ", parts[3])
+			}
+			cidr = &router.CIDR{Ip: []byte(ip), Prefix: uint32(prefix)}
+		default:
+			return nil
+		}
+		entry := getGeoIP(entries, strings.ToUpper(parts[1]))
+		entry.Cidr = append(entry.Cidr, cidr)
+		return nil
+	})
+}
+
+func getGeoIP(entries map[string]*router.GeoIP, code string) *router.GeoIP {
+	entry, found := entries[code]
+	if !found {
+		entry = &router.GeoIP{CountryCode: code}
+		entries[code] = entry
+	}
+	return entry
+}
+
+func loadSiteDir(dir string) ([]*router.GeoSite, error) {
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	sites := make([]*router.GeoSite, 0, len(files))
+	for code, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		entry := &router.GeoSite{CountryCode: code}
+		err = readLines(file, func(line string) error {
+			domain, err := router.ParseDomain(line)
+			if err != nil {
+				return newError("invalid line in ", path).Base(err)
+			}
+			entry.Domain = append(entry.Domain, domain)
+			return nil
+		})
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		sites = append(sites, entry)
+	}
+	sort.Slice(sites, func(i, j int) bool {
+		return sites[i].CountryCode < sites[j].CountryCode
+	})
+	return sites, nil
+}
+
+func writeFile(path string, message proto.Message) {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		log.Fatalf("Failed to marshal %s: %v", path, err)
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	if len(*ipDir) == 0 && len(*apnic) == 0 && len(*siteDir) == 0 {
+		log.Fatal("No input. Set -ipdir, -apnic or -sitedir.")
+	}
+
+	if len(*ipDir) > 0 || len(*apnic) > 0 {
+		entries := make(map[string]*router.GeoIP)
+		if len(*apnic) > 0 {
+			if err := loadAPNIC(*apnic, entries); err != nil {
+				log.Fatalf("Failed to load APNIC stats: %v", err)
+			}
+		}
+		if len(*ipDir) > 0 {
+			if err := loadIPDir(*ipDir, entries); err != nil {
+				log.Fatalf("Failed to load IP lists: %v", err)
+			}
+		}
+		list := &router.GeoIPList{
+			Version:  router.GeoDataVersion,
+			Revision: *revision,
+		}
+		for _, entry := range entries {
+			list.Entry = append(list.Entry, entry)
+		}
+		sort.Slice(list.Entry, func(i, j int) bool {
+			return list.Entry[i].CountryCode < list.Entry[j].CountryCode
+		})
+		writeFile(*ipOut, list)
+		log.Printf("%d GeoIP entries written to %s", len(list.Entry), *ipOut)
+	}
+
+	if len(*siteDir) > 0 {
+		sites, err := loadSiteDir(*siteDir)
+		if err != nil {
+			log.Fatalf("Failed to load domain lists: %v", err)
+		}
+		writeFile(*siteOut, &router.GeoSiteList{
+			Version:  router.GeoDataVersion,
+			Revision: *revision,
+			Entry:    sites,
+		})
+		log.Printf("%d GeoSite entries written to %s", len(sites), *siteOut)
+	}
+}
@@ -2,8 +2,12 @@ package kcp
 
 import (
 	"sync"
+
+	"v2ray.com/core/app/metrics"
 )
 
+var retransmissions = metrics.NewCounter("v2ray_kcp_retransmissions_total", "Number of data segments retransmitted by mKCP.")
+
 type SendingWindow struct {
 	start uint32
 	cap   uint32
@@ -177,6 +181,10 @@ func (v *SendingWindow) Flush(current uint32, rto uint32, maxInFlightSize uint32
 		return true
 	})
 
+	if lost > 0 {
+		retransmissions.Add(int64(lost))
+	}
+
 	if v.onPacketLoss != nil && inFlightSize > 0 && v.totalInFlightSize != 0 {
 		rate := lost * 100 / v.totalInFlightSize
 		v.onPacketLoss(rate)
@@ -0,0 +1,115 @@
+package ray
+
+import (
+	"time"
+
+	"v2ray.com/core/common/buf"
+)
+
+// StatCounter is a counter that accumulates the number of bytes passing through a stream.
+type StatCounter interface {
+	Add(int64) int64
+}
+
+type statInputStream struct {
+	InputStream
+	counter StatCounter
+}
+
+func (s *statInputStream) Read() (buf.MultiBuffer, error) {
+	mb, err := s.InputStream.Read()
+	s.counter.Add(int64(mb.Len()))
+	return mb, err
+}
+
+func (s *statInputStream) ReadTimeout(timeout time.Duration) (buf.MultiBuffer, error) {
+	mb, err := s.InputStream.ReadTimeout(timeout)
+	s.counter.Add(int64(mb.Len()))
+	return mb, err
+}
+
+type statOutputStream struct {
+	OutputStream
+	counter StatCounter
+}
+
+func (s *statOutputStream) Write(mb buf.MultiBuffer) error {
+	size := mb.Len()
+	if err := s.OutputStream.Write(mb); err != nil {
+		return err
+	}
+	s.counter.Add(int64(size))
+	return nil
+}
+
+// NewStatInputStream returns an InputStream that counts all bytes read from the given stream.
+func NewStatInputStream(stream InputStream, counter StatCounter) InputStream {
+	if counter == nil {
+		return stream
+	}
+	return &statInputStream{
+		InputStream: stream,
+		counter:     counter,
+	}
+}
+
+// NewStatOutputStream returns an OutputStream that counts all bytes written into the given stream.
+func NewStatOutputStream(stream OutputStream, counter StatCounter) OutputStream {
+	if counter == nil {
+		return stream
+	}
+	return &statOutputStream{
+		OutputStream: stream,
+		counter:      counter,
+	}
+}
+
+type statInboundRay struct {
+	input  OutputStream
+	output InputStream
+}
+
+func (r *statInboundRay) InboundInput() OutputStream {
+	return r.input
+}
+
+func (r *statInboundRay) InboundOutput() InputStream {
+	return r.output
+}
+
+// NewStatInboundRay returns an InboundRay that counts the bytes written by the inbound connection as uplink,
+// and the bytes read by the inbound connection as downlink. Either counter may be nil.
+func NewStatInboundRay(r InboundRay, uplink StatCounter, downlink StatCounter) InboundRay {
+	if uplink == nil && downlink == nil {
+		return r
+	}
+	return &statInboundRay{
+		input:  NewStatOutputStream(r.InboundInput(), uplink),
+		output: NewStatInputStream(r.InboundOutput(), downlink),
+	}
+}
+
+type statOutboundRay struct {
+	input  InputStream
+	output OutputStream
+}
+
+func (r *statOutboundRay) OutboundInput() InputStream {
+	return r.input
+}
+
+func (r *statOutboundRay) OutboundOutput() OutputStream {
+	return r.output
+}
+
+// NewStatOutboundRay returns an OutboundRay that counts the bytes read by the outbound connection as uplink,
+// and the bytes written by the outbound connection as downlink. Either counter may be nil.
+func NewStatOutboundRay(r OutboundRay, uplink StatCounter, downlink StatCounter) OutboundRay {
+	if uplink == nil && downlink == nil {
+		return r
+	}
+	return &statOutboundRay{
+		input:  NewStatInputStream(r.OutboundInput(), uplink),
+		output: NewStatOutputStream(r.OutboundOutput(), downlink),
+	}
+}
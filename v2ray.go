@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"sync"
 
 	"v2ray.com/core/app"
 	"v2ray.com/core/app/dispatcher"
@@ -20,6 +21,11 @@ type Server interface {
 
 	// Close closes the V2Ray server. All inbound and outbound connections will be closed immediately.
 	Close()
+
+	// Reload applies the given config to the running server. Inbounds and outbounds are identified by their tags.
+	// Only handlers whose settings are changed are replaced, so connections of other handlers are kept.
+	// Routing rules and DNS hosts are replaced as well. Other changes require a restart.
+	Reload(config *Config) error
 }
 
 // New creates a new V2Ray server with given config.
@@ -29,7 +35,9 @@ func New(config *Config) (Server, error) {
 
 // simpleServer shell of V2Ray.
 type simpleServer struct {
-	space app.Space
+	access sync.Mutex
+	space  app.Space
+	config *Config
 }
 
 // newSimpleServer returns a new Point server based on given configuration.
@@ -45,6 +53,7 @@ func newSimpleServer(config *Config) (*simpleServer, error) {
 	ctx := app.ContextWithSpace(context.Background(), space)
 
 	server.space = space
+	server.config = config
 
 	for _, appSettings := range config.App {
 		settings, err := appSettings.GetInstance()
@@ -5,6 +5,7 @@ import (
 
 	. "v2ray.com/core"
 	"v2ray.com/core/app/proxyman"
+	"v2ray.com/core/app/router"
 	"v2ray.com/core/common/dice"
 	v2net "v2ray.com/core/common/net"
 	"v2ray.com/core/common/protocol"
@@ -12,6 +13,7 @@ import (
 	"v2ray.com/core/common/uuid"
 	_ "v2ray.com/core/main/distro/all"
 	"v2ray.com/core/proxy/dokodemo"
+	"v2ray.com/core/proxy/freedom"
 	"v2ray.com/core/proxy/vmess"
 	"v2ray.com/core/proxy/vmess/outbound"
 	"v2ray.com/core/testing/assert"
@@ -63,3 +65,44 @@ func TestV2RayClose(t *testing.T) {
 
 	server.Close()
 }
+
+func TestTraceRoute(t *testing.T) {
+	assert := assert.On(t)
+
+	config := &Config{
+		App: []*serial.TypedMessage{
+			serial.ToTypedMessage(&router.Config{
+				Rule: []*router.RoutingRule{
+					{
+						Tag:        "vip",
+						UserEmail:  []string{"love@v2ray.com"},
+						InboundTag: []string{"vmess"},
+					},
+				},
+			}),
+		},
+		Outbound: []*proxyman.OutboundHandlerConfig{
+			{
+				Tag:           "vip",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	trace, err := TraceRoute(config, &router.RouteQuery{
+		Destination: v2net.TCPDestination(v2net.DomainAddress("v2ray.com"), 443),
+		InboundTag:  "vmess",
+		User:        "love@v2ray.com",
+	})
+	assert.Error(err).IsNil()
+	assert.Int(trace.MatchedRule).Equals(0)
+	assert.String(trace.OutboundTag).Equals("vip")
+
+	trace, err = TraceRoute(config, &router.RouteQuery{
+		Destination: v2net.TCPDestination(v2net.DomainAddress("v2ray.com"), 443),
+		InboundTag:  "vmess",
+	})
+	assert.Error(err).IsNil()
+	assert.Int(trace.MatchedRule).Equals(-1)
+	assert.String(trace.Rules[0].Reason).Equals("user does not match")
+}